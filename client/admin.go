@@ -0,0 +1,141 @@
+package csclient
+
+import (
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/nodeset-org/hyperdrive-constellation/common/contracts/constellation"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/rocket-pool/node-manager-core/api/client"
+	"github.com/rocket-pool/node-manager-core/api/types"
+)
+
+// Requester for the Constellation admin routes. These are only available if the daemon has them enabled.
+// Each TX builder checks that the node address holds the role the function requires; if export is true, the TX is also
+// exported as an unsigned EIP-1559 transaction for offline signing, and the daemon doesn't need a wallet loaded.
+type AdminRequester struct {
+	context client.IRequesterContext
+}
+
+func NewAdminRequester(context client.IRequesterContext) *AdminRequester {
+	return &AdminRequester{
+		context: context,
+	}
+}
+
+func (r *AdminRequester) GetName() string {
+	return "Admin"
+}
+func (r *AdminRequester) GetRoute() string {
+	return "admin"
+}
+func (r *AdminRequester) GetContext() client.IRequesterContext {
+	return r.context
+}
+
+// Gets which of the Constellation access control roles the node address holds
+func (r *AdminRequester) Roles() (*types.ApiResponse[csapi.AdminRolesData], error) {
+	args := map[string]string{}
+	return client.SendGetRequest[csapi.AdminRolesData](r, "roles", "Roles", args)
+}
+
+// Gets a TX for setting the amount of ETH, in wei, that sub-node operators have to lock when creating a minipool
+func (r *AdminRequester) SetLockAmount(amount *big.Int, export bool) (*types.ApiResponse[csapi.AdminTxData], error) {
+	args := map[string]string{
+		"amount": amount.String(),
+	}
+	return sendAdminTxRequest(r, "set-lock-amount", "SetLockAmount", args, export)
+}
+
+// Gets a TX for setting the most minipools a single sub-node operator can run
+func (r *AdminRequester) SetMaxValidators(maxValidators uint64, export bool) (*types.ApiResponse[csapi.AdminTxData], error) {
+	args := map[string]string{
+		"max-validators": strconv.FormatUint(maxValidators, 10),
+	}
+	return sendAdminTxRequest(r, "set-max-validators", "SetMaxValidators", args, export)
+}
+
+// Gets a TX for setting the highest ratio of WETH to RPL the WETH vault will accept deposits at
+func (r *AdminRequester) SetMaxWethRplRatio(ratio *big.Int, export bool) (*types.ApiResponse[csapi.AdminTxData], error) {
+	args := map[string]string{
+		"ratio": ratio.String(),
+	}
+	return sendAdminTxRequest(r, "set-max-weth-rpl-ratio", "SetMaxWethRplRatio", args, export)
+}
+
+// Gets a TX for setting the share of the WETH vault's rewards that goes to the Treasury, where 1e18 is 100%
+func (r *AdminRequester) SetTreasuryFee(fee *big.Int, export bool) (*types.ApiResponse[csapi.AdminTxData], error) {
+	args := map[string]string{
+		"fee": fee.String(),
+	}
+	return sendAdminTxRequest(r, "set-treasury-fee", "SetTreasuryFee", args, export)
+}
+
+// Gets a TX for setting the share of the WETH vault's rewards that goes to node operators, where 1e18 is 100%
+func (r *AdminRequester) SetNodeOperatorFee(fee *big.Int, export bool) (*types.ApiResponse[csapi.AdminTxData], error) {
+	args := map[string]string{
+		"fee": fee.String(),
+	}
+	return sendAdminTxRequest(r, "set-node-operator-fee", "SetNodeOperatorFee", args, export)
+}
+
+// Gets a TX for setting the lowest ratio of WETH to RPL the RPL vault will accept deposits at
+func (r *AdminRequester) SetMinWethRplRatio(ratio *big.Int, export bool) (*types.ApiResponse[csapi.AdminTxData], error) {
+	args := map[string]string{
+		"ratio": ratio.String(),
+	}
+	return sendAdminTxRequest(r, "set-min-weth-rpl-ratio", "SetMinWethRplRatio", args, export)
+}
+
+// Gets a TX for setting the ratio of RPL to borrowed ETH the OperatorDistributor aims to stake for the supernode, where 1e18 is 100%
+func (r *AdminRequester) SetTargetStakeRatio(ratio *big.Int, export bool) (*types.ApiResponse[csapi.AdminTxData], error) {
+	args := map[string]string{
+		"ratio": ratio.String(),
+	}
+	return sendAdminTxRequest(r, "set-target-stake-ratio", "SetTargetStakeRatio", args, export)
+}
+
+// Gets a TX for sending all of the ETH in the Treasury to the recipient
+func (r *AdminRequester) ClaimEth(recipient common.Address, export bool) (*types.ApiResponse[csapi.AdminTxData], error) {
+	args := map[string]string{
+		"recipient": recipient.Hex(),
+	}
+	return sendAdminTxRequest(r, "claim-eth", "ClaimEth", args, export)
+}
+
+// Gets a TX for sending all of the Treasury's balance of an ERC20 token to the recipient
+func (r *AdminRequester) ClaimToken(token common.Address, recipient common.Address, export bool) (*types.ApiResponse[csapi.AdminTxData], error) {
+	args := map[string]string{
+		"token":     token.Hex(),
+		"recipient": recipient.Hex(),
+	}
+	return sendAdminTxRequest(r, "claim-token", "ClaimToken", args, export)
+}
+
+// Gets a TX for pointing the Directory at a new set of protocol contracts
+func (r *AdminRequester) SetAll(protocol constellation.Protocol, export bool) (*types.ApiResponse[csapi.AdminTxData], error) {
+	args := map[string]string{
+		"whitelist":             protocol.Whitelist.Hex(),
+		"weth-vault":            protocol.WethVault.Hex(),
+		"rpl-vault":             protocol.RplVault.Hex(),
+		"operator-distributor":  protocol.OperatorDistributor.Hex(),
+		"merkle-claim-streamer": protocol.MerkleClaimStreamer.Hex(),
+		"operator-reward":       protocol.OperatorReward.Hex(),
+		"oracle":                protocol.Oracle.Hex(),
+		"price-fetcher":         protocol.PriceFetcher.Hex(),
+		"super-node":            protocol.SuperNode.Hex(),
+		"rocket-storage":        protocol.RocketStorage.Hex(),
+		"weth":                  protocol.Weth.Hex(),
+		"sanctions":             protocol.Sanctions.Hex(),
+	}
+	return sendAdminTxRequest(r, "set-all", "SetAll", args, export)
+}
+
+// Send a request to one of the admin TX builders
+func sendAdminTxRequest(r *AdminRequester, method string, requestName string, args map[string]string, export bool) (*types.ApiResponse[csapi.AdminTxData], error) {
+	if export {
+		args["export"] = "true"
+	}
+	return client.SendGetRequest[csapi.AdminTxData](r, method, requestName, args)
+}
@@ -12,10 +12,13 @@ import (
 // Binder for the Constellation API server
 type ApiClient struct {
 	context  client.IRequesterContext
+	Admin    *AdminRequester
+	Events   *EventsRequester
 	Minipool *MinipoolRequester
 	Network  *NetworkRequester
 	Node     *NodeRequester
 	Service  *ServiceRequester
+	Tx       *TxRequester
 	Wallet   *WalletRequester
 }
 
@@ -25,10 +28,13 @@ func NewApiClient(apiUrl *url.URL, logger *slog.Logger, tracer *httptrace.Client
 
 	client := &ApiClient{
 		context:  context,
+		Admin:    NewAdminRequester(context),
+		Events:   NewEventsRequester(context),
 		Minipool: NewMinipoolRequester(context),
 		Network:  NewNetworkRequester(context),
 		Node:     NewNodeRequester(context),
 		Service:  NewServiceRequester(context),
+		Tx:       NewTxRequester(context),
 		Wallet:   NewWalletRequester(context),
 	}
 	return client
@@ -0,0 +1,122 @@
+package csclient
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/rocket-pool/node-manager-core/api/client"
+	"github.com/rocket-pool/node-manager-core/log"
+)
+
+type EventsRequester struct {
+	context client.IRequesterContext
+}
+
+func NewEventsRequester(context client.IRequesterContext) *EventsRequester {
+	return &EventsRequester{
+		context: context,
+	}
+}
+
+func (r *EventsRequester) GetName() string {
+	return "Events"
+}
+func (r *EventsRequester) GetRoute() string {
+	return "events"
+}
+func (r *EventsRequester) GetContext() client.IRequesterContext {
+	return r.context
+}
+
+// Subscribes to the daemon's event stream.
+// If lastEventID is provided, any events after it that the daemon still remembers will be sent first; use 0 to only get new events.
+// Events are delivered on the returned channel until the context is cancelled or the stream ends. Both channels are closed
+// when that happens; if the stream ended because of an error, it's sent on the error channel first.
+func (r *EventsRequester) Subscribe(ctx context.Context, lastEventID uint64) (<-chan csapi.Event, <-chan error, error) {
+	// Create the request
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/%s", r.context.GetAddressBase(), r.GetRoute()), nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating HTTP request: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if lastEventID > 0 {
+		req.Header.Set("Last-Event-ID", strconv.FormatUint(lastEventID, 10))
+	}
+	r.context.GetLogger().Debug("API Request", slog.String(log.MethodKey, http.MethodGet), slog.String(log.QueryKey, req.URL.String()))
+
+	// Open the stream
+	resp, err := r.context.SendRequest(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error subscribing to events: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, nil, fmt.Errorf("error subscribing to events: server responded with %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	events := make(chan csapi.Event)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(errs)
+		defer close(events)
+		defer resp.Body.Close()
+
+		err := readEventStream(ctx, resp.Body, events)
+		if err != nil && ctx.Err() == nil {
+			errs <- err
+		}
+	}()
+	return events, errs, nil
+}
+
+// Read events from a Server-Sent Events stream until it ends
+func readEventStream(ctx context.Context, body io.Reader, events chan<- csapi.Event) error {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	data := []string{}
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		// A blank line ends the event
+		if line == "" {
+			if len(data) == 0 {
+				continue
+			}
+			var event csapi.Event
+			err := json.Unmarshal([]byte(strings.Join(data, "\n")), &event)
+			if err != nil {
+				return fmt.Errorf("error deserializing event: %w", err)
+			}
+			data = data[:0]
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return nil
+			}
+			continue
+		}
+
+		// Comments are used as keepalives; the ID and type are already in the event body
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		if field == "data" {
+			data = append(data, strings.TrimPrefix(value, " "))
+		}
+	}
+	err := scanner.Err()
+	if err != nil {
+		return fmt.Errorf("error reading event stream: %w", err)
+	}
+	return fmt.Errorf("event stream closed by the daemon")
+}
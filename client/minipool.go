@@ -31,8 +31,16 @@ func (r *MinipoolRequester) GetContext() client.IRequesterContext {
 }
 
 // Close
-func (r *MinipoolRequester) Close(addresses []common.Address) (*types.ApiResponse[types.BatchTxInfoData], error) {
-	return sendMultiMinipoolRequest[types.BatchTxInfoData](r, "close", "Close", addresses, nil)
+func (r *MinipoolRequester) Close(addresses []common.Address) (*types.ApiResponse[csapi.MinipoolCloseData], error) {
+	return sendMultiMinipoolRequest[csapi.MinipoolCloseData](r, "close", "Close", addresses, nil)
+}
+
+// Close, also exporting the TXs as unsigned EIP-1559 transactions for offline signing
+func (r *MinipoolRequester) ExportClose(addresses []common.Address) (*types.ApiResponse[csapi.MinipoolCloseData], error) {
+	args := map[string]string{
+		"export": "true",
+	}
+	return sendMultiMinipoolRequest[csapi.MinipoolCloseData](r, "close", "ExportClose", addresses, args)
 }
 
 // Get close details
@@ -40,6 +48,14 @@ func (r *MinipoolRequester) GetCloseDetails() (*types.ApiResponse[csapi.Minipool
 	return client.SendGetRequest[csapi.MinipoolCloseDetailsData](r, "close/details", "GetCloseDetails", nil)
 }
 
+// Get close details for another sub-node's minipools in read-only mode
+func (r *MinipoolRequester) GetCloseDetailsForNode(nodeAddress common.Address) (*types.ApiResponse[csapi.MinipoolCloseDetailsData], error) {
+	args := map[string]string{
+		"node-address": nodeAddress.Hex(),
+	}
+	return client.SendGetRequest[csapi.MinipoolCloseDetailsData](r, "close/details", "GetCloseDetailsForNode", args)
+}
+
 // Deposit to Constellation to create a new minipool
 func (r *MinipoolRequester) Create(salt *big.Int, skipLiquidityCheck bool, skipBalanceCheck bool) (*types.ApiResponse[csapi.MinipoolCreateData], error) {
 	args := map[string]string{
@@ -50,6 +66,17 @@ func (r *MinipoolRequester) Create(salt *big.Int, skipLiquidityCheck bool, skipB
 	return client.SendGetRequest[csapi.MinipoolCreateData](r, "create", "Create", args)
 }
 
+// Deposit to Constellation to create a new minipool, also exporting the TX as an unsigned EIP-1559 transaction for offline signing
+func (r *MinipoolRequester) ExportCreate(salt *big.Int, skipLiquidityCheck bool, skipBalanceCheck bool) (*types.ApiResponse[csapi.MinipoolCreateData], error) {
+	args := map[string]string{
+		"salt":               salt.String(),
+		"skipLiquidityCheck": strconv.FormatBool(skipLiquidityCheck),
+		"skipBalanceCheck":   strconv.FormatBool(skipBalanceCheck),
+		"export":             "true",
+	}
+	return client.SendGetRequest[csapi.MinipoolCreateData](r, "create", "ExportCreate", args)
+}
+
 // Get details of minipools that are eligible for exiting, optionally listing all minipools instead (even ones that are not eligible)
 func (r *MinipoolRequester) GetExitDetails(verbose bool) (*types.ApiResponse[csapi.MinipoolExitDetailsData], error) {
 	args := map[string]string{
@@ -58,6 +85,20 @@ func (r *MinipoolRequester) GetExitDetails(verbose bool) (*types.ApiResponse[csa
 	return client.SendGetRequest[csapi.MinipoolExitDetailsData](r, "exit/details", "GetExitDetails", args)
 }
 
+// Get the exit details of another sub-node's minipools in read-only mode
+func (r *MinipoolRequester) GetExitDetailsForNode(nodeAddress common.Address, verbose bool) (*types.ApiResponse[csapi.MinipoolExitDetailsData], error) {
+	args := map[string]string{
+		"verbose":      strconv.FormatBool(verbose),
+		"node-address": nodeAddress.Hex(),
+	}
+	return client.SendGetRequest[csapi.MinipoolExitDetailsData](r, "exit/details", "GetExitDetailsForNode", args)
+}
+
+// Get the proposals made by this node's validators that paid the wrong fee recipient
+func (r *MinipoolRequester) GetFeeRecipientViolations() (*types.ApiResponse[csapi.MinipoolFeeRecipientViolationsData], error) {
+	return client.SendGetRequest[csapi.MinipoolFeeRecipientViolationsData](r, "fee-recipient-violations", "GetFeeRecipientViolations", nil)
+}
+
 // Submit voluntary exits for minipool validators to the Beacon Chain
 func (r *MinipoolRequester) Exit(infos []csapi.MinipoolValidatorInfo) (*types.ApiResponse[types.SuccessData], error) {
 	body := csapi.MinipoolExitBody{
@@ -74,18 +115,56 @@ func (r *MinipoolRequester) GetPubkeys(includeExited bool) (*types.ApiResponse[c
 	return client.SendGetRequest[csapi.MinipoolGetPubkeysData](r, "get-pubkeys", "GetPubkeys", args)
 }
 
+// Get the minipool address, validator pubkey, and Beacon chain index for each of another sub-node's minipools
+func (r *MinipoolRequester) GetPubkeysForNode(nodeAddress common.Address, includeExited bool) (*types.ApiResponse[csapi.MinipoolGetPubkeysData], error) {
+	args := map[string]string{
+		"includeExited": strconv.FormatBool(includeExited),
+		"node-address":  nodeAddress.Hex(),
+	}
+	return client.SendGetRequest[csapi.MinipoolGetPubkeysData](r, "get-pubkeys", "GetPubkeysForNode", args)
+}
+
+// Get the attestation, proposal, and sync committee performance of each minipool's validator over a range of epochs.
+// Nil epochs will use the daemon's defaults (the most recent epochs that can be reported on).
+func (r *MinipoolRequester) GetPerformance(startEpoch *uint64, endEpoch *uint64) (*types.ApiResponse[csapi.MinipoolPerformanceData], error) {
+	args := map[string]string{}
+	if startEpoch != nil {
+		args["startEpoch"] = strconv.FormatUint(*startEpoch, 10)
+	}
+	if endEpoch != nil {
+		args["endEpoch"] = strconv.FormatUint(*endEpoch, 10)
+	}
+	return client.SendGetRequest[csapi.MinipoolPerformanceData](r, "performance", "GetPerformance", args)
+}
+
 // Get details and transaction info of minipools that are eligible for staking
 func (r *MinipoolRequester) Stake() (*types.ApiResponse[csapi.MinipoolStakeData], error) {
 	args := map[string]string{}
 	return client.SendGetRequest[csapi.MinipoolStakeData](r, "stake", "Stake", args)
 }
 
+// Get details of minipools that are eligible for staking, also exporting the TXs as unsigned EIP-1559 transactions for offline signing
+func (r *MinipoolRequester) ExportStake() (*types.ApiResponse[csapi.MinipoolStakeData], error) {
+	args := map[string]string{
+		"export": "true",
+	}
+	return client.SendGetRequest[csapi.MinipoolStakeData](r, "stake", "ExportStake", args)
+}
+
 // Get all status details for minipools
 func (r *MinipoolRequester) Status() (*types.ApiResponse[csapi.MinipoolStatusData], error) {
 	args := map[string]string{}
 	return client.SendGetRequest[csapi.MinipoolStatusData](r, "status", "Status", args)
 }
 
+// Get all status details for another sub-node's minipools in read-only mode; NodeSet details are only available for the daemon's own node
+func (r *MinipoolRequester) StatusForNode(nodeAddress common.Address) (*types.ApiResponse[csapi.MinipoolStatusData], error) {
+	args := map[string]string{
+		"node-address": nodeAddress.Hex(),
+	}
+	return client.SendGetRequest[csapi.MinipoolStatusData](r, "status", "StatusForNode", args)
+}
+
 // Upload signed voluntary exit messages for minipool validators to the NodeSet server
 func (r *MinipoolRequester) UploadSignedExits(infos []csapi.MinipoolValidatorInfo) (*types.ApiResponse[types.SuccessData], error) {
 	body := csapi.MinipoolUploadSignedExitBody{
@@ -110,3 +189,9 @@ func sendMultiMinipoolRequest[DataType any](r *MinipoolRequester, method string,
 	args["addresses"] = client.MakeBatchArg(addresses)
 	return client.SendGetRequest[DataType](r, method, requestName, args)
 }
+
+// Get the minipool status of each node in the fleet
+func (r *MinipoolRequester) GetFleetStatus() (*types.ApiResponse[csapi.MinipoolFleetStatusData], error) {
+	args := map[string]string{}
+	return client.SendGetRequest[csapi.MinipoolFleetStatusData](r, "fleet-status", "GetFleetStatus", args)
+}
@@ -1,6 +1,9 @@
 package csclient
 
 import (
+	"math/big"
+	"strconv"
+
 	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
 	"github.com/rocket-pool/node-manager-core/api/client"
 	"github.com/rocket-pool/node-manager-core/api/types"
@@ -31,3 +34,36 @@ func (r *NetworkRequester) Stats() (*types.ApiResponse[csapi.NetworkStatsData],
 	args := map[string]string{}
 	return client.SendGetRequest[csapi.NetworkStatsData](r, "stats", "Stats", args)
 }
+
+// Get the gas price forecast and the plan for submitting automatic transactions
+func (r *NetworkRequester) GasPlan() (*types.ApiResponse[csapi.NetworkGasPlanData], error) {
+	args := map[string]string{}
+	return client.SendGetRequest[csapi.NetworkGasPlanData](r, "gas-plan", "GasPlan", args)
+}
+
+// Get the settings of the minipool processing keeper and what it's spent and done so far
+func (r *NetworkRequester) Keeper() (*types.ApiResponse[csapi.NetworkKeeperData], error) {
+	args := map[string]string{}
+	return client.SendGetRequest[csapi.NetworkKeeperData](r, "keeper", "Keeper", args)
+}
+
+// Get how many more minipools Constellation can fund with its current liquidity, and with what the vaults would pass along
+// after deposits of the given amounts (in wei) of ETH and RPL
+func (r *NetworkRequester) Liquidity(ethDeposit *big.Int, rplDeposit *big.Int) (*types.ApiResponse[csapi.NetworkLiquidityData], error) {
+	args := map[string]string{}
+	if ethDeposit != nil {
+		args["eth-deposit"] = ethDeposit.String()
+	}
+	if rplDeposit != nil {
+		args["rpl-deposit"] = rplDeposit.String()
+	}
+	return client.SendGetRequest[csapi.NetworkLiquidityData](r, "liquidity", "Liquidity", args)
+}
+
+// Get the upgrades and admin changes of the Constellation contracts since the given block, from the daemon's index of contract events
+func (r *NetworkRequester) ProxyEvents(startBlock uint64) (*types.ApiResponse[csapi.NetworkProxyEventsData], error) {
+	args := map[string]string{
+		"start-block": strconv.FormatUint(startBlock, 10),
+	}
+	return client.SendGetRequest[csapi.NetworkProxyEventsData](r, "proxy-events", "ProxyEvents", args)
+}
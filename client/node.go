@@ -1,6 +1,8 @@
 package csclient
 
 import (
+	"strconv"
+
 	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
 	"github.com/rocket-pool/node-manager-core/api/client"
 	"github.com/rocket-pool/node-manager-core/api/types"
@@ -32,8 +34,47 @@ func (r *NodeRequester) GetRegistrationStatus() (*types.ApiResponse[csapi.NodeGe
 	return client.SendGetRequest[csapi.NodeGetRegistrationStatusData](r, "get-registration-status", "GetRegistrationStatus", args)
 }
 
+// Runs a checklist of everything the node needs to be able to create minipools, with hints on how to fix any problems
+func (r *NodeRequester) Diagnose() (*types.ApiResponse[csapi.NodeDiagnoseData], error) {
+	args := map[string]string{}
+	return client.SendGetRequest[csapi.NodeDiagnoseData](r, "diagnose", "Diagnose", args)
+}
+
+// Gets the minipools created and destroyed by the node since the given block, from the daemon's index of Constellation contract events
+func (r *NodeRequester) MinipoolEvents(startBlock uint64) (*types.ApiResponse[csapi.NodeMinipoolEventsData], error) {
+	args := map[string]string{
+		"start-block": strconv.FormatUint(startBlock, 10),
+	}
+	return client.SendGetRequest[csapi.NodeMinipoolEventsData](r, "minipool-events", "MinipoolEvents", args)
+}
+
+// Gets the node's lockup, bond, fees, and rewards across its minipools, with an APR estimated from the given number of days of Beacon balance history
+func (r *NodeRequester) Position(aprDays uint64) (*types.ApiResponse[csapi.NodePositionData], error) {
+	args := map[string]string{
+		"apr-days": strconv.FormatUint(aprDays, 10),
+	}
+	return client.SendGetRequest[csapi.NodePositionData](r, "position", "Position", args)
+}
+
+// Gets the node's position, also exporting it as CSV for accounting
+func (r *NodeRequester) ExportPosition(aprDays uint64) (*types.ApiResponse[csapi.NodePositionData], error) {
+	args := map[string]string{
+		"apr-days": strconv.FormatUint(aprDays, 10),
+		"export":   "true",
+	}
+	return client.SendGetRequest[csapi.NodePositionData](r, "position", "ExportPosition", args)
+}
+
 // Gets a TX for registering the node with Constellation
 func (r *NodeRequester) Register() (*types.ApiResponse[csapi.NodeRegisterData], error) {
 	args := map[string]string{}
 	return client.SendGetRequest[csapi.NodeRegisterData](r, "register", "Register", args)
 }
+
+// Gets a TX for registering the node with Constellation, also exporting it as an unsigned EIP-1559 transaction for offline signing
+func (r *NodeRequester) ExportRegister() (*types.ApiResponse[csapi.NodeRegisterData], error) {
+	args := map[string]string{
+		"export": "true",
+	}
+	return client.SendGetRequest[csapi.NodeRegisterData](r, "register", "ExportRegister", args)
+}
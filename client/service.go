@@ -1,6 +1,9 @@
 package csclient
 
 import (
+	"fmt"
+	"time"
+
 	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
 	"github.com/rocket-pool/node-manager-core/api/client"
 	"github.com/rocket-pool/node-manager-core/api/types"
@@ -36,7 +39,48 @@ func (r *ServiceRequester) GetNetworkSettings() (*types.ApiResponse[csapi.Servic
 	return client.SendGetRequest[csapi.ServiceGetNetworkSettingsData](r, "get-network-settings", "GetNetworkSettings", nil)
 }
 
+// Reloads the network settings files, switching to the new contracts if the selected network's settings changed.
+// If the new settings are invalid, the daemon keeps using its current ones.
+func (r *ServiceRequester) ReloadSettings() (*types.ApiResponse[csapi.ServiceReloadSettingsData], error) {
+	return client.SendPostRequest[csapi.ServiceReloadSettingsData](r, "reload-settings", "ReloadSettings", csapi.ServiceReloadSettingsBody{})
+}
+
+// Gets the upcoming proposal and sync committee duties for the node's validators, and the longest window without any of them
+func (r *ServiceRequester) GetMaintenanceWindow() (*types.ApiResponse[csapi.ServiceMaintenanceWindowData], error) {
+	return client.SendGetRequest[csapi.ServiceMaintenanceWindowData](r, "maintenance-window", "GetMaintenanceWindow", nil)
+}
+
+// Restarts the Constellation validator client. Unless force is set, the restart will be blocked if
+// it would overlap one of the node's scheduled proposal or sync committee duties.
+func (r *ServiceRequester) RestartVc(force bool) (*types.ApiResponse[csapi.ServiceRestartVcData], error) {
+	body := csapi.ServiceRestartVcBody{
+		Force: force,
+	}
+	return client.SendPostRequest[csapi.ServiceRestartVcData](r, "restart-vc", "RestartVc", body)
+}
+
+// Gets the audit log of state-changing API calls made between the start and end times; zero times leave that end of the range open
+func (r *ServiceRequester) GetAuditLog(start time.Time, end time.Time) (*types.ApiResponse[csapi.ServiceAuditLogData], error) {
+	args := map[string]string{}
+	if !start.IsZero() {
+		args["start"] = start.Format(time.RFC3339)
+	}
+	if !end.IsZero() {
+		args["end"] = end.Format(time.RFC3339)
+	}
+	return client.SendGetRequest[csapi.ServiceAuditLogData](r, "audit-log", "GetAuditLog", args)
+}
+
 // Gets the version of the daemon
 func (r *ServiceRequester) Version() (*types.ApiResponse[csapi.ServiceVersionData], error) {
 	return client.SendGetRequest[csapi.ServiceVersionData](r, "version", "Version", nil)
 }
+
+// Gets the health and readiness of the daemon's task loop
+func (r *ServiceRequester) GetHealth() (*types.ApiResponse[csapi.ServiceHealthData], error) {
+	response, err := client.RawGetRequest[csapi.ServiceHealthData](r.context, "health", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error during %s GetHealth request: %w", r.GetName(), err)
+	}
+	return response, nil
+}
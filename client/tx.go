@@ -0,0 +1,66 @@
+package csclient
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/rocket-pool/node-manager-core/api/client"
+	"github.com/rocket-pool/node-manager-core/api/types"
+)
+
+type TxRequester struct {
+	context client.IRequesterContext
+}
+
+func NewTxRequester(context client.IRequesterContext) *TxRequester {
+	return &TxRequester{
+		context: context,
+	}
+}
+
+func (r *TxRequester) GetName() string {
+	return "TX"
+}
+func (r *TxRequester) GetRoute() string {
+	return "tx"
+}
+func (r *TxRequester) GetContext() client.IRequesterContext {
+	return r.context
+}
+
+// Get the status of a transaction
+func (r *TxRequester) GetStatus(hash common.Hash) (*types.ApiResponse[csapi.TxStatusData], error) {
+	args := map[string]string{
+		"hash": hash.Hex(),
+	}
+	return client.SendGetRequest[csapi.TxStatusData](r, "status", "GetStatus", args)
+}
+
+// Submit a transaction that was signed offline. The daemon tracks it until it's included; use GetStatus to check on it.
+func (r *TxRequester) SubmitSigned(signedTx []byte) (*types.ApiResponse[csapi.TxStatusData], error) {
+	body := csapi.TxSubmitSignedBody{
+		SignedTx: signedTx,
+	}
+	return client.SendPostRequest[csapi.TxStatusData](r, "submit-signed", "SubmitSigned", body)
+}
+
+// Get the transactions submitted by the daemon that are still pending
+func (r *TxRequester) GetPending() (*types.ApiResponse[csapi.TxPendingData], error) {
+	args := map[string]string{}
+	return client.SendGetRequest[csapi.TxPendingData](r, "pending", "GetPending", args)
+}
+
+// Replace a pending transaction with a copy that pays higher fees
+func (r *TxRequester) SpeedUp(nonce uint64) (*types.ApiResponse[csapi.TxReplaceData], error) {
+	body := csapi.TxReplaceBody{
+		Nonce: nonce,
+	}
+	return client.SendPostRequest[csapi.TxReplaceData](r, "speed-up", "SpeedUp", body)
+}
+
+// Cancel a pending transaction by replacing it with a 0-value transfer to the node's own address
+func (r *TxRequester) Cancel(nonce uint64) (*types.ApiResponse[csapi.TxReplaceData], error) {
+	body := csapi.TxReplaceBody{
+		Nonce: nonce,
+	}
+	return client.SendPostRequest[csapi.TxReplaceData](r, "cancel", "Cancel", body)
+}
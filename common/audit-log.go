@@ -0,0 +1,201 @@
+package cscommon
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/services"
+)
+
+const (
+	auditLogFilename string = "audit_log"
+
+	// The longest line the audit log reader will accept; request bodies for large batches can get fairly big
+	auditLogMaxLineSize int = 16 * 1024 * 1024
+)
+
+// An append-only record of the state-changing API calls the daemon has served.
+// Entries are stored one per line, and each one is hashed along with the hash of the entry before it so tampering can be detected.
+type AuditLog struct {
+	path         string
+	nextSequence uint64
+	lastHash     common.Hash
+	lock         *sync.Mutex
+}
+
+// Result of checking the integrity of the audit log
+type AuditLogVerification struct {
+	// The number of entries in the log
+	TotalEntries uint64
+
+	// True if every entry's hash is correct and links to the entry before it
+	Intact bool
+
+	// The sequence number of the first entry that failed verification, if the chain isn't intact
+	InvalidSequence uint64
+}
+
+// Create a new audit log, picking up where the existing log on disk left off if present
+func NewAuditLog(sp services.IModuleServiceProvider) (*AuditLog, error) {
+	return newAuditLog(filepath.Join(sp.GetModuleDir(), auditLogFilename))
+}
+
+// Create a new audit log at the given path
+func newAuditLog(path string) (*AuditLog, error) {
+	l := &AuditLog{
+		path: path,
+		lock: &sync.Mutex{},
+	}
+
+	// Find the last entry
+	entries, err := l.readEntries()
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) > 0 {
+		last := entries[len(entries)-1]
+		l.nextSequence = last.Sequence + 1
+		l.lastHash = last.Hash
+	}
+	return l, nil
+}
+
+// Add an entry to the log; its sequence number and hashes are assigned here
+func (l *AuditLog) Append(entry csapi.AuditLogEntry) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	entry.Sequence = l.nextSequence
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+	entry.Time = entry.Time.UTC()
+	entry.PreviousHash = l.lastHash
+	hash, err := hashAuditLogEntry(entry)
+	if err != nil {
+		return err
+	}
+	entry.Hash = hash
+
+	// Serialize it
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("error serializing audit log entry: %w", err)
+	}
+	line = append(line, '\n')
+
+	// Append it to the file and make sure it's on disk before moving on
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, fileMode)
+	if err != nil {
+		return fmt.Errorf("error opening audit log [%s]: %w", l.path, err)
+	}
+	defer file.Close()
+	_, err = file.Write(line)
+	if err != nil {
+		return fmt.Errorf("error writing audit log entry: %w", err)
+	}
+	err = file.Sync()
+	if err != nil {
+		return fmt.Errorf("error syncing audit log: %w", err)
+	}
+
+	l.nextSequence++
+	l.lastHash = hash
+	return nil
+}
+
+// Get the entries recorded between the start and end times (inclusive), along with the integrity of the full log.
+// Zero times leave that end of the range open.
+func (l *AuditLog) GetEntries(start time.Time, end time.Time) ([]csapi.AuditLogEntry, AuditLogVerification, error) {
+	l.lock.Lock()
+	entries, err := l.readEntries()
+	l.lock.Unlock()
+	if err != nil {
+		return nil, AuditLogVerification{}, err
+	}
+
+	// Verify the chain
+	verification := AuditLogVerification{
+		TotalEntries: uint64(len(entries)),
+		Intact:       true,
+	}
+	previousHash := common.Hash{}
+	for i, entry := range entries {
+		hash, err := hashAuditLogEntry(entry)
+		if err != nil {
+			return nil, AuditLogVerification{}, err
+		}
+		if entry.Sequence != uint64(i) || entry.PreviousHash != previousHash || entry.Hash != hash {
+			verification.Intact = false
+			verification.InvalidSequence = uint64(i)
+			break
+		}
+		previousHash = entry.Hash
+	}
+
+	// Filter by time
+	filtered := []csapi.AuditLogEntry{}
+	for _, entry := range entries {
+		if !start.IsZero() && entry.Time.Before(start) {
+			continue
+		}
+		if !end.IsZero() && entry.Time.After(end) {
+			continue
+		}
+		filtered = append(filtered, entry)
+	}
+	return filtered, verification, nil
+}
+
+// Read all of the entries from disk
+func (l *AuditLog) readEntries() ([]csapi.AuditLogEntry, error) {
+	file, err := os.Open(l.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return []csapi.AuditLogEntry{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error opening audit log [%s]: %w", l.path, err)
+	}
+	defer file.Close()
+
+	entries := []csapi.AuditLogEntry{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), auditLogMaxLineSize)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var entry csapi.AuditLogEntry
+		err = json.Unmarshal(line, &entry)
+		if err != nil {
+			return nil, fmt.Errorf("error deserializing audit log entry %d: %w", len(entries), err)
+		}
+		entries = append(entries, entry)
+	}
+	err = scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("error reading audit log: %w", err)
+	}
+	return entries, nil
+}
+
+// Get the hash of an audit log entry, which covers everything but the hash itself
+func hashAuditLogEntry(entry csapi.AuditLogEntry) (common.Hash, error) {
+	entry.Hash = common.Hash{}
+	bytes, err := json.Marshal(entry)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error serializing audit log entry %d for hashing: %w", entry.Sequence, err)
+	}
+	return crypto.Keccak256Hash(bytes), nil
+}
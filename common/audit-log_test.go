@@ -0,0 +1,130 @@
+package cscommon
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/stretchr/testify/require"
+)
+
+// Make sure entries are chained together and the chain verifies, including after the log is reopened
+func TestAuditLogChain(t *testing.T) {
+	path := filepath.Join(t.TempDir(), auditLogFilename)
+	auditLog, err := newAuditLog(path)
+	require.NoError(t, err)
+	appendAuditLogEntries(t, auditLog, "/minipool/create", "/minipool/stake")
+
+	// Reopen it and keep going from where it left off
+	auditLog, err = newAuditLog(path)
+	require.NoError(t, err)
+	appendAuditLogEntries(t, auditLog, "/minipool/exit")
+
+	entries, verification, err := auditLog.GetEntries(time.Time{}, time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), verification.TotalEntries)
+	require.True(t, verification.Intact)
+	require.Len(t, entries, 3)
+	previousHash := common.Hash{}
+	for i, route := range []string{"/minipool/create", "/minipool/stake", "/minipool/exit"} {
+		require.Equal(t, uint64(i), entries[i].Sequence)
+		require.Equal(t, route, entries[i].Route)
+		require.Equal(t, previousHash, entries[i].PreviousHash)
+		require.NotEqual(t, common.Hash{}, entries[i].Hash)
+		previousHash = entries[i].Hash
+	}
+}
+
+// Make sure editing an entry on disk breaks the chain at that entry
+func TestAuditLogTamperedEntry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), auditLogFilename)
+	auditLog, err := newAuditLog(path)
+	require.NoError(t, err)
+	appendAuditLogEntries(t, auditLog, "/minipool/create", "/minipool/stake", "/minipool/exit")
+
+	// Change the route of the second entry without touching its hash
+	rewriteAuditLog(t, path, func(entries []csapi.AuditLogEntry) []csapi.AuditLogEntry {
+		entries[1].Route = "/node/register"
+		return entries
+	})
+
+	_, verification, err := auditLog.GetEntries(time.Time{}, time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), verification.TotalEntries)
+	require.False(t, verification.Intact)
+	require.Equal(t, uint64(1), verification.InvalidSequence)
+}
+
+// Make sure removing an entry from the middle of the log breaks the chain where it was
+func TestAuditLogDeletedEntry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), auditLogFilename)
+	auditLog, err := newAuditLog(path)
+	require.NoError(t, err)
+	appendAuditLogEntries(t, auditLog, "/minipool/create", "/minipool/stake", "/minipool/exit")
+
+	rewriteAuditLog(t, path, func(entries []csapi.AuditLogEntry) []csapi.AuditLogEntry {
+		return append(entries[:1], entries[2:]...)
+	})
+
+	_, verification, err := auditLog.GetEntries(time.Time{}, time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), verification.TotalEntries)
+	require.False(t, verification.Intact)
+	require.Equal(t, uint64(1), verification.InvalidSequence)
+}
+
+// Make sure the time filter only returns the entries in the range
+func TestAuditLogTimeFilter(t *testing.T) {
+	auditLog, err := newAuditLog(filepath.Join(t.TempDir(), auditLogFilename))
+	require.NoError(t, err)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, route := range []string{"/minipool/create", "/minipool/stake", "/minipool/exit"} {
+		err := auditLog.Append(csapi.AuditLogEntry{
+			Time:  start.Add(time.Duration(i) * time.Hour),
+			Route: route,
+		})
+		require.NoError(t, err)
+	}
+
+	entries, verification, err := auditLog.GetEntries(start.Add(30*time.Minute), start.Add(2*time.Hour))
+	require.NoError(t, err)
+	require.True(t, verification.Intact)
+	require.Equal(t, uint64(3), verification.TotalEntries)
+	require.Len(t, entries, 2)
+	require.Equal(t, "/minipool/stake", entries[0].Route)
+	require.Equal(t, "/minipool/exit", entries[1].Route)
+}
+
+// Append an entry for each of the given routes
+func appendAuditLogEntries(t *testing.T, auditLog *AuditLog, routes ...string) {
+	for _, route := range routes {
+		err := auditLog.Append(csapi.AuditLogEntry{
+			Method: "POST",
+			Route:  route,
+		})
+		require.NoError(t, err)
+	}
+}
+
+// Rewrite the audit log on disk with a modified set of entries, keeping their stored hashes
+func rewriteAuditLog(t *testing.T, path string, modify func([]csapi.AuditLogEntry) []csapi.AuditLogEntry) {
+	auditLog, err := newAuditLog(path)
+	require.NoError(t, err)
+	entries, err := auditLog.readEntries()
+	require.NoError(t, err)
+	entries = modify(entries)
+
+	var buffer bytes.Buffer
+	for _, entry := range entries {
+		line, err := json.Marshal(entry)
+		require.NoError(t, err)
+		buffer.Write(line)
+		buffer.WriteByte('\n')
+	}
+	require.NoError(t, os.WriteFile(path, buffer.Bytes(), fileMode))
+}
@@ -0,0 +1,270 @@
+package cscommon
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	hdconfig "github.com/nodeset-org/hyperdrive-daemon/shared/config"
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/rocket-pool/node-manager-core/utils"
+)
+
+const (
+	beaconApiProposerDutiesPath string = "/eth/v1/validator/duties/proposer/%d"
+	beaconApiSyncDutiesPath     string = "/eth/v1/validator/duties/sync/%d"
+	beaconApiBlockPath          string = "/eth/v2/beacon/blocks/%d"
+	beaconApiContentType        string = "application/json"
+)
+
+// A proposal a validator is scheduled for
+type ProposerDuty struct {
+	ValidatorIndex string
+	Slot           uint64
+}
+
+// The parts of a Beacon block used to check on the node's validators
+type BlockDetails struct {
+	Slot                 uint64
+	ProposerIndex        string
+	Graffiti             string
+	HasExecutionPayload  bool
+	FeeRecipient         common.Address
+	ExecutionBlockNumber uint64
+	Attestations         []beacon.AttestationInfo
+
+	// True if the block has a sync aggregate, which covers the block in the previous slot
+	HasSyncAggregate  bool
+	SyncCommitteeBits []byte
+}
+
+// Check if the sync committee member at the given position participated in the block's sync aggregate
+func (b *BlockDetails) SyncCommitteeBitAt(position uint64) bool {
+	byteIndex := position / 8
+	if byteIndex >= uint64(len(b.SyncCommitteeBits)) {
+		return false
+	}
+	return b.SyncCommitteeBits[byteIndex]&(1<<(position%8)) != 0
+}
+
+// Raw Beacon API responses
+type proposerDutiesResponse struct {
+	Data []struct {
+		ValidatorIndex string         `json:"validator_index"`
+		Slot           utils.Uinteger `json:"slot"`
+	} `json:"data"`
+}
+type syncDutiesResponse struct {
+	Data []struct {
+		ValidatorIndex       string           `json:"validator_index"`
+		SyncCommitteeIndices []utils.Uinteger `json:"validator_sync_committee_indices"`
+	} `json:"data"`
+}
+type blockResponse struct {
+	Data struct {
+		Message struct {
+			Slot          utils.Uinteger `json:"slot"`
+			ProposerIndex string         `json:"proposer_index"`
+			Body          struct {
+				Graffiti     utils.ByteArray `json:"graffiti"`
+				Attestations []struct {
+					AggregationBits string `json:"aggregation_bits"`
+					Data            struct {
+						Slot  utils.Uinteger `json:"slot"`
+						Index utils.Uinteger `json:"index"`
+					} `json:"data"`
+				} `json:"attestations"`
+				SyncAggregate *struct {
+					SyncCommitteeBits utils.ByteArray `json:"sync_committee_bits"`
+				} `json:"sync_aggregate"`
+				ExecutionPayload *struct {
+					FeeRecipient utils.ByteArray `json:"fee_recipient"`
+					BlockNumber  utils.Uinteger  `json:"block_number"`
+				} `json:"execution_payload"`
+			} `json:"body"`
+		} `json:"message"`
+	} `json:"data"`
+}
+
+// Reads the parts of the Beacon API that the Beacon client doesn't expose, such as the slots of proposer duties,
+// sync committee positions, and block graffiti and sync aggregates.
+// Requests go to the primary Beacon Node, and to the fallback one if it's enabled and the primary fails.
+type BeaconApiClient struct {
+	urls   []string
+	client *http.Client
+}
+
+// Create a new Beacon API client for the Beacon Nodes in the Hyperdrive config
+func NewBeaconApiClient(hdCfg *hdconfig.HyperdriveConfig) *BeaconApiClient {
+	primaryUrl, fallbackUrl := hdCfg.GetBeaconNodeUrls()
+	urls := []string{primaryUrl}
+	if fallbackUrl != "" {
+		urls = append(urls, fallbackUrl)
+	}
+	return &BeaconApiClient{
+		urls: urls,
+		client: &http.Client{
+			Timeout: time.Duration(hdCfg.ClientTimeout.Value) * time.Second,
+		},
+	}
+}
+
+// Get the proposals scheduled in the given epoch for the validators with the given indices
+func (c *BeaconApiClient) GetProposerDuties(ctx context.Context, indices []string, epoch uint64) ([]ProposerDuty, error) {
+	body, exists, err := c.request(ctx, http.MethodGet, fmt.Sprintf(beaconApiProposerDutiesPath, epoch), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting proposer duties for epoch %d: %w", epoch, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("proposer duties for epoch %d were not found", epoch)
+	}
+	var response proposerDutiesResponse
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding proposer duties for epoch %d: %w", epoch, err)
+	}
+
+	indexMap := make(map[string]bool, len(indices))
+	for _, index := range indices {
+		indexMap[index] = true
+	}
+	duties := []ProposerDuty{}
+	for _, duty := range response.Data {
+		if indexMap[duty.ValidatorIndex] {
+			duties = append(duties, ProposerDuty{
+				ValidatorIndex: duty.ValidatorIndex,
+				Slot:           uint64(duty.Slot),
+			})
+		}
+	}
+	return duties, nil
+}
+
+// Get the positions in the sync committee for the given epoch of each of the validators with the given indices.
+// Validators that aren't in the committee are left out. Beacon Nodes only serve this for the current and next sync
+// committee periods.
+func (c *BeaconApiClient) GetSyncCommitteePositions(ctx context.Context, indices []string, epoch uint64) (map[string][]uint64, error) {
+	body, exists, err := c.request(ctx, http.MethodPost, fmt.Sprintf(beaconApiSyncDutiesPath, epoch), indices)
+	if err != nil {
+		return nil, fmt.Errorf("error getting sync duties for epoch %d: %w", epoch, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("sync duties for epoch %d were not found", epoch)
+	}
+	var response syncDutiesResponse
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding sync duties for epoch %d: %w", epoch, err)
+	}
+
+	positions := map[string][]uint64{}
+	for _, duty := range response.Data {
+		for _, position := range duty.SyncCommitteeIndices {
+			positions[duty.ValidatorIndex] = append(positions[duty.ValidatorIndex], uint64(position))
+		}
+	}
+	return positions, nil
+}
+
+// Get the block in the given slot; the bool will be false if the slot was missed
+func (c *BeaconApiClient) GetBlock(ctx context.Context, slot uint64) (BlockDetails, bool, error) {
+	body, exists, err := c.request(ctx, http.MethodGet, fmt.Sprintf(beaconApiBlockPath, slot), nil)
+	if err != nil {
+		return BlockDetails{}, false, fmt.Errorf("error getting block for slot %d: %w", slot, err)
+	}
+	if !exists {
+		return BlockDetails{}, false, nil
+	}
+	var response blockResponse
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return BlockDetails{}, false, fmt.Errorf("error decoding block for slot %d: %w", slot, err)
+	}
+
+	message := response.Data.Message
+	block := BlockDetails{
+		Slot:          uint64(message.Slot),
+		ProposerIndex: message.ProposerIndex,
+		Graffiti:      strings.TrimRight(string(message.Body.Graffiti), "\x00"),
+	}
+	if message.Body.ExecutionPayload != nil {
+		block.HasExecutionPayload = true
+		block.FeeRecipient = common.BytesToAddress(message.Body.ExecutionPayload.FeeRecipient)
+		block.ExecutionBlockNumber = uint64(message.Body.ExecutionPayload.BlockNumber)
+	}
+	if message.Body.SyncAggregate != nil {
+		block.HasSyncAggregate = true
+		block.SyncCommitteeBits = message.Body.SyncAggregate.SyncCommitteeBits
+	}
+	for i, attestation := range message.Body.Attestations {
+		info := beacon.AttestationInfo{
+			SlotIndex:      uint64(attestation.Data.Slot),
+			CommitteeIndex: uint64(attestation.Data.Index),
+		}
+		info.AggregationBits, err = hex.DecodeString(utils.RemovePrefix(attestation.AggregationBits))
+		if err != nil {
+			return BlockDetails{}, false, fmt.Errorf("error decoding aggregation bits for attestation %d of block %d: %w", i, slot, err)
+		}
+		block.Attestations = append(block.Attestations, info)
+	}
+	return block, true, nil
+}
+
+// Send a request to the Beacon Nodes, trying the fallback if the primary fails.
+// The bool will be false if the resource wasn't found.
+func (c *BeaconApiClient) request(ctx context.Context, method string, path string, requestBody any) ([]byte, bool, error) {
+	errs := []error{}
+	for _, url := range c.urls {
+		body, exists, err := c.requestFrom(ctx, method, url+path, requestBody)
+		if err == nil {
+			return body, exists, nil
+		}
+		errs = append(errs, err)
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, false, errors.Join(errs...)
+}
+
+// Send a request to a single Beacon Node
+func (c *BeaconApiClient) requestFrom(ctx context.Context, method string, url string, requestBody any) ([]byte, bool, error) {
+	var bodyReader io.Reader
+	if requestBody != nil {
+		bodyBytes, err := json.Marshal(requestBody)
+		if err != nil {
+			return nil, false, fmt.Errorf("error serializing request body: %w", err)
+		}
+		bodyReader = bytes.NewReader(bodyBytes)
+	}
+	request, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+	if err != nil {
+		return nil, false, fmt.Errorf("error creating %s request to [%s]: %w", method, url, err)
+	}
+	request.Header.Set("Content-Type", beaconApiContentType)
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		return nil, false, fmt.Errorf("error sending %s request to [%s]: %w", method, url, err)
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, false, fmt.Errorf("error reading response from [%s]: %w", url, err)
+	}
+	if response.StatusCode == http.StatusNotFound {
+		return nil, false, nil
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, false, fmt.Errorf("request to [%s] failed with status %d: %s", url, response.StatusCode, string(body))
+	}
+	return body, true, nil
+}
@@ -2,16 +2,46 @@ package cscommon
 
 import (
 	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/nodeset-org/hyperdrive-constellation/common/contracts/constellation"
 	csconfig "github.com/nodeset-org/hyperdrive-constellation/shared/config"
 	batch "github.com/rocket-pool/batch-query"
 	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/rocket-pool/node-manager-core/log"
+)
+
+// Names of the Constellation contracts, used to track which addresses the bindings were created with
+const (
+	directoryContractName              string = "Directory"
+	whitelistContractName              string = "Whitelist"
+	superNodeAccountContractName       string = "SuperNodeAccount"
+	priceFetcherContractName           string = "PriceFetcher"
+	operatorDistributorContractName    string = "OperatorDistributor"
+	wethVaultContractName              string = "WethVault"
+	rplVaultContractName               string = "RplVault"
+	poaConstellationOracleContractName string = "PoAConstellationOracle"
+	treasuryContractName               string = "Treasury"
+	merkleClaimStreamerContractName    string = "MerkleClaimStreamer"
 )
 
 // Manager for Constellation contract bindings
 type ConstellationManager struct {
+	// Internal fields
+	ec        eth.IExecutionClient
+	qMgr      *eth.QueryManager
+	txMgr     *eth.TransactionManager
+	logger    *slog.Logger
+	contracts atomic.Pointer[ConstellationContracts]
+	loadLock  *sync.Mutex
+}
+
+// A set of Constellation contract bindings. Sets are never modified once they've been published, so a caller that
+// holds onto one always sees bindings that belong together.
+type ConstellationContracts struct {
 	Directory              *constellation.Directory
 	Whitelist              *constellation.Whitelist
 	SuperNodeAccount       *constellation.SuperNodeAccount
@@ -24,34 +54,72 @@ type ConstellationManager struct {
 	MerkleClaimStreamer    *constellation.MerkleClaimStreamer
 
 	// Internal fields
-	ec       eth.IExecutionClient
-	qMgr     *eth.QueryManager
-	txMgr    *eth.TransactionManager
 	isLoaded bool
+	loaded   constellationContractSet
+}
+
+// The proxy and implementation addresses of each Constellation contract, by contract name
+type constellationContractSet struct {
+	addresses       map[string]common.Address
+	implementations map[string]common.Address
 }
 
 // Creates a new ConstellationManager instance
-func NewConstellationManager(res *csconfig.ConstellationResources, ec eth.IExecutionClient, qMgr *eth.QueryManager, txMgr *eth.TransactionManager) (*ConstellationManager, error) {
+func NewConstellationManager(res *csconfig.ConstellationResources, ec eth.IExecutionClient, qMgr *eth.QueryManager, txMgr *eth.TransactionManager, logger *slog.Logger) (*ConstellationManager, error) {
 	directory, err := constellation.NewDirectory(*res.Directory, ec, txMgr)
 	if err != nil {
 		return nil, fmt.Errorf("error creating directory binding: %w", err)
 	}
 
-	return &ConstellationManager{
+	m := &ConstellationManager{
+		ec:       ec,
+		qMgr:     qMgr,
+		txMgr:    txMgr,
+		logger:   logger,
+		loadLock: &sync.Mutex{},
+	}
+	m.contracts.Store(&ConstellationContracts{
 		Directory: directory,
-		ec:        ec,
-		qMgr:      qMgr,
-		txMgr:     txMgr,
-	}, nil
+	})
+	return m, nil
+}
+
+// Get the current set of contract bindings. Only the Directory is set until the contracts have been loaded.
+func (m *ConstellationManager) Contracts() *ConstellationContracts {
+	return m.contracts.Load()
 }
 
-// Checks if the contract addresses have been loaded yet, and if not, generates the bindings with the on-chain addresses.
+// Generates the bindings with the on-chain addresses if they haven't been loaded yet.
 // Requires a synced EC to function properly; you're responsible for ensuring it's synced before calling this.
 func (m *ConstellationManager) LoadContracts() error {
-	if m.isLoaded {
+	if m.contracts.Load().isLoaded {
 		return nil
 	}
 
+	m.loadLock.Lock()
+	defer m.loadLock.Unlock()
+	if m.contracts.Load().isLoaded {
+		return nil
+	}
+	return m.bindContracts()
+}
+
+// Regenerates the bindings if the Directory now has a different address for any of the contracts, or any of their
+// UUPS proxies has been upgraded to a new implementation. This is meant to be called once per task loop iteration
+// rather than by every caller.
+// Requires a synced EC to function properly; you're responsible for ensuring it's synced before calling this.
+func (m *ConstellationManager) CheckForUpgrades() error {
+	m.loadLock.Lock()
+	defer m.loadLock.Unlock()
+	return m.bindContracts()
+}
+
+// Query the contract addresses and implementations, and publish a new set of bindings if they haven't been loaded
+// yet or anything changed. Must be called while holding the load lock.
+func (m *ConstellationManager) bindContracts() error {
+	previous := m.contracts.Load()
+	directory := previous.Directory
+
 	// Get the addresses
 	var whitelistAddress common.Address
 	var superNodeAccountAddress common.Address
@@ -63,23 +131,38 @@ func (m *ConstellationManager) LoadContracts() error {
 	var treasuryAddress common.Address
 	var nodeSetOperatorRewardsDistributorAddress common.Address
 	var merkleClaimStreamerAddress common.Address
+	current := constellationContractSet{
+		addresses:       map[string]common.Address{},
+		implementations: map[string]common.Address{},
+	}
+	var directoryImplementation common.Address
 	err := m.qMgr.Query(func(mc *batch.MultiCaller) error {
-		m.Directory.GetWhitelistAddress(mc, &whitelistAddress)
-		m.Directory.GetSuperNodeAddress(mc, &superNodeAccountAddress)
-		m.Directory.GetPriceFetcherAddress(mc, &priceFetcherAddress)
-		m.Directory.GetOperatorDistributorAddress(mc, &operatorDistributorAddress)
-		m.Directory.GetWethVaultAddress(mc, &wethVaultAddress)
-		m.Directory.GetRplVaultAddress(mc, &rplVaultAddress)
-		m.Directory.GetOracleAddress(mc, &poaBeaconOracleAddress)
-		m.Directory.GetTreasuryAddress(mc, &treasuryAddress)
-		m.Directory.GetOperatorRewardAddress(mc, &nodeSetOperatorRewardsDistributorAddress)
-		m.Directory.GetMerkleClaimStreamerAddress(mc, &merkleClaimStreamerAddress)
+		directory.GetWhitelistAddress(mc, &whitelistAddress)
+		directory.GetSuperNodeAddress(mc, &superNodeAccountAddress)
+		directory.GetPriceFetcherAddress(mc, &priceFetcherAddress)
+		directory.GetOperatorDistributorAddress(mc, &operatorDistributorAddress)
+		directory.GetWethVaultAddress(mc, &wethVaultAddress)
+		directory.GetRplVaultAddress(mc, &rplVaultAddress)
+		directory.GetOracleAddress(mc, &poaBeaconOracleAddress)
+		directory.GetTreasuryAddress(mc, &treasuryAddress)
+		directory.GetOperatorRewardAddress(mc, &nodeSetOperatorRewardsDistributorAddress)
+		directory.GetMerkleClaimStreamerAddress(mc, &merkleClaimStreamerAddress)
+		directory.GetImplementation(mc, &directoryImplementation)
 		return nil
 	}, nil)
 	if err != nil {
 		return fmt.Errorf("error getting contract addresses: %w", err)
 	}
-
+	current.addresses[whitelistContractName] = whitelistAddress
+	current.addresses[superNodeAccountContractName] = superNodeAccountAddress
+	current.addresses[priceFetcherContractName] = priceFetcherAddress
+	current.addresses[operatorDistributorContractName] = operatorDistributorAddress
+	current.addresses[wethVaultContractName] = wethVaultAddress
+	current.addresses[rplVaultContractName] = rplVaultAddress
+	current.addresses[poaConstellationOracleContractName] = poaBeaconOracleAddress
+	current.addresses[treasuryContractName] = treasuryAddress
+	current.addresses[merkleClaimStreamerContractName] = merkleClaimStreamerAddress
+	current.implementations[directoryContractName] = directoryImplementation
 	// Generate the bindings
 	whitelist, err := constellation.NewWhitelist(whitelistAddress, m.ec, m.txMgr)
 	if err != nil {
@@ -118,16 +201,140 @@ func (m *ConstellationManager) LoadContracts() error {
 		return fmt.Errorf("error creating merkle claim streamer binding: %w", err)
 	}
 
-	// Update the bindings
-	m.Whitelist = whitelist
-	m.SuperNodeAccount = superNodeAccount
-	m.PriceFetcher = priceFetcher
-	m.OperatorDistributor = operatorDistributor
-	m.WethVault = wethVault
-	m.RplVault = rplVault
-	m.PoAConstellationOracle = poaBeaconOracle
-	m.Treasury = treasury
-	m.MerkleClaimStreamer = merkleClaimStreamer
-	m.isLoaded = true
+	// Get the implementation behind each proxy; the Treasury doesn't expose its implementation, so only its address is tracked
+	implementations := map[string]*common.Address{}
+	for name := range current.addresses {
+		if name != treasuryContractName {
+			implementations[name] = new(common.Address)
+		}
+	}
+	err = m.qMgr.Query(func(mc *batch.MultiCaller) error {
+		whitelist.GetImplementation(mc, implementations[whitelistContractName])
+		superNodeAccount.GetImplementation(mc, implementations[superNodeAccountContractName])
+		priceFetcher.GetImplementation(mc, implementations[priceFetcherContractName])
+		operatorDistributor.GetImplementation(mc, implementations[operatorDistributorContractName])
+		wethVault.GetImplementation(mc, implementations[wethVaultContractName])
+		rplVault.GetImplementation(mc, implementations[rplVaultContractName])
+		poaBeaconOracle.GetImplementation(mc, implementations[poaConstellationOracleContractName])
+		merkleClaimStreamer.GetImplementation(mc, implementations[merkleClaimStreamerContractName])
+		return nil
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("error getting contract implementations: %w", err)
+	}
+	for name, implementation := range implementations {
+		current.implementations[name] = *implementation
+	}
+
+	// Keep the existing bindings if nothing changed
+	if previous.isLoaded {
+		changes := previous.loaded.getChanges(current)
+		if len(changes) == 0 {
+			return nil
+		}
+		for _, change := range changes {
+			m.logger.Warn("Constellation contract changed on-chain, rebinding",
+				slog.String("contract", change.name),
+				slog.String("kind", change.kind),
+				slog.String("old", change.old.Hex()),
+				slog.String("new", change.new.Hex()),
+			)
+		}
+	}
+	m.checkImplementations(current)
+
+	// Publish the new bindings all at once so callers never see a mix of old and new ones
+	m.contracts.Store(&ConstellationContracts{
+		Directory:              directory,
+		Whitelist:              whitelist,
+		SuperNodeAccount:       superNodeAccount,
+		PriceFetcher:           priceFetcher,
+		OperatorDistributor:    operatorDistributor,
+		WethVault:              wethVault,
+		RplVault:               rplVault,
+		PoAConstellationOracle: poaBeaconOracle,
+		Treasury:               treasury,
+		MerkleClaimStreamer:    merkleClaimStreamer,
+		isLoaded:               true,
+		loaded:                 current,
+	})
+	if previous.isLoaded {
+		m.logger.Info("Rebound Constellation contracts", slog.String("directory", directory.Address.Hex()))
+	}
 	return nil
 }
+
+// Replace the bindings with those of another manager, such as one created for new network settings.
+// The other manager should have its contracts loaded already so callers never see unloaded bindings.
+func (m *ConstellationManager) replaceWith(other *ConstellationManager) {
+	m.loadLock.Lock()
+	defer m.loadLock.Unlock()
+	m.contracts.Store(other.contracts.Load())
+}
+
+// Get the proxy address of each Constellation contract the bindings were created with, by contract name.
+// This only has the Directory until the contracts have been loaded.
+func (m *ConstellationManager) GetContractAddresses() map[string]common.Address {
+	contracts := m.contracts.Load()
+	addresses := make(map[string]common.Address, len(contracts.loaded.addresses)+1)
+	addresses[directoryContractName] = contracts.Directory.Address
+	for name, address := range contracts.loaded.addresses {
+		addresses[name] = address
+	}
+	return addresses
+}
+
+// Make sure each implementation reports the standard EIP-1967 slot for UUPS upgrades.
+// This is only a sanity check, so problems are logged rather than stopping the bindings from loading.
+func (m *ConstellationManager) checkImplementations(set constellationContractSet) {
+	uuids := map[string]*common.Hash{}
+	err := m.qMgr.Query(func(mc *batch.MultiCaller) error {
+		for name, address := range set.implementations {
+			implementation, err := constellation.NewUupsImplementation(address, m.ec)
+			if err != nil {
+				return fmt.Errorf("error creating %s implementation binding: %w", name, err)
+			}
+			uuid := new(common.Hash)
+			uuids[name] = uuid
+			implementation.ProxiableUUID(mc, uuid)
+		}
+		return nil
+	}, nil)
+	if err != nil {
+		m.logger.Warn("Couldn't verify the Constellation contract implementations", log.Err(err))
+		return
+	}
+	for name, uuid := range uuids {
+		if *uuid != constellation.Eip1967ImplementationSlot {
+			m.logger.Warn("Constellation contract implementation doesn't report the expected UUPS slot",
+				slog.String("contract", name),
+				slog.String("implementation", set.implementations[name].Hex()),
+				slog.String("uuid", uuid.Hex()),
+			)
+		}
+	}
+}
+
+// A difference between two sets of Constellation contract addresses
+type constellationContractChange struct {
+	name string
+	kind string
+	old  common.Address
+	new  common.Address
+}
+
+// Get everything that's different in the new set of addresses
+func (s constellationContractSet) getChanges(newSet constellationContractSet) []constellationContractChange {
+	changes := []constellationContractChange{}
+	for name, newAddress := range newSet.addresses {
+		if oldAddress := s.addresses[name]; oldAddress != newAddress {
+			changes = append(changes, constellationContractChange{name: name, kind: "address", old: oldAddress, new: newAddress})
+		}
+	}
+	for name, newImplementation := range newSet.implementations {
+		if oldImplementation := s.implementations[name]; oldImplementation != newImplementation {
+			changes = append(changes, constellationContractChange{name: name, kind: "implementation", old: oldImplementation, new: newImplementation})
+		}
+	}
+	return changes
+}
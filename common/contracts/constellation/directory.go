@@ -50,6 +50,7 @@ func NewDirectory(address common.Address, ec eth.IExecutionClient, txMgr *eth.Tr
 		parsedAbi, err = abi.JSON(strings.NewReader(directoryAbiString))
 		if err == nil {
 			directoryAbi = parsedAbi
+			registerRevertErrors(&directoryAbi)
 		}
 	})
 	if err != nil {
@@ -146,6 +147,10 @@ func (c *Directory) GetRocketDepositPoolAddress(mc *batch.MultiCaller, out *comm
 	eth.AddCallToMulticaller(mc, c.contract, out, "getRocketDepositPoolAddress")
 }
 
+func (c *Directory) GetImplementation(mc *batch.MultiCaller, out *common.Address) {
+	eth.AddCallToMulticaller(mc, c.contract, out, "getImplementation")
+}
+
 // ====================
 // === Transactions ===
 // ====================
@@ -0,0 +1,103 @@
+package constellation
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/node-manager-core/eth"
+)
+
+// Emitted by the SuperNodeAccount when a sub-node operator creates a minipool
+type MinipoolCreatedEvent struct {
+	MinipoolAddress common.Address
+	OperatorAddress common.Address
+	Raw             types.Log
+}
+
+// Emitted by the SuperNodeAccount when a minipool is removed from Constellation
+type MinipoolDestroyedEvent struct {
+	MinipoolAddress common.Address
+	OperatorAddress common.Address
+	Raw             types.Log
+}
+
+// Emitted by a UUPS proxy when it's upgraded to a new implementation
+type UpgradedEvent struct {
+	Implementation common.Address
+	Raw            types.Log
+}
+
+// Emitted by a proxy when its admin changes
+type AdminChangedEvent struct {
+	PreviousAdmin common.Address
+	NewAdmin      common.Address
+	Raw           types.Log
+}
+
+// Get the logs for an event emitted by any of the given contracts between the start and end blocks (inclusive).
+// Each set of topic values filters on the corresponding indexed argument of the event; leave it empty to match any value.
+// A nil end block means the latest block.
+func filterEventLogs(ctx context.Context, ec eth.IExecutionClient, contractAbi *abi.ABI, eventName string, addresses []common.Address, startBlock *big.Int, endBlock *big.Int, topicValues ...[]any) ([]types.Log, error) {
+	event, exists := contractAbi.Events[eventName]
+	if !exists {
+		return nil, fmt.Errorf("ABI doesn't have a %s event", eventName)
+	}
+	topics, err := abi.MakeTopics(topicValues...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating topics for %s: %w", eventName, err)
+	}
+	topics = append([][]common.Hash{{event.ID}}, topics...)
+
+	logs, err := ec.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: startBlock,
+		ToBlock:   endBlock,
+		Addresses: addresses,
+		Topics:    topics,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting %s logs: %w", eventName, err)
+	}
+	return logs, nil
+}
+
+// Unpack a log into one of the event structs, including its indexed arguments
+func unpackEventLog(contractAbi *abi.ABI, out any, eventName string, log types.Log) error {
+	event, exists := contractAbi.Events[eventName]
+	if !exists {
+		return fmt.Errorf("ABI doesn't have a %s event", eventName)
+	}
+	if len(log.Topics) == 0 || log.Topics[0] != event.ID {
+		return fmt.Errorf("log %d of TX %s doesn't match the %s event", log.Index, log.TxHash.Hex(), eventName)
+	}
+	if len(log.Data) > 0 {
+		err := contractAbi.UnpackIntoInterface(out, eventName, log.Data)
+		if err != nil {
+			return fmt.Errorf("error unpacking %s data: %w", eventName, err)
+		}
+	}
+	indexed := abi.Arguments{}
+	for _, arg := range event.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	err := abi.ParseTopics(out, indexed, log.Topics[1:])
+	if err != nil {
+		return fmt.Errorf("error parsing %s topics: %w", eventName, err)
+	}
+	return nil
+}
+
+// Convert a list of addresses into topic values for filtering
+func addressTopics(addresses []common.Address) []any {
+	values := make([]any, len(addresses))
+	for i, address := range addresses {
+		values[i] = address
+	}
+	return values
+}
@@ -36,6 +36,7 @@ func NewMerkleClaimStreamer(address common.Address, ec eth.IExecutionClient, txM
 		parsedAbi, err = abi.JSON(strings.NewReader(merkleClaimStreamerAbiString))
 		if err == nil {
 			merkleClaimStreamerAbi = parsedAbi
+			registerRevertErrors(&merkleClaimStreamerAbi)
 		}
 	})
 	if err != nil {
@@ -76,6 +77,10 @@ func (c *MerkleClaimStreamer) GetStreamedTvlRpl(mc *batch.MultiCaller, out **big
 	eth.AddCallToMulticaller(mc, c.contract, out, "getStreamedTvlRpl")
 }
 
+func (c *MerkleClaimStreamer) GetImplementation(mc *batch.MultiCaller, out *common.Address) {
+	eth.AddCallToMulticaller(mc, c.contract, out, "getImplementation")
+}
+
 // ====================
 // === Transactions ===
 // ====================
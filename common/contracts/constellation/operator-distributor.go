@@ -36,6 +36,7 @@ func NewOperatorDistributor(address common.Address, ec eth.IExecutionClient, txM
 		parsedAbi, err = abi.JSON(strings.NewReader(operatorDistributorAbiString))
 		if err == nil {
 			operatorDistributorAbi = parsedAbi
+			registerRevertErrors(&operatorDistributorAbi)
 		}
 	})
 	if err != nil {
@@ -43,6 +43,7 @@ func NewPoAConstellationOracle(address common.Address, ec eth.IExecutionClient,
 		parsedAbi, err = abi.JSON(strings.NewReader(poaConstellationOracleAbiString))
 		if err == nil {
 			poaConstellationOracleAbi = parsedAbi
+			registerRevertErrors(&poaConstellationOracleAbi)
 		}
 	})
 	if err != nil {
@@ -72,6 +73,15 @@ func (c *PoAConstellationOracle) GetTotalYieldAccrued(mc *batch.MultiCaller, out
 	eth.AddCallToMulticaller(mc, c.contract, out, "getTotalYieldAccrued")
 }
 
+// Gets the timestamp of the signature used for the last total yield update
+func (c *PoAConstellationOracle) GetLastUpdatedTotalYieldAccrued(mc *batch.MultiCaller, out **big.Int) {
+	eth.AddCallToMulticaller(mc, c.contract, out, "getLastUpdatedTotalYieldAccrued")
+}
+
+func (c *PoAConstellationOracle) GetImplementation(mc *batch.MultiCaller, out *common.Address) {
+	eth.AddCallToMulticaller(mc, c.contract, out, "getImplementation")
+}
+
 // ====================
 // === Transactions ===
 // ====================
@@ -36,6 +36,7 @@ func NewPriceFetcher(address common.Address, ec eth.IExecutionClient, txMgr *eth
 		parsedAbi, err = abi.JSON(strings.NewReader(priceFetcherAbiString))
 		if err == nil {
 			priceFetcherAbi = parsedAbi
+			registerRevertErrors(&priceFetcherAbi)
 		}
 	})
 	if err != nil {
@@ -65,6 +66,10 @@ func (c *PriceFetcher) GetRplPrice(mc *batch.MultiCaller, out **big.Int) {
 	eth.AddCallToMulticaller(mc, c.contract, out, "getPrice")
 }
 
+func (c *PriceFetcher) GetImplementation(mc *batch.MultiCaller, out *common.Address) {
+	eth.AddCallToMulticaller(mc, c.contract, out, "getImplementation")
+}
+
 // ====================
 // === Transactions ===
 // ====================
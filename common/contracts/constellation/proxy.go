@@ -0,0 +1,171 @@
+package constellation
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	batch "github.com/rocket-pool/batch-query"
+	"github.com/rocket-pool/node-manager-core/eth"
+)
+
+const (
+	// The part of the UUPS interface that every Constellation implementation contract exposes
+	uupsImplementationAbiString string = `[{"inputs":[],"name":"proxiableUUID","outputs":[{"internalType":"bytes32","name":"","type":"bytes32"}],"stateMutability":"view","type":"function"}]`
+
+	// The EIP-1967 events every Constellation proxy emits
+	proxyEventsAbiString string = `[{"anonymous":false,"inputs":[{"indexed":false,"internalType":"address","name":"previousAdmin","type":"address"},{"indexed":false,"internalType":"address","name":"newAdmin","type":"address"}],"name":"AdminChanged","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"implementation","type":"address"}],"name":"Upgraded","type":"event"}]`
+)
+
+// The EIP-1967 storage slot UUPS proxies keep their implementation address in; valid implementations return this from proxiableUUID()
+var Eip1967ImplementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+
+// ABI cache
+var uupsImplementationAbi abi.ABI
+var uupsImplementationOnce sync.Once
+var proxyEventsAbi abi.ABI
+var proxyEventsOnce sync.Once
+
+// Binding for the implementation contract behind a Constellation UUPS proxy
+type UupsImplementation struct {
+	Address  common.Address
+	contract *eth.Contract
+}
+
+// Create a new UupsImplementation instance
+func NewUupsImplementation(address common.Address, ec eth.IExecutionClient) (*UupsImplementation, error) {
+	// Parse the ABI
+	var err error
+	uupsImplementationOnce.Do(func() {
+		var parsedAbi abi.ABI
+		parsedAbi, err = abi.JSON(strings.NewReader(uupsImplementationAbiString))
+		if err == nil {
+			uupsImplementationAbi = parsedAbi
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error parsing UUPS implementation ABI: %w", err)
+	}
+
+	// Create the contract
+	contract := &eth.Contract{
+		ContractImpl: bind.NewBoundContract(address, uupsImplementationAbi, ec, ec, ec),
+		Address:      address,
+		ABI:          &uupsImplementationAbi,
+	}
+
+	return &UupsImplementation{
+		Address:  address,
+		contract: contract,
+	}, nil
+}
+
+// =============
+// === Calls ===
+// =============
+
+// The storage slot the implementation expects its proxy to use. This reverts when called through the proxy, so it can only be called on the implementation itself.
+func (c *UupsImplementation) ProxiableUUID(mc *batch.MultiCaller, out *common.Hash) {
+	eth.AddCallToMulticaller(mc, c.contract, out, "proxiableUUID")
+}
+
+// ==============
+// === Events ===
+// ==============
+
+// Get the ABI for the proxy events, parsing it if it hasn't been parsed yet
+func getProxyEventsAbi() (*abi.ABI, error) {
+	var err error
+	proxyEventsOnce.Do(func() {
+		var parsedAbi abi.ABI
+		parsedAbi, err = abi.JSON(strings.NewReader(proxyEventsAbiString))
+		if err == nil {
+			proxyEventsAbi = parsedAbi
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error parsing proxy events ABI: %w", err)
+	}
+	return &proxyEventsAbi, nil
+}
+
+// Get the Upgraded events emitted by any of the given proxies between the start and end blocks (inclusive); a nil end block means the latest block.
+// The results can be narrowed to specific implementations; leave the list empty to match all of them.
+func FilterUpgraded(ctx context.Context, ec eth.IExecutionClient, proxies []common.Address, startBlock *big.Int, endBlock *big.Int, implementations []common.Address) ([]UpgradedEvent, error) {
+	eventsAbi, err := getProxyEventsAbi()
+	if err != nil {
+		return nil, err
+	}
+	logs, err := filterEventLogs(ctx, ec, eventsAbi, "Upgraded", proxies, startBlock, endBlock, addressTopics(implementations))
+	if err != nil {
+		return nil, err
+	}
+	events := make([]UpgradedEvent, len(logs))
+	for i, log := range logs {
+		event, err := ParseUpgraded(log)
+		if err != nil {
+			return nil, err
+		}
+		events[i] = *event
+	}
+	return events, nil
+}
+
+// Parse an Upgraded event from a log
+func ParseUpgraded(log types.Log) (*UpgradedEvent, error) {
+	eventsAbi, err := getProxyEventsAbi()
+	if err != nil {
+		return nil, err
+	}
+	event := &UpgradedEvent{
+		Raw: log,
+	}
+	err = unpackEventLog(eventsAbi, event, "Upgraded", log)
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// Get the AdminChanged events emitted by any of the given proxies between the start and end blocks (inclusive); a nil end block means the latest block
+func FilterAdminChanged(ctx context.Context, ec eth.IExecutionClient, proxies []common.Address, startBlock *big.Int, endBlock *big.Int) ([]AdminChangedEvent, error) {
+	eventsAbi, err := getProxyEventsAbi()
+	if err != nil {
+		return nil, err
+	}
+	logs, err := filterEventLogs(ctx, ec, eventsAbi, "AdminChanged", proxies, startBlock, endBlock)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]AdminChangedEvent, len(logs))
+	for i, log := range logs {
+		event, err := ParseAdminChanged(log)
+		if err != nil {
+			return nil, err
+		}
+		events[i] = *event
+	}
+	return events, nil
+}
+
+// Parse an AdminChanged event from a log
+func ParseAdminChanged(log types.Log) (*AdminChangedEvent, error) {
+	eventsAbi, err := getProxyEventsAbi()
+	if err != nil {
+		return nil, err
+	}
+	event := &AdminChangedEvent{
+		Raw: log,
+	}
+	err = unpackEventLog(eventsAbi, event, "AdminChanged", log)
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
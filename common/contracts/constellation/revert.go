@@ -0,0 +1,219 @@
+package constellation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rocket-pool/node-manager-core/eth"
+)
+
+// The custom errors declared by the Constellation contracts; use these with errors.Is to check what a revert was
+var (
+	ErrBadBondAmount        = &RevertError{Name: "BadBondAmount"}
+	ErrBadPredictedCreation = &RevertError{Name: "BadPredictedCreation"}
+	ErrBadRole              = &RevertError{Name: "BadRole"}
+	ErrBadSender            = &RevertError{Name: "BadSender"}
+	ErrInsufficientBalance  = &RevertError{Name: "InsufficientBalance"}
+	ErrLowLevelCall         = &RevertError{Name: "LowLevelCall"}
+	ErrLowLevelEthTransfer  = &RevertError{Name: "LowLevelEthTransfer"}
+	ErrNotAContract         = &RevertError{Name: "NotAContract"}
+	ErrZeroAddress          = &RevertError{Name: "ZeroAddressError"}
+)
+
+// The name used for reverts with a plain reason string (or a panic code) rather than a custom error
+const RevertReasonName string = "Error"
+
+// Custom errors from every Constellation ABI that has been parsed, by selector
+var revertErrors = map[[4]byte]abi.Error{}
+var revertErrorsLock sync.RWMutex
+
+// Some clients only report the revert data in the error message, right after the revert marker
+var revertDataRegex = regexp.MustCompile(`(?i)(?:reverted:|return data:)\s*(0x[0-9a-fA-F]{8,})`)
+
+// A decoded argument of a custom error
+type RevertArgument struct {
+	Name  string
+	Type  string
+	Value any
+}
+
+// A revert from a Constellation contract, decoded from its revert data
+type RevertError struct {
+	// The name of the custom error, RevertReasonName for a reason string, or empty if it couldn't be decoded
+	Name string
+
+	// The first 4 bytes of the revert data
+	Selector [4]byte
+
+	// The decoded arguments of the custom error, in order
+	Arguments []RevertArgument
+
+	// The reason string, for reverts that aren't custom errors
+	Reason string
+
+	// The raw revert data
+	Data []byte
+}
+
+func (e *RevertError) Error() string {
+	switch e.Name {
+	case "":
+		if len(e.Data) == 0 {
+			return "reverted without a reason"
+		}
+		return fmt.Sprintf("reverted with unknown error %s", hexutil.Encode(e.Selector[:]))
+	case RevertReasonName:
+		return fmt.Sprintf("reverted: %s", e.Reason)
+	}
+	args := make([]string, len(e.Arguments))
+	for i, arg := range e.Arguments {
+		args[i] = fmt.Sprintf("%s=%s", arg.Name, arg.String())
+	}
+	return fmt.Sprintf("reverted with %s(%s)", e.Name, strings.Join(args, ", "))
+}
+
+// Matches any revert with the same custom error, regardless of its arguments
+func (e *RevertError) Is(target error) bool {
+	targetErr, ok := target.(*RevertError)
+	if !ok || targetErr.Name == "" {
+		return false
+	}
+	return targetErr.Name == e.Name
+}
+
+// Get the value of the custom error's argument with the given name
+func (e *RevertError) Argument(name string) (any, bool) {
+	for _, arg := range e.Arguments {
+		if arg.Name == name {
+			return arg.Value, true
+		}
+	}
+	return nil, false
+}
+
+// Format the argument's value for display
+func (a RevertArgument) String() string {
+	switch value := a.Value.(type) {
+	case *big.Int:
+		return value.String()
+	case common.Address:
+		return value.Hex()
+	case [32]byte:
+		return hexutil.Encode(value[:])
+	case []byte:
+		return hexutil.Encode(value)
+	case bool:
+		return strconv.FormatBool(value)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// Add the custom errors declared in an ABI to the set that reverts can be decoded with
+func registerRevertErrors(contractAbi *abi.ABI) {
+	revertErrorsLock.Lock()
+	defer revertErrorsLock.Unlock()
+	for _, abiError := range contractAbi.Errors {
+		var selector [4]byte
+		copy(selector[:], abiError.ID[:4])
+		revertErrors[selector] = abiError
+	}
+}
+
+// Decode revert data into a RevertError, using the custom errors of every Constellation binding that has been created
+func DecodeRevert(data []byte) *RevertError {
+	revertErr := &RevertError{
+		Data: data,
+	}
+	if len(data) < 4 {
+		return revertErr
+	}
+	copy(revertErr.Selector[:], data[:4])
+
+	// Check for a reason string or panic first
+	reason, err := abi.UnpackRevert(data)
+	if err == nil {
+		revertErr.Name = RevertReasonName
+		revertErr.Reason = reason
+		return revertErr
+	}
+
+	// Look up the custom error
+	revertErrorsLock.RLock()
+	abiError, exists := revertErrors[revertErr.Selector]
+	revertErrorsLock.RUnlock()
+	if !exists {
+		return revertErr
+	}
+	unpacked, err := abiError.Unpack(data)
+	if err != nil {
+		return revertErr
+	}
+	values, ok := unpacked.([]any)
+	if !ok || len(values) != len(abiError.Inputs) {
+		return revertErr
+	}
+	revertErr.Name = abiError.Name
+	revertErr.Arguments = make([]RevertArgument, len(values))
+	for i, input := range abiError.Inputs {
+		revertErr.Arguments[i] = RevertArgument{
+			Name:  input.Name,
+			Type:  input.Type.String(),
+			Value: values[i],
+		}
+	}
+	return revertErr
+}
+
+// Replay a transaction as a call to find out why it reverts, since the simulation during TX creation only keeps the error message.
+// Returns nil if the call no longer reverts, a *RevertError if the revert data could be retrieved, or the call's error otherwise.
+func GetRevertError(ctx context.Context, ec eth.IExecutionClient, from common.Address, txInfo *eth.TransactionInfo) error {
+	to := txInfo.To
+	_, err := ec.CallContract(ctx, ethereum.CallMsg{
+		From:  from,
+		To:    &to,
+		Value: txInfo.Value,
+		Data:  txInfo.Data,
+	}, nil)
+	if err == nil {
+		return nil
+	}
+	data, found := getRevertData(err)
+	if !found {
+		return err
+	}
+	return DecodeRevert(data)
+}
+
+// Get the revert data from a call error, if the client provided it
+func getRevertData(err error) ([]byte, bool) {
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if hexData, ok := dataErr.ErrorData().(string); ok {
+			data, decodeErr := hexutil.Decode(hexData)
+			if decodeErr == nil {
+				return data, true
+			}
+		}
+	}
+	match := revertDataRegex.FindStringSubmatch(err.Error())
+	if match == nil {
+		return nil, false
+	}
+	data, decodeErr := hexutil.Decode(match[1])
+	if decodeErr != nil {
+		return nil, false
+	}
+	return data, true
+}
@@ -0,0 +1,119 @@
+package constellation
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testRevertAbiString string = `[{"inputs":[{"internalType":"uint256","name":"expectedBalance","type":"uint256"},{"internalType":"uint256","name":"actualBalance","type":"uint256"}],"name":"InsufficientBalance","type":"error"}]`
+)
+
+// An error from the client that carries the revert data separately from the message
+type testDataError struct {
+	message string
+	data    any
+}
+
+func (e *testDataError) Error() string {
+	return e.message
+}
+
+func (e *testDataError) ErrorData() any {
+	return e.data
+}
+
+// Make sure custom errors, reason strings and unknown selectors are decoded
+func TestDecodeRevert(t *testing.T) {
+	testAbi, err := abi.JSON(strings.NewReader(testRevertAbiString))
+	require.NoError(t, err)
+	registerRevertErrors(&testAbi)
+
+	// Custom error with arguments
+	abiError := testAbi.Errors["InsufficientBalance"]
+	args, err := abiError.Inputs.Pack(big.NewInt(100), big.NewInt(42))
+	require.NoError(t, err)
+	revertErr := DecodeRevert(append(abiError.ID[:4:4], args...))
+	require.ErrorIs(t, revertErr, ErrInsufficientBalance)
+	require.Len(t, revertErr.Arguments, 2)
+	expected, exists := revertErr.Argument("expectedBalance")
+	require.True(t, exists)
+	require.Equal(t, big.NewInt(100), expected)
+	require.Equal(t, "reverted with InsufficientBalance(expectedBalance=100, actualBalance=42)", revertErr.Error())
+
+	// Reason string
+	stringType, err := abi.NewType("string", "", nil)
+	require.NoError(t, err)
+	reason, err := abi.Arguments{{Type: stringType}}.Pack("not allowed")
+	require.NoError(t, err)
+	revertErr = DecodeRevert(append(hexutil.MustDecode("0x08c379a0"), reason...))
+	require.Equal(t, RevertReasonName, revertErr.Name)
+	require.Equal(t, "not allowed", revertErr.Reason)
+	require.False(t, errors.Is(revertErr, ErrInsufficientBalance))
+
+	// Unknown selector
+	revertErr = DecodeRevert(hexutil.MustDecode("0xdeadbeef"))
+	require.Empty(t, revertErr.Name)
+	require.Equal(t, "reverted with unknown error 0xdeadbeef", revertErr.Error())
+
+	// No data
+	revertErr = DecodeRevert(nil)
+	require.Equal(t, "reverted without a reason", revertErr.Error())
+}
+
+// Make sure revert data is only taken from errors that are actually reverts
+func TestGetRevertData(t *testing.T) {
+	tests := []struct {
+		name  string
+		err   error
+		data  string
+		found bool
+	}{
+		{
+			name:  "data error",
+			err:   &testDataError{message: "execution reverted", data: "0xdeadbeef01"},
+			data:  "0xdeadbeef01",
+			found: true,
+		},
+		{
+			name:  "revert data in the message",
+			err:   errors.New("execution reverted: 0xdeadbeef01"),
+			data:  "0xdeadbeef01",
+			found: true,
+		},
+		{
+			name:  "return data in the message",
+			err:   errors.New("VM Exception while processing transaction: reverted with an unrecognized custom error (return data: 0xdeadbeef01)"),
+			data:  "0xdeadbeef01",
+			found: true,
+		},
+		{
+			name: "revert reason in the message",
+			err:  errors.New("execution reverted: not allowed"),
+		},
+		{
+			name: "insufficient funds",
+			err:  errors.New("insufficient funds for gas * price + value: address " + common.HexToAddress("0x71562b71999873DB5b286dF957af199Ec94617F7").Hex() + " have 1000 want 2000"),
+		},
+		{
+			name: "data error without data",
+			err:  &testDataError{message: "nonce too low", data: nil},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, found := getRevertData(test.err)
+			require.Equal(t, test.found, found)
+			if test.found {
+				require.Equal(t, test.data, hexutil.Encode(data))
+			}
+		})
+	}
+}
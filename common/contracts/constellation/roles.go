@@ -0,0 +1,30 @@
+package constellation
+
+import (
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// The access control roles the Constellation contracts check before running admin functions.
+// Everything but the Treasury's roles are granted through the Directory.
+var (
+	// General protocol administration
+	AdminRole [32]byte = crypto.Keccak256Hash([]byte("ADMIN_ROLE"))
+
+	// The NodeSet server that signs sub-node registrations and minipool creations
+	AdminServerRole [32]byte = crypto.Keccak256Hash([]byte("ADMIN_SERVER_ROLE"))
+
+	// The oracle that signs yield updates
+	AdminOracleRole [32]byte = crypto.Keccak256Hash([]byte("ADMIN_ORACLE_ROLE"))
+
+	// The timelock for parameters that can change on short notice
+	TimelockShortRole [32]byte = crypto.Keccak256Hash([]byte("TIMELOCK_SHORT"))
+
+	// The timelock for parameters that need medium notice, such as fees
+	TimelockMediumRole [32]byte = crypto.Keccak256Hash([]byte("TIMELOCK_MED"))
+
+	// The timelock for parameters that need long notice
+	TimelockLongRole [32]byte = crypto.Keccak256Hash([]byte("TIMELOCK_LONG"))
+
+	// Allowed to move funds out of the Treasury; this is granted by the Treasury itself
+	TreasurerRole [32]byte = crypto.Keccak256Hash([]byte("TREASURER_ROLE"))
+)
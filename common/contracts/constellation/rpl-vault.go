@@ -38,6 +38,7 @@ func NewRplVault(address common.Address, ec eth.IExecutionClient, qMgr *eth.Quer
 		parsedAbi, err = abi.JSON(strings.NewReader(rplVaultAbiString))
 		if err == nil {
 			rplVaultAbi = parsedAbi
+			registerRevertErrors(&rplVaultAbi)
 		}
 	})
 	if err != nil {
@@ -88,6 +89,10 @@ func (c *RplVault) GetTotalAssets(mc *batch.MultiCaller, out **big.Int) {
 	eth.AddCallToMulticaller(mc, c.contract, out, "totalAssets")
 }
 
+func (c *RplVault) GetImplementation(mc *batch.MultiCaller, out *common.Address) {
+	eth.AddCallToMulticaller(mc, c.contract, out, "getImplementation")
+}
+
 // ====================
 // === Transactions ===
 // ====================
@@ -1,6 +1,7 @@
 package constellation
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"strings"
@@ -9,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	batch "github.com/rocket-pool/batch-query"
 	"github.com/rocket-pool/node-manager-core/beacon"
@@ -42,6 +44,7 @@ type MinipoolData struct {
 type SuperNodeAccount struct {
 	Address  common.Address
 	contract *eth.Contract
+	ec       eth.IExecutionClient
 	txMgr    *eth.TransactionManager
 }
 
@@ -62,6 +65,7 @@ func NewSuperNodeAccount(address common.Address, ec eth.IExecutionClient, txMgr
 		parsedAbi, err = abi.JSON(strings.NewReader(superNodeAccountAbiString))
 		if err == nil {
 			superNodeAccountAbi = parsedAbi
+			registerRevertErrors(&superNodeAccountAbi)
 		}
 	})
 	if err != nil {
@@ -78,6 +82,7 @@ func NewSuperNodeAccount(address common.Address, ec eth.IExecutionClient, txMgr
 	return &SuperNodeAccount{
 		Address:  address,
 		contract: contract,
+		ec:       ec,
 		txMgr:    txMgr,
 	}, nil
 }
@@ -117,6 +122,11 @@ func (c *SuperNodeAccount) LockThreshold(mc *batch.MultiCaller, out **big.Int) {
 	eth.AddCallToMulticaller(mc, c.contract, out, "lockThreshold")
 }
 
+// The amount of ETH the subnode operator has locked for the given minipool
+func (c *SuperNodeAccount) LockedEth(mc *batch.MultiCaller, out **big.Int, minipool common.Address) {
+	eth.AddCallToMulticaller(mc, c.contract, out, "lockedEth", minipool)
+}
+
 // The max number of minipools a subnode operator is allowed to have active
 func (c *SuperNodeAccount) GetMaxValidators(mc *batch.MultiCaller, out **big.Int) {
 	eth.AddCallToMulticaller(mc, c.contract, out, "maxValidators")
@@ -137,6 +147,10 @@ func (c *SuperNodeAccount) GetMinipoolData(mc *batch.MultiCaller, out *MinipoolD
 	eth.AddCallToMulticaller(mc, c.contract, out, "minipoolData", address)
 }
 
+func (c *SuperNodeAccount) GetImplementation(mc *batch.MultiCaller, out *common.Address) {
+	eth.AddCallToMulticaller(mc, c.contract, out, "getImplementation")
+}
+
 // ====================
 // === Transactions ===
 // ====================
@@ -201,3 +215,67 @@ func (c *SuperNodeAccount) SetMaxValidators(maxValidators *big.Int, opts *bind.T
 func (c *SuperNodeAccount) MerkleClaim(rewardIndex []*big.Int, amountRPL []*big.Int, amountETH []*big.Int, merkleProof [][]common.Hash, config *MerkleRewardsConfig, opts *bind.TransactOpts) (*eth.TransactionInfo, error) {
 	return c.txMgr.CreateTransactionInfo(c.contract, "merkleClaim", opts, rewardIndex, amountRPL, amountETH, merkleProof, config)
 }
+
+// ==============
+// === Events ===
+// ==============
+
+// Get the MinipoolCreated events between the start and end blocks (inclusive); a nil end block means the latest block.
+// The results can be narrowed to specific minipools and sub-node operators; leave either list empty to match all of them.
+func (c *SuperNodeAccount) FilterMinipoolCreated(ctx context.Context, startBlock *big.Int, endBlock *big.Int, minipools []common.Address, operators []common.Address) ([]MinipoolCreatedEvent, error) {
+	logs, err := filterEventLogs(ctx, c.ec, &superNodeAccountAbi, "MinipoolCreated", []common.Address{c.Address}, startBlock, endBlock, addressTopics(minipools), addressTopics(operators))
+	if err != nil {
+		return nil, err
+	}
+	events := make([]MinipoolCreatedEvent, len(logs))
+	for i, log := range logs {
+		event, err := c.ParseMinipoolCreated(log)
+		if err != nil {
+			return nil, err
+		}
+		events[i] = *event
+	}
+	return events, nil
+}
+
+// Parse a MinipoolCreated event from a log
+func (c *SuperNodeAccount) ParseMinipoolCreated(log types.Log) (*MinipoolCreatedEvent, error) {
+	event := &MinipoolCreatedEvent{
+		Raw: log,
+	}
+	err := unpackEventLog(&superNodeAccountAbi, event, "MinipoolCreated", log)
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// Get the MinipoolDestroyed events between the start and end blocks (inclusive); a nil end block means the latest block.
+// The results can be narrowed to specific minipools and sub-node operators; leave either list empty to match all of them.
+func (c *SuperNodeAccount) FilterMinipoolDestroyed(ctx context.Context, startBlock *big.Int, endBlock *big.Int, minipools []common.Address, operators []common.Address) ([]MinipoolDestroyedEvent, error) {
+	logs, err := filterEventLogs(ctx, c.ec, &superNodeAccountAbi, "MinipoolDestroyed", []common.Address{c.Address}, startBlock, endBlock, addressTopics(minipools), addressTopics(operators))
+	if err != nil {
+		return nil, err
+	}
+	events := make([]MinipoolDestroyedEvent, len(logs))
+	for i, log := range logs {
+		event, err := c.ParseMinipoolDestroyed(log)
+		if err != nil {
+			return nil, err
+		}
+		events[i] = *event
+	}
+	return events, nil
+}
+
+// Parse a MinipoolDestroyed event from a log
+func (c *SuperNodeAccount) ParseMinipoolDestroyed(log types.Log) (*MinipoolDestroyedEvent, error) {
+	event := &MinipoolDestroyedEvent{
+		Raw: log,
+	}
+	err := unpackEventLog(&superNodeAccountAbi, event, "MinipoolDestroyed", log)
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
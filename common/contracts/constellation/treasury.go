@@ -35,6 +35,7 @@ func NewTreasury(address common.Address, ec eth.IExecutionClient, txMgr *eth.Tra
 		parsedAbi, err = abi.JSON(strings.NewReader(treasuryAbiString))
 		if err == nil {
 			treasuryAbi = parsedAbi
+			registerRevertErrors(&treasuryAbi)
 		}
 	})
 	if err != nil {
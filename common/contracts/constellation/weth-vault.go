@@ -38,6 +38,7 @@ func NewWethVault(address common.Address, ec eth.IExecutionClient, qMgr *eth.Que
 		parsedAbi, err = abi.JSON(strings.NewReader(wethVaultAbiString))
 		if err == nil {
 			wethVaultAbi = parsedAbi
+			registerRevertErrors(&wethVaultAbi)
 		}
 	})
 	if err != nil {
@@ -92,6 +93,10 @@ func (c *WethVault) GetMintFee(mc *batch.MultiCaller, out **big.Int) {
 	eth.AddCallToMulticaller(mc, c.contract, out, "mintFee")
 }
 
+func (c *WethVault) GetImplementation(mc *batch.MultiCaller, out *common.Address) {
+	eth.AddCallToMulticaller(mc, c.contract, out, "getImplementation")
+}
+
 // ====================
 // === Transactions ===
 // ====================
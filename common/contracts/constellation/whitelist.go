@@ -36,6 +36,7 @@ func NewWhitelist(address common.Address, ec eth.IExecutionClient, txMgr *eth.Tr
 		parsedAbi, err = abi.JSON(strings.NewReader(whitelistAbiString))
 		if err == nil {
 			whitelistAbi = parsedAbi
+			registerRevertErrors(&whitelistAbi)
 		}
 	})
 	if err != nil {
@@ -68,6 +69,10 @@ func (c *Whitelist) GetActiveValidatorCountForOperator(mc *batch.MultiCaller, ou
 	eth.AddCallToMulticaller(mc, c.contract, out, "getActiveValidatorCountForOperator", account)
 }
 
+func (c *Whitelist) GetImplementation(mc *batch.MultiCaller, out *common.Address) {
+	eth.AddCallToMulticaller(mc, c.contract, out, "getImplementation")
+}
+
 // ====================
 // === Transactions ===
 // ====================
@@ -0,0 +1,96 @@
+package cscommon
+
+import (
+	"sync"
+	"time"
+
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+)
+
+const (
+	// The number of recent events kept so reconnecting subscribers can catch up on what they missed
+	eventHistorySize int = 256
+
+	// The number of events that can be queued for a subscriber before it's considered too slow and gets dropped
+	eventSubscriberBufferSize int = 64
+)
+
+// Distributes events from the task loop and the API server to the subscribers of the event stream.
+// Subscribers that fall behind are dropped rather than slowing down the publishers; they can reconnect and resume
+// from the last event they saw, as long as it's still in the history.
+type EventBroker struct {
+	nextEventID      uint64
+	history          []csapi.Event
+	subscribers      map[uint64]chan csapi.Event
+	nextSubscriberID uint64
+	lock             *sync.Mutex
+}
+
+// Create a new event broker
+func NewEventBroker() *EventBroker {
+	return &EventBroker{
+		nextEventID: 1,
+		history:     []csapi.Event{},
+		subscribers: map[uint64]chan csapi.Event{},
+		lock:        &sync.Mutex{},
+	}
+}
+
+// Publish an event to all subscribers; the ID and time are assigned here
+func (b *EventBroker) Publish(event csapi.Event) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	event.ID = b.nextEventID
+	b.nextEventID++
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	// Add it to the history
+	b.history = append(b.history, event)
+	if len(b.history) > eventHistorySize {
+		b.history = b.history[len(b.history)-eventHistorySize:]
+	}
+
+	// Send it out
+	for id, subscriber := range b.subscribers {
+		select {
+		case subscriber <- event:
+		default:
+			close(subscriber)
+			delete(b.subscribers, id)
+		}
+	}
+}
+
+// Subscribe to new events.
+// Any events in the history after lastEventID are returned so the subscriber can catch up; use 0 to skip the history.
+// The channel will be closed if the subscriber falls too far behind. Call the returned function to unsubscribe.
+func (b *EventBroker) Subscribe(lastEventID uint64) ([]csapi.Event, <-chan csapi.Event, func()) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	missed := []csapi.Event{}
+	if lastEventID > 0 {
+		for _, event := range b.history {
+			if event.ID > lastEventID {
+				missed = append(missed, event)
+			}
+		}
+	}
+
+	id := b.nextSubscriberID
+	b.nextSubscriberID++
+	subscriber := make(chan csapi.Event, eventSubscriberBufferSize)
+	b.subscribers[id] = subscriber
+	unsubscribe := func() {
+		b.lock.Lock()
+		defer b.lock.Unlock()
+		if _, exists := b.subscribers[id]; exists {
+			close(subscriber)
+			delete(b.subscribers, id)
+		}
+	}
+	return missed, subscriber, unsubscribe
+}
@@ -0,0 +1,112 @@
+package cscommon
+
+import (
+	"testing"
+
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/stretchr/testify/require"
+)
+
+// Make sure every subscriber gets every event, in order, with sequential IDs
+func TestEventBrokerFanOut(t *testing.T) {
+	broker := NewEventBroker()
+	_, first, unsubscribeFirst := broker.Subscribe(0)
+	defer unsubscribeFirst()
+	_, second, unsubscribeSecond := broker.Subscribe(0)
+	defer unsubscribeSecond()
+
+	broker.Publish(csapi.Event{Type: csapi.EventType_TaskError, Task: "task1"})
+	broker.Publish(csapi.Event{Type: csapi.EventType_ExitUploaded, Minipool: testMinipoolAddress})
+
+	for _, subscriber := range []<-chan csapi.Event{first, second} {
+		require.Len(t, subscriber, 2)
+		event := <-subscriber
+		require.Equal(t, uint64(1), event.ID)
+		require.Equal(t, csapi.EventType_TaskError, event.Type)
+		require.Equal(t, "task1", event.Task)
+		require.False(t, event.Time.IsZero())
+
+		event = <-subscriber
+		require.Equal(t, uint64(2), event.ID)
+		require.Equal(t, csapi.EventType_ExitUploaded, event.Type)
+		require.Equal(t, testMinipoolAddress, event.Minipool)
+	}
+}
+
+// Make sure a subscriber resuming from an event ID gets the events after it from the history, and only those
+func TestEventBrokerReplay(t *testing.T) {
+	broker := NewEventBroker()
+	for i := 0; i < 3; i++ {
+		broker.Publish(csapi.Event{Type: csapi.EventType_TaskError})
+	}
+
+	missed, _, unsubscribe := broker.Subscribe(1)
+	defer unsubscribe()
+	require.Len(t, missed, 2)
+	require.Equal(t, uint64(2), missed[0].ID)
+	require.Equal(t, uint64(3), missed[1].ID)
+
+	// Skipping the history returns nothing
+	missed, _, unsubscribe2 := broker.Subscribe(0)
+	defer unsubscribe2()
+	require.Empty(t, missed)
+}
+
+// Make sure the history only keeps the most recent events
+func TestEventBrokerHistoryLimit(t *testing.T) {
+	broker := NewEventBroker()
+	total := eventHistorySize + 10
+	for i := 0; i < total; i++ {
+		broker.Publish(csapi.Event{Type: csapi.EventType_TaskError})
+	}
+
+	missed, _, unsubscribe := broker.Subscribe(1)
+	defer unsubscribe()
+	require.Len(t, missed, eventHistorySize)
+	require.Equal(t, uint64(total-eventHistorySize+1), missed[0].ID)
+	require.Equal(t, uint64(total), missed[len(missed)-1].ID)
+}
+
+// Make sure a subscriber that falls behind is dropped without affecting the others
+func TestEventBrokerDropsSlowSubscriber(t *testing.T) {
+	broker := NewEventBroker()
+	_, slow, unsubscribeSlow := broker.Subscribe(0)
+	defer unsubscribeSlow()
+	_, fast, unsubscribeFast := broker.Subscribe(0)
+	defer unsubscribeFast()
+
+	// Fill the slow subscriber's buffer and overflow it, while draining the fast one
+	for i := 0; i < eventSubscriberBufferSize+1; i++ {
+		broker.Publish(csapi.Event{Type: csapi.EventType_TaskError})
+		event := <-fast
+		require.Equal(t, uint64(i+1), event.ID)
+	}
+
+	// The slow one gets what fit in its buffer, then is closed
+	for i := 0; i < eventSubscriberBufferSize; i++ {
+		event, ok := <-slow
+		require.True(t, ok)
+		require.Equal(t, uint64(i+1), event.ID)
+	}
+	_, ok := <-slow
+	require.False(t, ok)
+
+	// The fast one keeps getting events
+	broker.Publish(csapi.Event{Type: csapi.EventType_TaskError})
+	event, ok := <-fast
+	require.True(t, ok)
+	require.Equal(t, uint64(eventSubscriberBufferSize+2), event.ID)
+}
+
+// Make sure unsubscribing closes the channel and can safely be called more than once
+func TestEventBrokerUnsubscribe(t *testing.T) {
+	broker := NewEventBroker()
+	_, subscriber, unsubscribe := broker.Subscribe(0)
+	unsubscribe()
+	unsubscribe()
+	_, ok := <-subscriber
+	require.False(t, ok)
+
+	// Publishing with no subscribers is fine
+	broker.Publish(csapi.Event{Type: csapi.EventType_TaskError})
+}
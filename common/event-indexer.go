@@ -0,0 +1,557 @@
+package cscommon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/nodeset-org/hyperdrive-constellation/common/contracts/constellation"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/services"
+	"github.com/rocket-pool/rocketpool-go/v2/rocketpool"
+)
+
+const (
+	eventIndexFilename string = "event_index"
+
+	// The number of blocks to request logs for at once; many providers reject larger ranges
+	eventIndexChunkSize uint64 = 10000
+
+	// The most blocks a single update will scan, so catching up on a long history doesn't hold up the task loop for too long
+	eventIndexMaxBlocksPerUpdate uint64 = 100000
+
+	// The number of recent checkpoints kept to find the common ancestor after a reorg
+	eventIndexCheckpointCount int = 32
+)
+
+// A block the index has scanned up to, and its hash at the time
+type eventIndexCheckpoint struct {
+	Block uint64      `json:"block"`
+	Hash  common.Hash `json:"hash"`
+}
+
+// Persistent state of the event indexer
+type eventIndexData struct {
+	StartBlock         uint64                        `json:"startBlock"`
+	Contracts          map[string]common.Address     `json:"contracts"`
+	Checkpoints        []eventIndexCheckpoint        `json:"checkpoints"`
+	MinipoolsCreated   []csapi.MinipoolContractEvent `json:"minipoolsCreated"`
+	MinipoolsDestroyed []csapi.MinipoolContractEvent `json:"minipoolsDestroyed"`
+	Upgrades           []csapi.ProxyUpgradeEvent     `json:"upgrades"`
+	AdminChanges       []csapi.ProxyAdminChangeEvent `json:"adminChanges"`
+	RewardClaims       []csapi.RewardClaimEvent      `json:"rewardClaims"`
+}
+
+// How far the event index has been built
+type EventIndexStatus struct {
+	// The first block the index covers
+	StartBlock uint64
+
+	// The last block the index covers; only valid if IsIndexed is true
+	IndexedBlock uint64
+
+	// The latest block on the chain as of the last update
+	LatestBlock uint64
+
+	// True if any blocks have been indexed yet
+	IsIndexed bool
+}
+
+// True if the index covers every block up to the latest one as of the last update
+func (s EventIndexStatus) IsCaughtUp() bool {
+	return s.IsIndexed && s.IndexedBlock >= s.LatestBlock
+}
+
+// Incrementally indexes the events of the Constellation contracts, and the supernode's Rocket Pool rewards claims,
+// so routes can look up historical activity without enumerating the contracts' state. The index is built in chunks from
+// a checkpoint, rolled back to the last checkpoint still on the canonical chain when a reorg is detected, and rebuilt if
+// the contract addresses change.
+// Only the index events task updates it; routes just read what's been indexed so far.
+type EventIndexer struct {
+	sp          services.IModuleServiceProvider
+	csMgr       *ConstellationManager
+	rpMgr       *RocketPoolManager
+	logger      *slog.Logger
+	startBlock  uint64
+	latestBlock uint64
+	data        eventIndexData
+
+	// Serializes updates; the data is only ever modified by an update
+	updateLock *sync.Mutex
+
+	// Guards the data and latest block; never held during network or disk I/O, so readers aren't blocked by an update
+	lock *sync.Mutex
+}
+
+// Create a new event indexer, loading its state from disk if present.
+// If the index on disk was built from a different start block, it will be rebuilt.
+func NewEventIndexer(sp services.IModuleServiceProvider, csMgr *ConstellationManager, rpMgr *RocketPoolManager, startBlock uint64, logger *slog.Logger) (*EventIndexer, error) {
+	indexer := &EventIndexer{
+		sp:         sp,
+		csMgr:      csMgr,
+		rpMgr:      rpMgr,
+		logger:     logger,
+		startBlock: startBlock,
+		updateLock: &sync.Mutex{},
+		lock:       &sync.Mutex{},
+	}
+	indexer.reset(map[string]common.Address{})
+
+	// Check if the data exists
+	dataPath := filepath.Join(sp.GetModuleDir(), eventIndexFilename)
+	_, err := os.Stat(dataPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return indexer, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error checking status of event index file [%s]: %w", dataPath, err)
+	}
+
+	// Read it
+	bytes, err := os.ReadFile(dataPath)
+	if err != nil {
+		return nil, fmt.Errorf("error loading event index: %w", err)
+	}
+	var data eventIndexData
+	err = json.Unmarshal(bytes, &data)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing event index: %w", err)
+	}
+	// Indexes from before rewards claims were tracked are rebuilt too, so they pick up the claims they skipped
+	if data.StartBlock == startBlock && data.RewardClaims != nil {
+		indexer.data = data
+	}
+	return indexer, nil
+}
+
+// Get how far the index has been built
+func (i *EventIndexer) GetStatus() EventIndexStatus {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	return i.getStatus()
+}
+
+// Scan the blocks since the last checkpoint for new events, up to a limited number of blocks per call.
+// The Constellation and Rocket Pool contracts must be loaded and the EC must be synced before calling this.
+// Only the index events task should call this.
+func (i *EventIndexer) Update(ctx context.Context) (EventIndexStatus, error) {
+	i.updateLock.Lock()
+	defer i.updateLock.Unlock()
+
+	// Rebuild the index if the contracts moved, since the old history doesn't apply to them anymore
+	if !i.csMgr.Contracts().isLoaded {
+		return i.GetStatus(), fmt.Errorf("the Constellation contracts haven't been loaded yet")
+	}
+	contracts := i.csMgr.GetContractAddresses()
+	distributor, err := i.rpMgr.RocketPool().GetContract(rocketpool.ContractName_RocketMerkleDistributorMainnet)
+	if err != nil {
+		return i.GetStatus(), fmt.Errorf("error getting Rocket Pool merkle distributor: %w", err)
+	}
+	if !sameContracts(i.data.Contracts, contracts) {
+		if len(i.data.Checkpoints) > 0 {
+			i.logger.Warn("Constellation contract addresses changed, rebuilding the event index")
+		}
+		i.lock.Lock()
+		i.reset(contracts)
+		i.lock.Unlock()
+	}
+
+	// Roll back to the last checkpoint that's still on the canonical chain
+	err = i.handleReorgs(ctx)
+	if err != nil {
+		return i.GetStatus(), err
+	}
+
+	// Get the range to scan
+	ec := i.sp.GetEthClient()
+	latestHeader, err := ec.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return i.GetStatus(), fmt.Errorf("error getting latest block header: %w", err)
+	}
+	latestBlock := latestHeader.Number.Uint64()
+	i.lock.Lock()
+	i.latestBlock = latestBlock
+	i.lock.Unlock()
+	nextBlock := i.getNextBlock()
+	if nextBlock > latestBlock {
+		return i.GetStatus(), nil
+	}
+	lastBlock := latestBlock
+	if lastBlock-nextBlock >= eventIndexMaxBlocksPerUpdate {
+		lastBlock = nextBlock + eventIndexMaxBlocksPerUpdate - 1
+	}
+
+	// Scan it in chunks, saving after each one so progress isn't lost
+	proxies := make([]common.Address, 0, len(contracts))
+	proxyNames := map[common.Address]string{}
+	for name, address := range contracts {
+		proxies = append(proxies, address)
+		proxyNames[address] = name
+	}
+	for start := nextBlock; start <= lastBlock; start += eventIndexChunkSize {
+		end := start + eventIndexChunkSize - 1
+		if end > lastBlock {
+			end = lastBlock
+		}
+		scanned, err := i.scanChunk(ctx, start, end, proxies, proxyNames, distributor.Address, distributor.ABI)
+		if err != nil {
+			return i.GetStatus(), fmt.Errorf("error scanning blocks %d to %d: %w", start, end, err)
+		}
+		err = i.saveData()
+		if err != nil {
+			return i.GetStatus(), err
+		}
+		if !scanned {
+			// The chain changed during the scan, so pick it up again on the next update
+			break
+		}
+	}
+	return i.GetStatus(), nil
+}
+
+// Clear the index and start it over from a new start block, such as after the network settings are reloaded with new
+// contracts. This waits for any update in progress to finish so it can't add events for the old contracts afterward.
+func (i *EventIndexer) Rebuild(startBlock uint64) error {
+	i.updateLock.Lock()
+	defer i.updateLock.Unlock()
+
+	i.lock.Lock()
+	i.startBlock = startBlock
+	i.latestBlock = 0
+	i.reset(map[string]common.Address{})
+	i.lock.Unlock()
+	return i.saveData()
+}
+
+// Get the MinipoolCreated and MinipoolDestroyed events for a sub-node operator, starting at the given block
+func (i *EventIndexer) GetMinipoolEvents(operator common.Address, startBlock uint64) ([]csapi.MinipoolContractEvent, []csapi.MinipoolContractEvent) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	created := []csapi.MinipoolContractEvent{}
+	for _, event := range i.data.MinipoolsCreated {
+		if event.Operator == operator && event.Block >= startBlock {
+			created = append(created, event)
+		}
+	}
+	destroyed := []csapi.MinipoolContractEvent{}
+	for _, event := range i.data.MinipoolsDestroyed {
+		if event.Operator == operator && event.Block >= startBlock {
+			destroyed = append(destroyed, event)
+		}
+	}
+	return created, destroyed
+}
+
+// Get the Upgraded and AdminChanged events for the Constellation proxies, starting at the given block
+func (i *EventIndexer) GetProxyEvents(startBlock uint64) ([]csapi.ProxyUpgradeEvent, []csapi.ProxyAdminChangeEvent) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	upgrades := []csapi.ProxyUpgradeEvent{}
+	for _, event := range i.data.Upgrades {
+		if event.Block >= startBlock {
+			upgrades = append(upgrades, event)
+		}
+	}
+	adminChanges := []csapi.ProxyAdminChangeEvent{}
+	for _, event := range i.data.AdminChanges {
+		if event.Block >= startBlock {
+			adminChanges = append(adminChanges, event)
+		}
+	}
+	return upgrades, adminChanges
+}
+
+// Get the rewards the supernode has claimed from Rocket Pool, by interval
+func (i *EventIndexer) GetRewardClaims() []csapi.RewardClaimEvent {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	claims := make([]csapi.RewardClaimEvent, len(i.data.RewardClaims))
+	copy(claims, i.data.RewardClaims)
+	return claims
+}
+
+// Scan a range of blocks and add its events to the index.
+// Returns false without changing the index if the end block was reorged during the scan.
+func (i *EventIndexer) scanChunk(ctx context.Context, start uint64, end uint64, proxies []common.Address, proxyNames map[common.Address]string, distributor common.Address, distributorAbi *abi.ABI) (bool, error) {
+	ec := i.sp.GetEthClient()
+	startBig := new(big.Int).SetUint64(start)
+	endBig := new(big.Int).SetUint64(end)
+
+	// Get the end block before the logs so a reorg during the scan can be detected
+	endHeader, err := ec.HeaderByNumber(ctx, endBig)
+	if err != nil {
+		return false, fmt.Errorf("error getting header for block %d: %w", end, err)
+	}
+
+	// Get the events
+	sna := i.csMgr.Contracts().SuperNodeAccount
+	created, err := sna.FilterMinipoolCreated(ctx, startBig, endBig, nil, nil)
+	if err != nil {
+		return false, err
+	}
+	destroyed, err := sna.FilterMinipoolDestroyed(ctx, startBig, endBig, nil, nil)
+	if err != nil {
+		return false, err
+	}
+	upgrades, err := constellation.FilterUpgraded(ctx, ec, proxies, startBig, endBig, nil)
+	if err != nil {
+		return false, err
+	}
+	adminChanges, err := constellation.FilterAdminChanged(ctx, ec, proxies, startBig, endBig)
+	if err != nil {
+		return false, err
+	}
+	claims, err := i.filterRewardClaims(ctx, startBig, endBig, sna.Address, distributor, distributorAbi)
+	if err != nil {
+		return false, err
+	}
+
+	// Make sure the chain didn't change underneath the scan
+	checkHeader, err := ec.HeaderByNumber(ctx, endBig)
+	if err != nil {
+		return false, fmt.Errorf("error getting header for block %d: %w", end, err)
+	}
+	if checkHeader.Hash() != endHeader.Hash() {
+		i.logger.Warn("Chain reorg detected while indexing events, retrying later", slog.Uint64("block", end))
+		return false, nil
+	}
+
+	// Add them to the index
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	for _, event := range created {
+		i.data.MinipoolsCreated = append(i.data.MinipoolsCreated, csapi.MinipoolContractEvent{
+			Minipool: event.MinipoolAddress,
+			Operator: event.OperatorAddress,
+			Block:    event.Raw.BlockNumber,
+			TxHash:   event.Raw.TxHash,
+			LogIndex: event.Raw.Index,
+		})
+	}
+	for _, event := range destroyed {
+		i.data.MinipoolsDestroyed = append(i.data.MinipoolsDestroyed, csapi.MinipoolContractEvent{
+			Minipool: event.MinipoolAddress,
+			Operator: event.OperatorAddress,
+			Block:    event.Raw.BlockNumber,
+			TxHash:   event.Raw.TxHash,
+			LogIndex: event.Raw.Index,
+		})
+	}
+	for _, event := range upgrades {
+		i.data.Upgrades = append(i.data.Upgrades, csapi.ProxyUpgradeEvent{
+			Contract:       proxyNames[event.Raw.Address],
+			Proxy:          event.Raw.Address,
+			Implementation: event.Implementation,
+			Block:          event.Raw.BlockNumber,
+			TxHash:         event.Raw.TxHash,
+			LogIndex:       event.Raw.Index,
+		})
+	}
+	for _, event := range adminChanges {
+		i.data.AdminChanges = append(i.data.AdminChanges, csapi.ProxyAdminChangeEvent{
+			Contract:      proxyNames[event.Raw.Address],
+			Proxy:         event.Raw.Address,
+			PreviousAdmin: event.PreviousAdmin,
+			NewAdmin:      event.NewAdmin,
+			Block:         event.Raw.BlockNumber,
+			TxHash:        event.Raw.TxHash,
+			LogIndex:      event.Raw.Index,
+		})
+	}
+
+	i.data.RewardClaims = append(i.data.RewardClaims, claims...)
+
+	// Move the checkpoint
+	i.data.Checkpoints = append(i.data.Checkpoints, eventIndexCheckpoint{
+		Block: end,
+		Hash:  endHeader.Hash(),
+	})
+	if len(i.data.Checkpoints) > eventIndexCheckpointCount {
+		i.data.Checkpoints = i.data.Checkpoints[len(i.data.Checkpoints)-eventIndexCheckpointCount:]
+	}
+	return true, nil
+}
+
+// Check the checkpoints against the canonical chain, newest first, and roll the index back to the first one that's still on it.
+// If none of them are, the index is rebuilt from scratch. The update lock must be held.
+func (i *EventIndexer) handleReorgs(ctx context.Context) error {
+	ec := i.sp.GetEthClient()
+	for j := len(i.data.Checkpoints) - 1; j >= 0; j-- {
+		checkpoint := i.data.Checkpoints[j]
+		header, err := ec.HeaderByNumber(ctx, new(big.Int).SetUint64(checkpoint.Block))
+		if errors.Is(err, ethereum.NotFound) {
+			// The canonical chain is shorter than it was, so the block is gone
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("error getting header for block %d: %w", checkpoint.Block, err)
+		}
+		if header.Hash() != checkpoint.Hash {
+			continue
+		}
+		if j == len(i.data.Checkpoints)-1 {
+			return nil
+		}
+
+		// Drop everything after the common ancestor
+		i.logger.Warn("Chain reorg detected, rolling back the event index",
+			slog.Uint64("from", i.data.Checkpoints[len(i.data.Checkpoints)-1].Block),
+			slog.Uint64("to", checkpoint.Block),
+		)
+		i.lock.Lock()
+		i.data.Checkpoints = i.data.Checkpoints[:j+1]
+		i.data.MinipoolsCreated = filterEventsUpTo(i.data.MinipoolsCreated, checkpoint.Block, func(e csapi.MinipoolContractEvent) uint64 { return e.Block })
+		i.data.MinipoolsDestroyed = filterEventsUpTo(i.data.MinipoolsDestroyed, checkpoint.Block, func(e csapi.MinipoolContractEvent) uint64 { return e.Block })
+		i.data.Upgrades = filterEventsUpTo(i.data.Upgrades, checkpoint.Block, func(e csapi.ProxyUpgradeEvent) uint64 { return e.Block })
+		i.data.AdminChanges = filterEventsUpTo(i.data.AdminChanges, checkpoint.Block, func(e csapi.ProxyAdminChangeEvent) uint64 { return e.Block })
+		i.data.RewardClaims = filterEventsUpTo(i.data.RewardClaims, checkpoint.Block, func(e csapi.RewardClaimEvent) uint64 { return e.Block })
+		i.lock.Unlock()
+		return i.saveData()
+	}
+
+	if len(i.data.Checkpoints) > 0 {
+		i.logger.Warn("Chain reorg went deeper than the event index's checkpoints, rebuilding it")
+		i.lock.Lock()
+		i.reset(i.data.Contracts)
+		i.lock.Unlock()
+		return i.saveData()
+	}
+	return nil
+}
+
+// Clear the index so it gets rebuilt for the given contracts; the lock must be held
+func (i *EventIndexer) reset(contracts map[string]common.Address) {
+	i.data = eventIndexData{
+		StartBlock:         i.startBlock,
+		Contracts:          contracts,
+		Checkpoints:        []eventIndexCheckpoint{},
+		MinipoolsCreated:   []csapi.MinipoolContractEvent{},
+		MinipoolsDestroyed: []csapi.MinipoolContractEvent{},
+		Upgrades:           []csapi.ProxyUpgradeEvent{},
+		AdminChanges:       []csapi.ProxyAdminChangeEvent{},
+		RewardClaims:       []csapi.RewardClaimEvent{},
+	}
+}
+
+// Get the supernode's RewardsClaimed events from the Rocket Pool merkle distributor in a range of blocks.
+// Each event can cover several intervals, so it's split into one claim per interval.
+func (i *EventIndexer) filterRewardClaims(ctx context.Context, startBlock *big.Int, endBlock *big.Int, superNode common.Address, distributor common.Address, distributorAbi *abi.ABI) ([]csapi.RewardClaimEvent, error) {
+	event, exists := distributorAbi.Events["RewardsClaimed"]
+	if !exists {
+		return nil, fmt.Errorf("the merkle distributor ABI doesn't have a RewardsClaimed event")
+	}
+	topics, err := abi.MakeTopics([]any{superNode})
+	if err != nil {
+		return nil, fmt.Errorf("error creating topics for RewardsClaimed: %w", err)
+	}
+	logs, err := i.sp.GetEthClient().FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: startBlock,
+		ToBlock:   endBlock,
+		Addresses: []common.Address{distributor},
+		Topics:    append([][]common.Hash{{event.ID}}, topics...),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting RewardsClaimed logs: %w", err)
+	}
+
+	claims := []csapi.RewardClaimEvent{}
+	for _, log := range logs {
+		var values struct {
+			RewardIndex []*big.Int
+			AmountRPL   []*big.Int
+			AmountETH   []*big.Int
+		}
+		err := distributorAbi.UnpackIntoInterface(&values, "RewardsClaimed", log.Data)
+		if err != nil {
+			return nil, fmt.Errorf("error unpacking RewardsClaimed log %d of TX %s: %w", log.Index, log.TxHash.Hex(), err)
+		}
+		if len(values.AmountRPL) != len(values.RewardIndex) || len(values.AmountETH) != len(values.RewardIndex) {
+			return nil, fmt.Errorf("RewardsClaimed log %d of TX %s has mismatched interval and amount counts", log.Index, log.TxHash.Hex())
+		}
+		for j, interval := range values.RewardIndex {
+			claims = append(claims, csapi.RewardClaimEvent{
+				Interval:  interval.Uint64(),
+				AmountEth: values.AmountETH[j],
+				AmountRpl: values.AmountRPL[j],
+				Block:     log.BlockNumber,
+				TxHash:    log.TxHash,
+				LogIndex:  log.Index,
+			})
+		}
+	}
+	return claims, nil
+}
+
+// Get the next block that needs to be scanned; the update lock must be held
+func (i *EventIndexer) getNextBlock() uint64 {
+	if len(i.data.Checkpoints) == 0 {
+		return i.data.StartBlock
+	}
+	return i.data.Checkpoints[len(i.data.Checkpoints)-1].Block + 1
+}
+
+// Get the status of the index; the lock must be held
+func (i *EventIndexer) getStatus() EventIndexStatus {
+	status := EventIndexStatus{
+		StartBlock:  i.data.StartBlock,
+		LatestBlock: i.latestBlock,
+	}
+	if len(i.data.Checkpoints) > 0 {
+		status.IndexedBlock = i.data.Checkpoints[len(i.data.Checkpoints)-1].Block
+		status.IsIndexed = true
+	}
+	return status
+}
+
+// Save the index to disk; the update lock must be held so the data doesn't change while it's being serialized
+func (i *EventIndexer) saveData() error {
+	// Serialize it
+	dataPath := filepath.Join(i.sp.GetModuleDir(), eventIndexFilename)
+	bytes, err := json.Marshal(i.data)
+	if err != nil {
+		return fmt.Errorf("error serializing event index: %w", err)
+	}
+
+	// Save it
+	err = os.WriteFile(dataPath, bytes, fileMode)
+	if err != nil {
+		return fmt.Errorf("error saving event index: %w", err)
+	}
+	return nil
+}
+
+// Check if two sets of contract addresses are the same
+func sameContracts(a map[string]common.Address, b map[string]common.Address) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, address := range a {
+		if b[name] != address {
+			return false
+		}
+	}
+	return true
+}
+
+// Remove the events after the given block
+func filterEventsUpTo[EventType any](events []EventType, block uint64, getBlock func(EventType) uint64) []EventType {
+	kept := []EventType{}
+	for _, event := range events {
+		if getBlock(event) <= block {
+			kept = append(kept, event)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,149 @@
+package cscommon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	csconfig "github.com/nodeset-org/hyperdrive-constellation/shared/config"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/services"
+)
+
+const (
+	feeRecipientMonitorFilename string = "fee_recipient_monitor"
+
+	// Violations older than this many epochs behind the last checked one are dropped (about 30 days)
+	feeRecipientViolationRetentionEpochs uint64 = 6750
+
+	// Max number of violations to keep, so a validator that's misconfigured for a long time can't grow the file forever
+	maxFeeRecipientViolations int = 1000
+
+	// The max length of a block's graffiti, in bytes
+	maxGraffitiLength int = 32
+)
+
+// Persistent state of the fee recipient monitor
+type feeRecipientMonitorData struct {
+	// True if at least one epoch has been scanned
+	HasScanned bool `json:"hasScanned"`
+
+	// The last epoch that was scanned for proposals
+	LastCheckedEpoch uint64 `json:"lastCheckedEpoch"`
+
+	// Proposals that paid the wrong fee recipient or used the wrong graffiti
+	Violations []csapi.MinipoolFeeRecipientViolation `json:"violations"`
+}
+
+// Tracks proposals made by the node's validators that didn't pay the expected fee recipient or use the expected graffiti.
+// The state is shared between the task loop, which scans the chain, and the API server, which reports on it.
+type FeeRecipientMonitor struct {
+	sp   services.IModuleServiceProvider
+	data feeRecipientMonitorData
+	lock *sync.Mutex
+}
+
+// Create a new fee recipient monitor, loading its state from disk if present
+func NewFeeRecipientMonitor(sp services.IModuleServiceProvider) (*FeeRecipientMonitor, error) {
+	m := &FeeRecipientMonitor{
+		sp:   sp,
+		lock: &sync.Mutex{},
+	}
+
+	// Check if the data exists
+	dataPath := filepath.Join(sp.GetModuleDir(), feeRecipientMonitorFilename)
+	_, err := os.Stat(dataPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return m, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error checking status of fee recipient monitor file [%s]: %w", dataPath, err)
+	}
+
+	// Read it
+	bytes, err := os.ReadFile(dataPath)
+	if err != nil {
+		return nil, fmt.Errorf("error loading fee recipient monitor data: %w", err)
+	}
+	err = json.Unmarshal(bytes, &m.data)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing fee recipient monitor data: %w", err)
+	}
+	return m, nil
+}
+
+// Get the last epoch that was scanned; the bool will be false if nothing has been scanned yet
+func (m *FeeRecipientMonitor) GetLastCheckedEpoch() (uint64, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.data.LastCheckedEpoch, m.data.HasScanned
+}
+
+// Get a copy of the violations found so far
+func (m *FeeRecipientMonitor) GetViolations() []csapi.MinipoolFeeRecipientViolation {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	violations := make([]csapi.MinipoolFeeRecipientViolation, len(m.data.Violations))
+	copy(violations, m.data.Violations)
+	return violations
+}
+
+// Mark an epoch as scanned, recording any violations found in it, and save the state to disk.
+// Violations that have aged out of the retention window are dropped.
+func (m *FeeRecipientMonitor) MarkEpochChecked(epoch uint64, violations []csapi.MinipoolFeeRecipientViolation) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.data.HasScanned = true
+	m.data.LastCheckedEpoch = epoch
+	m.data.Violations = append(m.data.Violations, violations...)
+	m.pruneViolations()
+	return m.saveData()
+}
+
+// Drop violations that are older than the retention window, then the oldest ones past the max count
+func (m *FeeRecipientMonitor) pruneViolations() {
+	var cutoff uint64
+	if m.data.LastCheckedEpoch > feeRecipientViolationRetentionEpochs {
+		cutoff = m.data.LastCheckedEpoch - feeRecipientViolationRetentionEpochs
+	}
+	kept := []csapi.MinipoolFeeRecipientViolation{}
+	for _, violation := range m.data.Violations {
+		if violation.Epoch >= cutoff {
+			kept = append(kept, violation)
+		}
+	}
+	if len(kept) > maxFeeRecipientViolations {
+		kept = kept[len(kept)-maxFeeRecipientViolations:]
+	}
+	m.data.Violations = kept
+}
+
+// Write the monitor data to disk
+func (m *FeeRecipientMonitor) saveData() error {
+	// Serialize it
+	dataPath := filepath.Join(m.sp.GetModuleDir(), feeRecipientMonitorFilename)
+	bytes, err := json.Marshal(m.data)
+	if err != nil {
+		return fmt.Errorf("error serializing fee recipient monitor data: %w", err)
+	}
+
+	// Save it
+	err = os.WriteFile(dataPath, bytes, fileMode)
+	if err != nil {
+		return fmt.Errorf("error saving fee recipient monitor data: %w", err)
+	}
+	return nil
+}
+
+// Get the graffiti the VC puts in its blocks, which is the configured one cut down to the length a block can hold
+func GetExpectedGraffiti(cfg *csconfig.ConstellationConfig) string {
+	graffiti := cfg.Graffiti()
+	if len(graffiti) > maxGraffitiLength {
+		graffiti = graffiti[:maxGraffitiLength]
+	}
+	return graffiti
+}
@@ -0,0 +1,45 @@
+package cscommon
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	csconfig "github.com/nodeset-org/hyperdrive-constellation/shared/config"
+	"github.com/rocket-pool/node-manager-core/wallet"
+)
+
+// A sub-node tracked as part of the fleet
+type FleetNode struct {
+	// The node's address
+	Address common.Address
+
+	// True if this is the daemon's own node, false if it's only monitored
+	IsDaemonNode bool
+}
+
+// Get the nodes in the fleet, starting with the daemon's own node if it has an address.
+// Duplicate addresses are ignored.
+func GetFleetNodes(cfg *csconfig.ConstellationConfig, walletStatus wallet.WalletStatus) ([]FleetNode, error) {
+	addresses, err := cfg.GetFleetNodeAddresses()
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := []FleetNode{}
+	seen := map[common.Address]bool{}
+	if walletStatus.Address.HasAddress {
+		nodes = append(nodes, FleetNode{
+			Address:      walletStatus.Address.NodeAddress,
+			IsDaemonNode: true,
+		})
+		seen[walletStatus.Address.NodeAddress] = true
+	}
+	for _, address := range addresses {
+		if seen[address] {
+			continue
+		}
+		nodes = append(nodes, FleetNode{
+			Address: address,
+		})
+		seen[address] = true
+	}
+	return nodes, nil
+}
@@ -0,0 +1,173 @@
+package cscommon
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	csconfig "github.com/nodeset-org/hyperdrive-constellation/shared/config"
+	"github.com/rocket-pool/node-manager-core/node/services"
+)
+
+const (
+	// The name of the minipool stake task in gas plans
+	GasPlanTask_StakeMinipool string = "stakeMinipool"
+
+	// If the time left until a deadline is less than this multiple of the expected wait for a cheap block, the TX is submitted right away
+	gasDeadlineSafetyFactor time.Duration = 3
+
+	// Block time to assume if it can't be derived from the fee history
+	defaultBlockTime time.Duration = 12 * time.Second
+
+	// The fraction of the launch timeout period after which minipool stakes are forced, regardless of gas prices
+	stakeTimeoutSafetyFactor time.Duration = 2
+)
+
+// The subset of ethclient.Client that provides eth_feeHistory
+type feeHistoryProvider interface {
+	FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error)
+}
+
+// Samples the fee history of recent blocks and forecasts how long it will take for the base fee to drop to the configured cheap percentile
+func GetGasForecast(ctx context.Context, ecMgr *services.ExecutionClientManager, cfg *csconfig.ConstellationConfig) (csapi.NetworkGasForecast, error) {
+	forecast := csapi.NetworkGasForecast{}
+
+	// Get the fee history from whichever client is active
+	client := ecMgr.GetPrimaryClient()
+	if !ecMgr.IsPrimaryReady() && ecMgr.IsFallbackEnabled() && ecMgr.IsFallbackReady() {
+		client = ecMgr.GetFallbackClient()
+	}
+	fhClient, ok := client.(feeHistoryProvider)
+	if !ok {
+		return forecast, fmt.Errorf("execution client does not support fee history queries")
+	}
+	history, err := fhClient.FeeHistory(ctx, cfg.GasSampleBlocks.Value, nil, []float64{cfg.GasTipPercentile.Value})
+	if err != nil {
+		return forecast, fmt.Errorf("error getting fee history: %w", err)
+	}
+	blockCount := len(history.GasUsedRatio)
+	if blockCount == 0 || len(history.BaseFee) <= blockCount {
+		return forecast, fmt.Errorf("fee history didn't include any blocks")
+	}
+
+	// The base fee history includes the base fee of the next block at the end
+	baseFees := history.BaseFee[:blockCount]
+	forecast.CurrentBaseFee = history.BaseFee[blockCount]
+	forecast.SampledBlocks = uint64(blockCount)
+	forecast.LatestBlock = history.OldestBlock.Uint64() + uint64(blockCount) - 1
+
+	// Get the average block time across the sample
+	forecast.AverageBlockTime = defaultBlockTime
+	if blockCount > 1 {
+		oldestHeader, err := ecMgr.HeaderByNumber(ctx, history.OldestBlock)
+		if err != nil {
+			return forecast, fmt.Errorf("error getting header for block %d: %w", history.OldestBlock.Uint64(), err)
+		}
+		latestHeader, err := ecMgr.HeaderByNumber(ctx, new(big.Int).SetUint64(forecast.LatestBlock))
+		if err != nil {
+			return forecast, fmt.Errorf("error getting header for block %d: %w", forecast.LatestBlock, err)
+		}
+		if latestHeader.Time > oldestHeader.Time {
+			forecast.AverageBlockTime = time.Duration(latestHeader.Time-oldestHeader.Time) * time.Second / time.Duration(blockCount-1)
+		}
+	}
+
+	// Get the target base fee
+	forecast.MedianBaseFee = getPercentile(baseFees, 50)
+	forecast.TargetBaseFee = getPercentile(baseFees, cfg.GasCheapPercentile.Value)
+
+	// Walk the sample to find how often blocks were cheap and how long the expensive streaks in between lasted.
+	// For a random point in the sample, the expected wait for a cheap block is the sum of L(L+1)/2 over the streak lengths L, divided by the sample size.
+	cheapCount := 0
+	streakLength := uint64(0)
+	totalWait := uint64(0)
+	for _, baseFee := range baseFees {
+		if baseFee.Cmp(forecast.TargetBaseFee) <= 0 {
+			cheapCount++
+			totalWait += streakLength * (streakLength + 1) / 2
+			streakLength = 0
+		} else {
+			streakLength++
+		}
+	}
+	totalWait += streakLength * (streakLength + 1) / 2
+	forecast.CheapBlockRatio = float64(cheapCount) / float64(blockCount)
+	forecast.ExpectedWaitForCheapBlock = time.Duration(totalWait) * forecast.AverageBlockTime / time.Duration(blockCount)
+
+	// Get the tip from the rewards paid in the sample
+	tips := []*big.Int{}
+	for _, rewards := range history.Reward {
+		if len(rewards) > 0 && rewards[0] != nil {
+			tips = append(tips, rewards[0])
+		}
+	}
+	if len(tips) > 0 {
+		forecast.SuggestedPriorityFee = getPercentile(tips, 50)
+	} else {
+		forecast.SuggestedPriorityFee, err = ecMgr.SuggestGasTipCap(ctx)
+		if err != nil {
+			return forecast, fmt.Errorf("error getting suggested priority fee: %w", err)
+		}
+	}
+	return forecast, nil
+}
+
+// Decide whether a transaction with the given submission window should be submitted now or wait for a cheaper base fee, based on a gas forecast.
+// The task and minipool fields of the returned entry are left for the caller to fill in.
+func PlanGasSubmission(forecast csapi.NetworkGasForecast, earliest time.Time, deadline time.Time, now time.Time) csapi.NetworkGasPlanEntry {
+	entry := csapi.NetworkGasPlanEntry{
+		EarliestSubmission:   earliest,
+		Deadline:             deadline,
+		MaxPriorityFeePerGas: forecast.SuggestedPriorityFee,
+	}
+	switch {
+	case now.Before(earliest):
+		entry.PlannedSubmission = earliest
+		entry.Reason = "not eligible for submission yet"
+	case !now.Before(deadline):
+		entry.SubmitNow = true
+		entry.Reason = "deadline has passed"
+	case forecast.CurrentBaseFee.Cmp(forecast.TargetBaseFee) <= 0:
+		entry.SubmitNow = true
+		entry.Reason = "base fee is at or below the target"
+	case deadline.Sub(now) <= forecast.ExpectedWaitForCheapBlock*gasDeadlineSafetyFactor:
+		entry.SubmitNow = true
+		entry.Reason = "not enough time left before the deadline to wait for a cheap block"
+	default:
+		entry.PlannedSubmission = now.Add(forecast.ExpectedWaitForCheapBlock)
+		entry.Reason = "waiting for the base fee to drop to the target"
+	}
+	if entry.SubmitNow {
+		entry.PlannedSubmission = now
+	}
+
+	// Leave room for the base fee to double while the TX is pending
+	baseFee := forecast.TargetBaseFee
+	if entry.SubmitNow {
+		baseFee = forecast.CurrentBaseFee
+	}
+	entry.MaxFeePerGas = new(big.Int).Mul(baseFee, big.NewInt(suggestedBaseFeeMultiplier))
+	entry.MaxFeePerGas.Add(entry.MaxFeePerGas, entry.MaxPriorityFeePerGas)
+	return entry
+}
+
+// Get the time at which a minipool's stake is due and needs to bypass any gas price limits
+func GetStakeDueTime(prelaunchTime time.Time, launchTimeout time.Duration) time.Time {
+	return prelaunchTime.Add(launchTimeout / stakeTimeoutSafetyFactor)
+}
+
+// Get the value at the given percentile (0-100) of a list of values
+func getPercentile(values []*big.Int, percentile float64) *big.Int {
+	sorted := make([]*big.Int, len(values))
+	copy(sorted, values)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Cmp(sorted[j]) < 0
+	})
+	index := int(percentile / 100 * float64(len(sorted)-1))
+	index = max(0, min(index, len(sorted)-1))
+	return new(big.Int).Set(sorted[index])
+}
@@ -0,0 +1,139 @@
+package cscommon
+
+import (
+	"sync"
+	"time"
+
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+)
+
+const (
+	// If the task loop hasn't reported in for this long, it's considered stalled and the daemon is unhealthy.
+	// This needs to be comfortably longer than the task loop's interval plus the time it takes to run the tasks.
+	taskLoopStallThreshold time.Duration = 20 * time.Minute
+)
+
+// Tracks the task loop's view of whether the daemon is ready to do its job, so the API server can report it to healthchecks.
+// The task loop updates this as it runs; nothing here is queried live.
+type HealthTracker struct {
+	startTime                time.Time
+	lastUpdate               time.Time
+	executionClientSynced    bool
+	beaconNodeSynced         bool
+	walletReady              bool
+	nodeSetRegistered        bool
+	constellationWhitelisted bool
+	hasSnapshot              bool
+	lastSnapshotBlock        uint64
+	lastSnapshotTime         time.Time
+	lock                     *sync.Mutex
+}
+
+// Create a new health tracker
+func NewHealthTracker() *HealthTracker {
+	return &HealthTracker{
+		startTime: time.Now(),
+		lock:      &sync.Mutex{},
+	}
+}
+
+// Record the sync status of the Execution Client
+func (t *HealthTracker) SetExecutionClientSynced(synced bool) {
+	t.update(func() {
+		t.executionClientSynced = synced
+	})
+}
+
+// Record the sync status of the Beacon Node
+func (t *HealthTracker) SetBeaconNodeSynced(synced bool) {
+	t.update(func() {
+		t.beaconNodeSynced = synced
+	})
+}
+
+// Record whether the node wallet is ready
+func (t *HealthTracker) SetWalletReady(ready bool) {
+	t.update(func() {
+		t.walletReady = ready
+	})
+}
+
+// Record whether the node is registered with NodeSet
+func (t *HealthTracker) SetNodeSetRegistered(registered bool) {
+	t.update(func() {
+		t.nodeSetRegistered = registered
+	})
+}
+
+// Record a successful network snapshot, and whether the node was on the Constellation whitelist at the time
+func (t *HealthTracker) SetSnapshot(block uint64, whitelisted bool) {
+	t.update(func() {
+		t.hasSnapshot = true
+		t.lastSnapshotBlock = block
+		t.lastSnapshotTime = time.Now()
+		t.constellationWhitelisted = whitelisted
+	})
+}
+
+// Get the current health of the daemon.
+// It's healthy as long as the task loop hasn't stalled, and ready once the task loop can run all of its tasks.
+func (t *HealthTracker) GetHealth() csapi.ServiceHealthData {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	data := csapi.ServiceHealthData{
+		StartTime:                t.startTime,
+		LastTaskLoopUpdate:       t.lastUpdate,
+		ExecutionClientSynced:    t.executionClientSynced,
+		BeaconNodeSynced:         t.beaconNodeSynced,
+		WalletReady:              t.walletReady,
+		NodeSetRegistered:        t.nodeSetRegistered,
+		ConstellationWhitelisted: t.constellationWhitelisted,
+		HasSnapshot:              t.hasSnapshot,
+		LastSnapshotBlock:        t.lastSnapshotBlock,
+		LastSnapshotTime:         t.lastSnapshotTime,
+		Issues:                   []string{},
+	}
+
+	// Check the task loop, giving it time to start up.
+	// It's expected to block while it waits for the wallet or NodeSet registration, so that doesn't count as stalling.
+	lastActivity := t.lastUpdate
+	if lastActivity.IsZero() {
+		lastActivity = t.startTime
+	}
+	isWaiting := !t.lastUpdate.IsZero() && (!t.walletReady || !t.nodeSetRegistered)
+	data.TaskLoopStalled = !isWaiting && time.Since(lastActivity) > taskLoopStallThreshold
+	if data.TaskLoopStalled {
+		data.Issues = append(data.Issues, "the task loop hasn't reported in since "+lastActivity.Format(time.RFC3339))
+	}
+	data.Healthy = !data.TaskLoopStalled
+
+	// Check readiness
+	if !data.ExecutionClientSynced {
+		data.Issues = append(data.Issues, "the Execution Client isn't synced")
+	}
+	if !data.BeaconNodeSynced {
+		data.Issues = append(data.Issues, "the Beacon Node isn't synced")
+	}
+	if !data.WalletReady {
+		data.Issues = append(data.Issues, "the node wallet isn't ready")
+	}
+	if !data.NodeSetRegistered {
+		data.Issues = append(data.Issues, "the node isn't registered with NodeSet")
+	}
+	if !data.HasSnapshot {
+		data.Issues = append(data.Issues, "the task loop hasn't created a network snapshot yet")
+	} else if !data.ConstellationWhitelisted {
+		data.Issues = append(data.Issues, "the node isn't whitelisted for Constellation")
+	}
+	data.Ready = len(data.Issues) == 0
+	return data
+}
+
+// Run an update to the tracked state
+func (t *HealthTracker) update(setter func()) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	setter()
+	t.lastUpdate = time.Now()
+}
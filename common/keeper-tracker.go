@@ -0,0 +1,215 @@
+package cscommon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/services"
+)
+
+const (
+	keeperTrackerFilename string = "keeper_stats"
+
+	// The number of processed minipools to keep in the stats
+	keeperRecentlyProcessedLimit int = 20
+)
+
+// Tracks what the minipool processing keeper has spent and done, so it can stay within its daily budget across restarts.
+// The state is shared between the task loop, which runs the keeper, and the API server, which reports on it.
+type KeeperTracker struct {
+	path string
+	data csapi.KeeperStats
+	lock *sync.Mutex
+}
+
+// Create a new keeper tracker, loading its state from disk if present
+func NewKeeperTracker(sp services.IModuleServiceProvider) (*KeeperTracker, error) {
+	return newKeeperTracker(filepath.Join(sp.GetModuleDir(), keeperTrackerFilename))
+}
+
+// Create a new keeper tracker that saves its state at the given path
+func newKeeperTracker(dataPath string) (*KeeperTracker, error) {
+	t := &KeeperTracker{
+		path: dataPath,
+		lock: &sync.Mutex{},
+	}
+
+	// Check if the data exists
+	_, err := os.Stat(dataPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.data = csapi.KeeperStats{
+			SpentToday:        big.NewInt(0),
+			TotalSpent:        big.NewInt(0),
+			TotalDistributed:  big.NewInt(0),
+			RecentlyProcessed: []csapi.KeeperProcessedMinipool{},
+		}
+		return t, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error checking status of keeper stats file [%s]: %w", dataPath, err)
+	}
+
+	// Read it
+	bytes, err := os.ReadFile(dataPath)
+	if err != nil {
+		return nil, fmt.Errorf("error loading keeper stats: %w", err)
+	}
+	err = json.Unmarshal(bytes, &t.data)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing keeper stats: %w", err)
+	}
+	return t, nil
+}
+
+// Get a copy of the keeper's stats
+func (t *KeeperTracker) GetStats() csapi.KeeperStats {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.rollBudgetDay(time.Now())
+
+	stats := t.data
+	stats.SpentToday = new(big.Int).Set(t.data.SpentToday)
+	stats.TotalSpent = new(big.Int).Set(t.data.TotalSpent)
+	stats.TotalDistributed = new(big.Int).Set(t.data.TotalDistributed)
+	stats.RecentlyProcessed = make([]csapi.KeeperProcessedMinipool, len(t.data.RecentlyProcessed))
+	copy(stats.RecentlyProcessed, t.data.RecentlyProcessed)
+	return stats
+}
+
+// Get how much of the given daily budget hasn't been spent yet today (UTC)
+func (t *KeeperTracker) GetRemainingBudget(dailyBudget *big.Int) *big.Int {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.rollBudgetDay(time.Now())
+
+	remaining := new(big.Int).Sub(dailyBudget, t.data.SpentToday)
+	if remaining.Sign() < 0 {
+		remaining.SetUint64(0)
+	}
+	return remaining
+}
+
+// Record a minipool the keeper processed, charging its estimated cost against today's budget until the transaction is
+// mined and its actual cost is known, and save the state to disk
+func (t *KeeperTracker) RecordProcessed(processed csapi.KeeperProcessedMinipool) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.rollBudgetDay(processed.Time)
+
+	t.data.SpentToday.Add(t.data.SpentToday, processed.EstimatedCost)
+	t.data.TotalSpent.Add(t.data.TotalSpent, processed.EstimatedCost)
+	t.data.TotalDistributed.Add(t.data.TotalDistributed, processed.DistributableRewards)
+	t.data.ProcessedCount++
+	t.data.LastCheckTime = processed.Time
+	t.data.LastSkipReason = csapi.KeeperSkipReason_None
+	t.data.LastSkipMessage = ""
+	t.data.RecentlyProcessed = append(t.data.RecentlyProcessed, processed)
+	if len(t.data.RecentlyProcessed) > keeperRecentlyProcessedLimit {
+		t.data.RecentlyProcessed = t.data.RecentlyProcessed[len(t.data.RecentlyProcessed)-keeperRecentlyProcessedLimit:]
+	}
+	return t.saveData()
+}
+
+// Replace the estimated cost of a processing transaction with what it actually cost once it's been mined, and save the
+// state to disk. The hashes should include every version of the transaction that was submitted, since a replacement may
+// be the one that was included. The receipt can be nil if the transaction's nonce was used by a different transaction,
+// in which case nothing was spent on it.
+// The bool will be false if none of the hashes belong to a minipool the keeper processed.
+func (t *KeeperTracker) RecordMined(hashes []common.Hash, receipt *types.Receipt) (bool, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.rollBudgetDay(time.Now())
+
+	// Find the minipool this transaction processed
+	var processed *csapi.KeeperProcessedMinipool
+	for i := range t.data.RecentlyProcessed {
+		for _, hash := range hashes {
+			if t.data.RecentlyProcessed[i].TxHash == hash {
+				processed = &t.data.RecentlyProcessed[i]
+				break
+			}
+		}
+		if processed != nil {
+			break
+		}
+	}
+	if processed == nil || processed.ActualCost != nil {
+		return false, nil
+	}
+
+	// Get what it actually cost
+	actualCost := big.NewInt(0)
+	if receipt != nil {
+		actualCost.Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice)
+	}
+	processed.ActualCost = actualCost
+
+	// Swap the estimate for the actual cost, if the estimate was charged to today's budget; otherwise it was charged to a
+	// previous day that's already over, so the whole cost counts against today's
+	if processed.Time.UTC().Truncate(24 * time.Hour).Equal(t.data.BudgetDay) {
+		t.data.SpentToday.Sub(t.data.SpentToday, processed.EstimatedCost)
+	}
+	t.data.SpentToday.Add(t.data.SpentToday, actualCost)
+	if t.data.SpentToday.Sign() < 0 {
+		t.data.SpentToday.SetUint64(0)
+	}
+	t.data.TotalSpent.Sub(t.data.TotalSpent, processed.EstimatedCost)
+	t.data.TotalSpent.Add(t.data.TotalSpent, actualCost)
+	return true, t.saveData()
+}
+
+// Record a check where the keeper didn't process anything, and save the state to disk
+func (t *KeeperTracker) RecordSkipped(reason csapi.KeeperSkipReason, message string) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	now := time.Now()
+	t.rollBudgetDay(now)
+
+	switch reason {
+	case csapi.KeeperSkipReason_Unprofitable:
+		t.data.SkippedUnprofitableCount++
+	case csapi.KeeperSkipReason_OverBudget:
+		t.data.SkippedOverBudgetCount++
+	case csapi.KeeperSkipReason_SimulationReverted:
+		t.data.SkippedRevertedCount++
+	}
+	t.data.LastCheckTime = now
+	t.data.LastSkipReason = reason
+	t.data.LastSkipMessage = message
+	return t.saveData()
+}
+
+// Reset the amount spent today if the UTC day has changed since the last time anything was spent
+func (t *KeeperTracker) rollBudgetDay(now time.Time) {
+	day := now.UTC().Truncate(24 * time.Hour)
+	if t.data.BudgetDay.Equal(day) {
+		return
+	}
+	t.data.BudgetDay = day
+	t.data.SpentToday = big.NewInt(0)
+}
+
+// Write the keeper stats to disk
+func (t *KeeperTracker) saveData() error {
+	// Serialize it
+	bytes, err := json.Marshal(t.data)
+	if err != nil {
+		return fmt.Errorf("error serializing keeper stats: %w", err)
+	}
+
+	// Save it
+	err = os.WriteFile(t.path, bytes, fileMode)
+	if err != nil {
+		return fmt.Errorf("error saving keeper stats: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package cscommon
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/stretchr/testify/require"
+)
+
+// Make sure what was spent on a previous UTC day doesn't count against today's budget
+func TestKeeperBudgetResetsDaily(t *testing.T) {
+	keeper := newTestKeeperTracker(t)
+	dailyBudget := eth.EthToWei(0.1)
+
+	// Spend most of the budget yesterday
+	err := keeper.RecordProcessed(newTestProcessedMinipool(common.HexToHash("0x01"), time.Now().Add(-24*time.Hour), eth.EthToWei(0.08)))
+	require.NoError(t, err)
+	require.Equal(t, dailyBudget, keeper.GetRemainingBudget(dailyBudget))
+
+	// Spend some of it today
+	err = keeper.RecordProcessed(newTestProcessedMinipool(common.HexToHash("0x02"), time.Now(), eth.EthToWei(0.03)))
+	require.NoError(t, err)
+	require.Equal(t, eth.EthToWei(0.07), keeper.GetRemainingBudget(dailyBudget))
+
+	stats := keeper.GetStats()
+	require.Equal(t, eth.EthToWei(0.03), stats.SpentToday)
+	require.Equal(t, eth.EthToWei(0.11), stats.TotalSpent)
+	require.Equal(t, uint64(2), stats.ProcessedCount)
+}
+
+// Make sure spending past the budget leaves nothing rather than a negative amount
+func TestKeeperOverBudget(t *testing.T) {
+	keeper := newTestKeeperTracker(t)
+	dailyBudget := eth.EthToWei(0.1)
+
+	err := keeper.RecordProcessed(newTestProcessedMinipool(common.HexToHash("0x01"), time.Now(), eth.EthToWei(0.15)))
+	require.NoError(t, err)
+	require.Zero(t, keeper.GetRemainingBudget(dailyBudget).Sign())
+}
+
+// Make sure the estimate is swapped for the actual cost once a replacement of the transaction is mined, and only once
+func TestKeeperRecordMined(t *testing.T) {
+	keeper := newTestKeeperTracker(t)
+	dailyBudget := eth.EthToWei(0.1)
+	originalHash := common.HexToHash("0x01")
+	replacementHash := common.HexToHash("0x02")
+	err := keeper.RecordProcessed(newTestProcessedMinipool(originalHash, time.Now(), eth.EthToWei(0.01)))
+	require.NoError(t, err)
+
+	// The replacement paid more than the estimate
+	receipt := &types.Receipt{
+		TxHash:            replacementHash,
+		GasUsed:           1000000,
+		EffectiveGasPrice: eth.GweiToWei(50),
+	}
+	found, err := keeper.RecordMined([]common.Hash{replacementHash, originalHash}, receipt)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, eth.EthToWei(0.05), keeper.GetRemainingBudget(dailyBudget))
+	stats := keeper.GetStats()
+	require.Equal(t, eth.EthToWei(0.05), stats.TotalSpent)
+	require.Equal(t, eth.EthToWei(0.05), stats.RecentlyProcessed[0].ActualCost)
+
+	// Recording it again doesn't charge it twice
+	found, err = keeper.RecordMined([]common.Hash{replacementHash, originalHash}, receipt)
+	require.NoError(t, err)
+	require.False(t, found)
+	require.Equal(t, eth.EthToWei(0.05), keeper.GetRemainingBudget(dailyBudget))
+
+	// A transaction whose nonce was used by something else is refunded
+	err = keeper.RecordProcessed(newTestProcessedMinipool(common.HexToHash("0x03"), time.Now(), eth.EthToWei(0.01)))
+	require.NoError(t, err)
+	found, err = keeper.RecordMined([]common.Hash{common.HexToHash("0x03")}, nil)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, eth.EthToWei(0.05), keeper.GetRemainingBudget(dailyBudget))
+
+	// The state survives a restart
+	reloaded, err := newKeeperTracker(keeper.path)
+	require.NoError(t, err)
+	require.Equal(t, eth.EthToWei(0.05), reloaded.GetStats().TotalSpent)
+}
+
+// Create a keeper tracker that saves its state in a temporary directory
+func newTestKeeperTracker(t *testing.T) *KeeperTracker {
+	keeper, err := newKeeperTracker(filepath.Join(t.TempDir(), keeperTrackerFilename))
+	require.NoError(t, err)
+	return keeper
+}
+
+// Create a processed minipool record with the given estimated cost
+func newTestProcessedMinipool(hash common.Hash, processedTime time.Time, estimatedCost *big.Int) csapi.KeeperProcessedMinipool {
+	return csapi.KeeperProcessedMinipool{
+		Minipool:             testMinipoolAddress,
+		TxHash:               hash,
+		Time:                 processedTime,
+		DistributableRewards: eth.EthToWei(1),
+		EstimatedCost:        estimatedCost,
+	}
+}
@@ -0,0 +1,312 @@
+package cscommon
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	csconfig "github.com/nodeset-org/hyperdrive-constellation/shared/config"
+)
+
+const (
+	// Timeout for webhook requests
+	webhookTimeout time.Duration = 10 * time.Second
+
+	// Timeout for sending an email, used when the context doesn't have an earlier deadline
+	smtpTimeout time.Duration = 30 * time.Second
+
+	// The minimum time between repeats of a notification with the same key, so ongoing problems don't flood the sinks
+	notificationRepeatInterval time.Duration = 6 * time.Hour
+)
+
+// A destination that notifications can be sent to
+type INotificationSink interface {
+	// The name of the sink, used in error messages
+	GetName() string
+
+	// Send a notification
+	Send(ctx context.Context, notification csapi.Notification) error
+}
+
+// Sends notifications as JSON in the body of a POST request
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// Create a new webhook sink
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{
+		url: url,
+		client: &http.Client{
+			Timeout: webhookTimeout,
+		},
+	}
+}
+
+func (s *WebhookSink) GetName() string {
+	return "webhook"
+}
+
+func (s *WebhookSink) Send(ctx context.Context, notification csapi.Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("error serializing notification: %w", err)
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error creating webhook request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	response, err := s.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("error sending webhook request: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %s", response.Status)
+	}
+	return nil
+}
+
+// Sends notifications as plain-text emails through an SMTP server
+type SmtpSink struct {
+	address  string
+	host     string
+	username string
+	password string
+	from     string
+	to       []string
+}
+
+// Create a new SMTP sink
+func NewSmtpSink(host string, port uint16, username string, password string, from string, to []string) *SmtpSink {
+	return &SmtpSink{
+		address:  net.JoinHostPort(host, strconv.FormatUint(uint64(port), 10)),
+		host:     host,
+		username: username,
+		password: password,
+		from:     from,
+		to:       to,
+	}
+}
+
+func (s *SmtpSink) GetName() string {
+	return "email"
+}
+
+func (s *SmtpSink) Send(ctx context.Context, notification csapi.Notification) error {
+	var auth smtp.Auth
+	if s.username != "" {
+		auth = smtp.PlainAuth("", s.username, s.password, s.host)
+	}
+
+	// Build the message
+	var body strings.Builder
+	body.WriteString(fmt.Sprintf("From: %s\r\n", s.from))
+	body.WriteString(fmt.Sprintf("To: %s\r\n", strings.Join(s.to, ", ")))
+	body.WriteString(fmt.Sprintf("Subject: [Constellation] %s\r\n", notification.Title))
+	body.WriteString(fmt.Sprintf("Date: %s\r\n", notification.Time.Format(time.RFC1123Z)))
+	body.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	body.WriteString(notification.Message + "\r\n\r\n")
+	body.WriteString(fmt.Sprintf("Event: %s\r\n", notification.Event))
+	body.WriteString(fmt.Sprintf("Severity: %s\r\n", notification.Severity))
+	body.WriteString(fmt.Sprintf("Node: %s\r\n", notification.NodeAddress.Hex()))
+	if notification.Minipool != (common.Address{}) {
+		body.WriteString(fmt.Sprintf("Minipool: %s\r\n", notification.Minipool.Hex()))
+	}
+	if notification.TxHash != (common.Hash{}) {
+		body.WriteString(fmt.Sprintf("Transaction: %s\r\n", notification.TxHash.Hex()))
+	}
+
+	err := s.sendMail(ctx, auth, []byte(body.String()))
+	if err != nil {
+		return fmt.Errorf("error sending email: %w", err)
+	}
+	return nil
+}
+
+// Send an email to the recipients. This does the same thing as smtp.SendMail, but the connection is bound to the
+// context's deadline (or the default timeout) so an unresponsive server can't hang the caller.
+func (s *SmtpSink) sendMail(ctx context.Context, auth smtp.Auth, message []byte) error {
+	// The envelope needs the bare addresses, not the display names that are allowed in the headers
+	from, err := mail.ParseAddress(s.from)
+	if err != nil {
+		return fmt.Errorf("invalid sender address [%s]: %w", s.from, err)
+	}
+	recipients := make([]string, len(s.to))
+	for i, to := range s.to {
+		recipient, err := mail.ParseAddress(to)
+		if err != nil {
+			return fmt.Errorf("invalid recipient address [%s]: %w", to, err)
+		}
+		recipients[i] = recipient.Address
+	}
+
+	// Connect
+	ctx, cancel := context.WithTimeout(ctx, smtpTimeout)
+	defer cancel()
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", s.address)
+	if err != nil {
+		return fmt.Errorf("error connecting to [%s]: %w", s.address, err)
+	}
+	deadline, _ := ctx.Deadline()
+	err = conn.SetDeadline(deadline)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("error setting connection deadline: %w", err)
+	}
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("error starting SMTP session: %w", err)
+	}
+	defer client.Close()
+
+	// Upgrade to TLS and authenticate if possible
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		err = client.StartTLS(&tls.Config{ServerName: s.host})
+		if err != nil {
+			return fmt.Errorf("error starting TLS: %w", err)
+		}
+	}
+	if auth != nil {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return fmt.Errorf("server doesn't support authentication")
+		}
+		err = client.Auth(auth)
+		if err != nil {
+			return fmt.Errorf("error authenticating: %w", err)
+		}
+	}
+
+	// Send the message
+	err = client.Mail(from.Address)
+	if err != nil {
+		return fmt.Errorf("error setting sender: %w", err)
+	}
+	for _, recipient := range recipients {
+		err = client.Rcpt(recipient)
+		if err != nil {
+			return fmt.Errorf("error adding recipient [%s]: %w", recipient, err)
+		}
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("error starting message: %w", err)
+	}
+	_, err = writer.Write(message)
+	if err != nil {
+		return fmt.Errorf("error writing message: %w", err)
+	}
+	err = writer.Close()
+	if err != nil {
+		return fmt.Errorf("error finishing message: %w", err)
+	}
+	return client.Quit()
+}
+
+// Sends notifications about lifecycle events to the sinks in the notification settings.
+// The sinks are rebuilt from the settings on every send, so changes to them take effect right away.
+type Notifier struct {
+	cfg      *csconfig.NotificationsConfig
+	lastSent map[string]time.Time
+	lock     *sync.Mutex
+}
+
+// Create a new notifier
+func NewNotifier(cfg *csconfig.NotificationsConfig) *Notifier {
+	return &Notifier{
+		cfg:      cfg,
+		lastSent: map[string]time.Time{},
+		lock:     &sync.Mutex{},
+	}
+}
+
+// Send a notification to all of the sinks.
+// If the key isn't empty, notifications with the same key are only sent once per repeat interval.
+// Returns an error describing every sink that failed.
+func (n *Notifier) Notify(ctx context.Context, key string, notification csapi.Notification) error {
+	sinks, err := n.getSinks()
+	if err != nil {
+		return err
+	}
+	if len(sinks) == 0 {
+		return nil
+	}
+
+	if key != "" {
+		n.lock.Lock()
+		lastSent, exists := n.lastSent[key]
+		n.lock.Unlock()
+		if exists && time.Since(lastSent) < notificationRepeatInterval {
+			return nil
+		}
+	}
+	if notification.Time.IsZero() {
+		notification.Time = time.Now()
+	}
+
+	// Send it without holding the lock, only marking it as sent if every sink got it
+	errs := []error{}
+	for _, sink := range sinks {
+		err := sink.Send(ctx, notification)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error sending %s notification: %w", sink.GetName(), err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	if key != "" {
+		n.lock.Lock()
+		n.lastSent[key] = time.Now()
+		n.lock.Unlock()
+	}
+	return nil
+}
+
+// Clear the repeat state of a notification key, so the next notification with it is sent right away.
+// Use this when the condition a notification was about has been resolved.
+func (n *Notifier) Reset(key string) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	delete(n.lastSent, key)
+}
+
+// Get the sinks to send notifications to
+func (n *Notifier) getSinks() ([]INotificationSink, error) {
+	sinks := []INotificationSink{}
+	if n.cfg.WebhookUrl.Value != "" {
+		sinks = append(sinks, NewWebhookSink(n.cfg.WebhookUrl.Value))
+	}
+	if n.cfg.SmtpHost.Value != "" {
+		recipients, err := n.cfg.GetSmtpRecipients()
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, NewSmtpSink(
+			n.cfg.SmtpHost.Value,
+			n.cfg.SmtpPort.Value,
+			n.cfg.SmtpUsername.Value,
+			n.cfg.SmtpPassword.Value,
+			n.cfg.SmtpFrom.Value,
+			recipients,
+		))
+	}
+	return sinks, nil
+}
@@ -0,0 +1,269 @@
+package cscommon
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	csconfig "github.com/nodeset-org/hyperdrive-constellation/shared/config"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testNodeAddress     = common.HexToAddress("0x90F79bf6EB2c4f870365E785982E1f101E93b906")
+	testMinipoolAddress = common.HexToAddress("0x15d34AAf54267DB7D7c367839AAf71A00a2C6A65")
+)
+
+// Make sure notifications are delivered to the configured webhook with their details intact
+func TestNotifierWebhook(t *testing.T) {
+	receiver, received := newWebhookReceiver(t, http.StatusOK)
+	notifier := newWebhookNotifier(receiver.URL)
+
+	err := notifier.Notify(context.Background(), "launchTimeout", csapi.Notification{
+		Event:       csapi.NotificationEvent_LaunchTimeoutApproaching,
+		Severity:    csapi.NotificationSeverity_Error,
+		Title:       "Minipool is approaching its launch timeout",
+		Message:     "Test message",
+		NodeAddress: testNodeAddress,
+		Minipool:    testMinipoolAddress,
+	})
+	require.NoError(t, err)
+
+	notifications := received()
+	require.Len(t, notifications, 1)
+	notification := notifications[0]
+	require.Equal(t, csapi.NotificationEvent_LaunchTimeoutApproaching, notification.Event)
+	require.Equal(t, csapi.NotificationSeverity_Error, notification.Severity)
+	require.Equal(t, "Minipool is approaching its launch timeout", notification.Title)
+	require.Equal(t, "Test message", notification.Message)
+	require.Equal(t, testNodeAddress, notification.NodeAddress)
+	require.Equal(t, testMinipoolAddress, notification.Minipool)
+	require.False(t, notification.Time.IsZero())
+}
+
+// Make sure repeats of a notification key are suppressed until the key is reset, and unkeyed notifications never are
+func TestNotifierDeduplication(t *testing.T) {
+	receiver, received := newWebhookReceiver(t, http.StatusOK)
+	notifier := newWebhookNotifier(receiver.URL)
+	notification := csapi.Notification{
+		Event:       csapi.NotificationEvent_LaunchTimeoutApproaching,
+		NodeAddress: testNodeAddress,
+	}
+
+	// Repeats of the same key are dropped
+	require.NoError(t, notifier.Notify(context.Background(), "key1", notification))
+	require.NoError(t, notifier.Notify(context.Background(), "key1", notification))
+	require.Len(t, received(), 1)
+
+	// Other keys aren't affected
+	require.NoError(t, notifier.Notify(context.Background(), "key2", notification))
+	require.Len(t, received(), 2)
+
+	// Resetting the key lets it through again
+	notifier.Reset("key1")
+	require.NoError(t, notifier.Notify(context.Background(), "key1", notification))
+	require.Len(t, received(), 3)
+
+	// Unkeyed notifications are always sent
+	require.NoError(t, notifier.Notify(context.Background(), "", notification))
+	require.NoError(t, notifier.Notify(context.Background(), "", notification))
+	require.Len(t, received(), 5)
+}
+
+// Make sure a notification that failed to send isn't marked as sent, so the next attempt goes through
+func TestNotifierFailedSendIsRetried(t *testing.T) {
+	failing, failed := newWebhookReceiver(t, http.StatusInternalServerError)
+	notifier := newWebhookNotifier(failing.URL)
+	notification := csapi.Notification{
+		Event:       csapi.NotificationEvent_LaunchTimeoutApproaching,
+		NodeAddress: testNodeAddress,
+	}
+
+	err := notifier.Notify(context.Background(), "key", notification)
+	require.ErrorContains(t, err, "error sending webhook notification")
+	require.Len(t, failed(), 1)
+
+	// Point it at a working receiver; the key shouldn't be suppressed
+	working, received := newWebhookReceiver(t, http.StatusOK)
+	notifier.cfg.WebhookUrl.Value = working.URL
+	require.NoError(t, notifier.Notify(context.Background(), "key", notification))
+	require.Len(t, received(), 1)
+}
+
+// Make sure emails are sent with the bare addresses in the envelope, even if the settings have display names
+func TestSmtpSinkEnvelope(t *testing.T) {
+	server := newSmtpReceiver(t)
+	host, port := server.hostAndPort(t)
+	sink := NewSmtpSink(host, port, "", "", "Constellation <daemon@example.com>", []string{"Operator <operator@example.com>", "team@example.com"})
+
+	err := sink.Send(context.Background(), csapi.Notification{
+		Event:       csapi.NotificationEvent_LaunchTimeoutApproaching,
+		Title:       "Test notification",
+		Message:     "Test message",
+		NodeAddress: testNodeAddress,
+		Time:        time.Now(),
+	})
+	require.NoError(t, err)
+
+	commands := server.getCommands()
+	require.Contains(t, commands, "MAIL FROM:<daemon@example.com>")
+	require.Contains(t, commands, "RCPT TO:<operator@example.com>")
+	require.Contains(t, commands, "RCPT TO:<team@example.com>")
+	require.Contains(t, server.getMessage(), "Subject: [Constellation] Test notification")
+}
+
+// Make sure an SMTP server that never responds can't hang the sender past the context's deadline
+func TestSmtpSinkTimeout(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		// Accept connections but never send a greeting
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	addr := listener.Addr().(*net.TCPAddr)
+	sink := NewSmtpSink(addr.IP.String(), uint16(addr.Port), "", "", "daemon@example.com", []string{"operator@example.com"})
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err = sink.Send(ctx, csapi.Notification{NodeAddress: testNodeAddress})
+	require.Error(t, err)
+	require.Less(t, time.Since(start), 5*time.Second)
+}
+
+// Create a notifier that only sends to the given webhook
+func newWebhookNotifier(url string) *Notifier {
+	cfg := csconfig.NewNotificationsConfig()
+	cfg.WebhookUrl.Value = url
+	cfg.SmtpHost.Value = ""
+	return NewNotifier(cfg)
+}
+
+// Start a webhook receiver that responds with the given status; the returned function gets what it has received so far
+func newWebhookReceiver(t *testing.T, status int) (*httptest.Server, func() []csapi.Notification) {
+	lock := &sync.Mutex{}
+	notifications := []csapi.Notification{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var notification csapi.Notification
+		err := json.NewDecoder(r.Body).Decode(&notification)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		lock.Lock()
+		notifications = append(notifications, notification)
+		lock.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []csapi.Notification {
+		lock.Lock()
+		defer lock.Unlock()
+		return append([]csapi.Notification{}, notifications...)
+	}
+}
+
+// A minimal SMTP server that records the commands and message it receives
+type smtpReceiver struct {
+	listener net.Listener
+	commands []string
+	message  strings.Builder
+	lock     *sync.Mutex
+}
+
+func newSmtpReceiver(t *testing.T) *smtpReceiver {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	r := &smtpReceiver{
+		listener: listener,
+		lock:     &sync.Mutex{},
+	}
+	go r.serve()
+	return r
+}
+
+func (r *smtpReceiver) hostAndPort(t *testing.T) (string, uint16) {
+	addr, ok := r.listener.Addr().(*net.TCPAddr)
+	require.True(t, ok)
+	return addr.IP.String(), uint16(addr.Port)
+}
+
+func (r *smtpReceiver) serve() {
+	conn, err := r.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	write := func(line string) {
+		_, _ = conn.Write([]byte(line + "\r\n"))
+	}
+	write("220 localhost ESMTP")
+	inData := false
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		r.lock.Lock()
+		if inData {
+			if line == "." {
+				inData = false
+				r.lock.Unlock()
+				write("250 OK")
+				continue
+			}
+			r.message.WriteString(line + "\n")
+			r.lock.Unlock()
+			continue
+		}
+		r.commands = append(r.commands, line)
+		r.lock.Unlock()
+
+		switch {
+		case strings.HasPrefix(line, "EHLO"), strings.HasPrefix(line, "HELO"):
+			write("250 localhost")
+		case line == "DATA":
+			inData = true
+			write("354 Start mail input")
+		case line == "QUIT":
+			write("221 Bye")
+			return
+		default:
+			write("250 OK")
+		}
+	}
+}
+
+func (r *smtpReceiver) getCommands() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]string{}, r.commands...)
+}
+
+func (r *smtpReceiver) getMessage() string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.message.String()
+}
@@ -14,6 +14,16 @@ var (
 	ErrNotRegisteredWithConstellation error = errors.New("The node is not registered with Constellation yet.")
 )
 
+// Makes sure the EC is synced, and discovers the network resources that were left out of the settings if that hasn't happened
+// yet, so nothing that uses the chain runs with the placeholders the daemon started with
+func (p *constellationServiceProvider) RequireEthClientSynced(ctx context.Context) error {
+	err := p.IModuleServiceProvider.RequireEthClientSynced(ctx)
+	if err != nil {
+		return err
+	}
+	return p.reloader.DiscoverPending()
+}
+
 // Makes sure the node is registered with Constellation.
 // If useWalletAddress is true, the wallet address will be used to check registration.
 // If false, the node address will be used.
@@ -50,7 +60,7 @@ func (p *constellationServiceProvider) RequireRegisteredWithConstellation(ctx co
 	qMgr := p.GetQueryManager()
 	var registered bool
 	err = qMgr.Query(func(mc *batch.MultiCaller) error {
-		p.csMgr.Whitelist.IsAddressInWhitelist(mc, &registered, address)
+		p.csMgr.Contracts().Whitelist.IsAddressInWhitelist(mc, &registered, address)
 		return nil
 	}, nil)
 	if err != nil {
@@ -0,0 +1,132 @@
+package cscommon
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/nodeset-org/hyperdrive-constellation/common/contracts/constellation"
+	csconfig "github.com/nodeset-org/hyperdrive-constellation/shared/config"
+	batch "github.com/rocket-pool/batch-query"
+	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/rocket-pool/rocketpool-go/v2/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/v2/storage"
+	snconfig "github.com/rocket-pool/smartnode/v2/shared/config"
+)
+
+var (
+	// The network settings have addresses that don't match the ones the Constellation Directory points to
+	ErrResourceMismatch error = errors.New("network settings don't match the chain")
+)
+
+// The network resources that can be found on-chain, starting from the Constellation Directory
+type OnChainResources struct {
+	// The RocketStorage contract the Directory uses
+	RocketStorage common.Address
+
+	// The Rocket Pool Smoothing Pool, which is the only valid fee recipient for Constellation validators
+	SmoothingPool common.Address
+
+	// The RPL token the Directory uses
+	RplToken common.Address
+
+	// The rETH token registered in RocketStorage
+	RethToken common.Address
+}
+
+// Get the network resources from the Constellation Directory and the RocketStorage contract it points to.
+// Requires a synced EC to function properly; you're responsible for ensuring it's synced before calling this.
+func GetOnChainResources(directoryAddress common.Address, ec eth.IExecutionClient, qMgr *eth.QueryManager) (*OnChainResources, error) {
+	directory, err := constellation.NewDirectory(directoryAddress, ec, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating directory binding: %w", err)
+	}
+
+	// Get the addresses from the Directory
+	res := &OnChainResources{}
+	err = qMgr.Query(func(mc *batch.MultiCaller) error {
+		directory.GetRocketStorageAddress(mc, &res.RocketStorage)
+		directory.GetRplAddress(mc, &res.RplToken)
+		return nil
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting addresses from Directory [%s]: %w", directoryAddress.Hex(), err)
+	}
+	if res.RocketStorage == (common.Address{}) {
+		return nil, fmt.Errorf("Directory [%s] doesn't have a RocketStorage address", directoryAddress.Hex())
+	}
+
+	// Get the addresses from RocketStorage
+	rocketStorage, err := storage.NewStorage(ec, res.RocketStorage)
+	if err != nil {
+		return nil, fmt.Errorf("error creating RocketStorage binding: %w", err)
+	}
+	err = qMgr.Query(func(mc *batch.MultiCaller) error {
+		rocketStorage.GetAddress(mc, &res.SmoothingPool, string(rocketpool.ContractName_RocketSmoothingPool))
+		rocketStorage.GetAddress(mc, &res.RethToken, string(rocketpool.ContractName_RocketTokenRETH))
+		return nil
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting addresses from RocketStorage [%s]: %w", res.RocketStorage.Hex(), err)
+	}
+	if res.SmoothingPool == (common.Address{}) {
+		return nil, fmt.Errorf("RocketStorage [%s] doesn't have a Smoothing Pool address", res.RocketStorage.Hex())
+	}
+	return res, nil
+}
+
+// Fill in any resources left out of the network settings with the ones on-chain, then make sure the ones that were
+// set match the chain. The settings are updated in place. The returned resources are the ones that were found on-chain,
+// which the daemon saves so templating can use them too.
+// Requires a synced EC to function properly; you're responsible for ensuring it's synced before calling this.
+func DiscoverResources(settings *csconfig.ConstellationSettings, ec eth.IExecutionClient, qMgr *eth.QueryManager) (*csconfig.DiscoveredResources, error) {
+	directory := *settings.ConstellationResources.Directory
+	onChain, err := GetOnChainResources(directory, ec, qMgr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fill in the missing ones
+	discovered := &csconfig.DiscoveredResources{
+		Network:       settings.Key,
+		Directory:     directory,
+		RocketStorage: onChain.RocketStorage,
+		FeeRecipient:  onChain.SmoothingPool,
+		RplToken:      onChain.RplToken,
+		RethToken:     onChain.RethToken,
+	}
+	discovered.Fill(settings)
+
+	// Check the ones that were set
+	err = onChain.Verify(settings.ConstellationResources, settings.SmartNodeResources)
+	if err != nil {
+		return nil, err
+	}
+	return discovered, nil
+}
+
+// Make sure the addresses in the network settings match the ones on-chain
+func (r *OnChainResources) Verify(res *csconfig.ConstellationResources, snRes *snconfig.SmartNodeResources) error {
+	mismatches := []string{}
+	check := func(name string, configured common.Address, expected common.Address) {
+		if configured != expected {
+			mismatches = append(mismatches, fmt.Sprintf("%s is %s but should be %s", name, configured.Hex(), expected.Hex()))
+		}
+	}
+	if res.RocketStorage != nil {
+		check("constellationResources.rocketStorage", *res.RocketStorage, r.RocketStorage)
+	}
+	if res.FeeRecipient != nil {
+		check("constellationResources.feeRecipient", *res.FeeRecipient, r.SmoothingPool)
+	}
+	if snRes != nil {
+		check("smartNodeResources.storageAddress", snRes.StorageAddress, r.RocketStorage)
+		check("smartNodeResources.rplTokenAddress", snRes.RplTokenAddress, r.RplToken)
+		check("smartNodeResources.rethAddress", snRes.RethAddress, r.RethToken)
+	}
+	if len(mismatches) > 0 {
+		return fmt.Errorf("%w: %s", ErrResourceMismatch, strings.Join(mismatches, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package cscommon
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	csconfig "github.com/nodeset-org/hyperdrive-constellation/shared/config"
+	snconfig "github.com/rocket-pool/smartnode/v2/shared/config"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testRocketStorage = common.HexToAddress("0xe7f1725E7734CE288F8367e1Bb143E90bb3F0512")
+	testSmoothingPool = common.HexToAddress("0x0E801D84Fa97b50751Dbf25036d067dCf18858bF")
+	testRplToken      = common.HexToAddress("0xa513E6E4b8f2a923D98304ec87F64353C4D5C853")
+	testRethToken     = common.HexToAddress("0x5FC8d32690cc91D4c39d9d3abcBD16989F875707")
+	testWrongAddress  = common.HexToAddress("0x1111111111111111111111111111111111111111")
+)
+
+// Make sure settings that match the chain pass, and each address that doesn't is reported
+func TestOnChainResourcesVerify(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(*csconfig.ConstellationResources, *snconfig.SmartNodeResources)
+		mismatch string
+	}{
+		{
+			name:   "matching",
+			modify: func(*csconfig.ConstellationResources, *snconfig.SmartNodeResources) {},
+		},
+		{
+			name: "rocket storage",
+			modify: func(res *csconfig.ConstellationResources, _ *snconfig.SmartNodeResources) {
+				res.RocketStorage = &testWrongAddress
+			},
+			mismatch: "constellationResources.rocketStorage",
+		},
+		{
+			name: "fee recipient",
+			modify: func(res *csconfig.ConstellationResources, _ *snconfig.SmartNodeResources) {
+				res.FeeRecipient = &testWrongAddress
+			},
+			mismatch: "constellationResources.feeRecipient",
+		},
+		{
+			name: "smart node storage",
+			modify: func(_ *csconfig.ConstellationResources, snRes *snconfig.SmartNodeResources) {
+				snRes.StorageAddress = testWrongAddress
+			},
+			mismatch: "smartNodeResources.storageAddress",
+		},
+		{
+			name: "rpl token",
+			modify: func(_ *csconfig.ConstellationResources, snRes *snconfig.SmartNodeResources) {
+				snRes.RplTokenAddress = testWrongAddress
+			},
+			mismatch: "smartNodeResources.rplTokenAddress",
+		},
+		{
+			name: "reth token",
+			modify: func(_ *csconfig.ConstellationResources, snRes *snconfig.SmartNodeResources) {
+				snRes.RethAddress = testWrongAddress
+			},
+			mismatch: "smartNodeResources.rethAddress",
+		},
+	}
+	onChain := &OnChainResources{
+		RocketStorage: testRocketStorage,
+		SmoothingPool: testSmoothingPool,
+		RplToken:      testRplToken,
+		RethToken:     testRethToken,
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rocketStorage := testRocketStorage
+			feeRecipient := testSmoothingPool
+			res := &csconfig.ConstellationResources{
+				RocketStorage: &rocketStorage,
+				FeeRecipient:  &feeRecipient,
+			}
+			snRes := &snconfig.SmartNodeResources{
+				StorageAddress:  testRocketStorage,
+				RplTokenAddress: testRplToken,
+				RethAddress:     testRethToken,
+			}
+			test.modify(res, snRes)
+
+			err := onChain.Verify(res, snRes)
+			if test.mismatch == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrResourceMismatch)
+			require.ErrorContains(t, err, test.mismatch)
+			require.ErrorContains(t, err, testWrongAddress.Hex())
+		})
+	}
+}
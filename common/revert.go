@@ -0,0 +1,45 @@
+package cscommon
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/nodeset-org/hyperdrive-constellation/common/contracts/constellation"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/rocket-pool/node-manager-core/eth"
+)
+
+// Get the reason a TX failed simulation, decoding the contract's custom error if there was one.
+// Returns nil if the TX wasn't simulated or simulated successfully. This is best-effort; if the TX can't be replayed,
+// the simulation error message is returned on its own.
+func GetSimulationRevert(ctx context.Context, ec eth.IExecutionClient, from common.Address, txInfo *eth.TransactionInfo) *csapi.RevertInfo {
+	if txInfo == nil || txInfo.SimulationResult.SimulationError == "" {
+		return nil
+	}
+	info := &csapi.RevertInfo{
+		Arguments: []csapi.RevertArgument{},
+		Message:   txInfo.SimulationResult.SimulationError,
+	}
+
+	// Replay it to get the revert data
+	err := constellation.GetRevertError(ctx, ec, from, txInfo)
+	var revertErr *constellation.RevertError
+	if !errors.As(err, &revertErr) {
+		return info
+	}
+	info.Error = revertErr.Name
+	info.Reason = revertErr.Reason
+	info.Message = revertErr.Error()
+	if len(revertErr.Data) >= len(revertErr.Selector) {
+		info.Selector = revertErr.Selector[:]
+	}
+	for _, arg := range revertErr.Arguments {
+		info.Arguments = append(info.Arguments, csapi.RevertArgument{
+			Name:  arg.Name,
+			Type:  arg.Type,
+			Value: arg.String(),
+		})
+	}
+	return info
+}
@@ -3,6 +3,7 @@ package cscommon
 import (
 	"fmt"
 	"sync"
+	"sync/atomic"
 
 	"github.com/hashicorp/go-version"
 	csconfig "github.com/nodeset-org/hyperdrive-constellation/shared/config"
@@ -12,9 +13,8 @@ import (
 
 // Manager for the Rocket Pool binding
 type RocketPoolManager struct {
-	RocketPool *rocketpool.RocketPool
-
-	// Internal fields
+	// The binding is published atomically so a settings reload can swap it while routes and tasks are using it
+	rp                    atomic.Pointer[rocketpool.RocketPool]
 	loadedContractVersion *version.Version
 	refreshLock           *sync.Mutex
 }
@@ -29,11 +29,18 @@ func NewRocketPoolManager(res *csconfig.MergedResources, ec eth.IExecutionClient
 
 	// Create the manager
 	defaultVersion, _ := version.NewSemver("0.0.0")
-	return &RocketPoolManager{
-		RocketPool:            rp,
+	m := &RocketPoolManager{
 		loadedContractVersion: defaultVersion,
 		refreshLock:           &sync.Mutex{},
-	}, nil
+	}
+	m.rp.Store(rp)
+	return m, nil
+}
+
+// Get the Rocket Pool binding. Callers that use it for more than one call should hold onto the result rather than
+// calling this again, so they don't mix bindings if the settings are reloaded in between.
+func (m *RocketPoolManager) RocketPool() *rocketpool.RocketPool {
+	return m.rp.Load()
 }
 
 // Refresh the Rocket Pool contracts if they've been updated since they were last loaded.
@@ -43,14 +50,15 @@ func (m *RocketPoolManager) RefreshRocketPoolContracts() error {
 	defer m.refreshLock.Unlock()
 
 	// Get the version on-chain
-	protocolVersion, err := m.RocketPool.GetProtocolVersion(nil)
+	rp := m.rp.Load()
+	protocolVersion, err := rp.GetProtocolVersion(nil)
 	if err != nil {
 		return err
 	}
 
 	// Reload everything if it's different from what we have
 	if !m.loadedContractVersion.Equal(protocolVersion) {
-		err := m.RocketPool.LoadAllContracts(nil)
+		err := rp.LoadAllContracts(nil)
 		if err != nil {
 			return fmt.Errorf("error updating rocket pool contracts to [%s]: %w", protocolVersion.String(), err)
 		}
@@ -58,3 +66,15 @@ func (m *RocketPoolManager) RefreshRocketPoolContracts() error {
 	}
 	return nil
 }
+
+// Replace the binding with that of another manager, such as one created for new network settings.
+// The other manager should have its contracts refreshed already so callers never see unloaded contracts.
+func (m *RocketPoolManager) replaceWith(other *RocketPoolManager) {
+	m.refreshLock.Lock()
+	defer m.refreshLock.Unlock()
+	other.refreshLock.Lock()
+	defer other.refreshLock.Unlock()
+
+	m.rp.Store(other.rp.Load())
+	m.loadedContractVersion = other.loadedContractVersion
+}
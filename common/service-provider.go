@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sync"
 
 	csconfig "github.com/nodeset-org/hyperdrive-constellation/shared/config"
 	"github.com/nodeset-org/hyperdrive-daemon/module-utils/services"
@@ -23,6 +24,9 @@ type IConstellationConfigProvider interface {
 
 	// Gets the Constellation resources
 	GetResources() *csconfig.MergedResources
+
+	// Gets the reloader for the network settings files
+	GetSettingsReloader() *SettingsReloader
 }
 
 // Provides the Constellation manager
@@ -37,6 +41,42 @@ type IConstellationWalletProvider interface {
 	GetWallet() *Wallet
 }
 
+// Provides the monitors that track the node's behavior on-chain
+type IConstellationMonitorProvider interface {
+	// Gets the fee recipient monitor
+	GetFeeRecipientMonitor() *FeeRecipientMonitor
+
+	// Gets the tracker for transactions submitted by the daemon
+	GetTransactionTracker() *TransactionTracker
+
+	// Gets the tracker for the task loop's readiness
+	GetHealthTracker() *HealthTracker
+
+	// Gets the log of state-changing API calls
+	GetAuditLog() *AuditLog
+
+	// Gets the index of Constellation contract events
+	GetEventIndexer() *EventIndexer
+
+	// Gets the tracker for the minipool processing keeper's spending
+	GetKeeperTracker() *KeeperTracker
+}
+
+// Provides access to the parts of the Beacon API that the Beacon client doesn't expose
+type IConstellationBeaconApiProvider interface {
+	// Gets the Beacon API client
+	GetBeaconApiClient() *BeaconApiClient
+}
+
+// Provides the ways the daemon reports lifecycle events
+type IConstellationNotificationProvider interface {
+	// Gets the notifier
+	GetNotifier() *Notifier
+
+	// Gets the broker for the event stream
+	GetEventBroker() *EventBroker
+}
+
 // Provides the services used for Rocket Pool and Smart Node interaction
 type ISmartNodeServiceProvider interface {
 	// Gets the Rocket Pool manager
@@ -49,7 +89,8 @@ type ISmartNodeServiceProvider interface {
 // Provides the requirements for the Constellation daemon
 type IConstellationRequirementsProvider interface {
 	// Requires either the node address or the wallet address to be registered with Constellation.
-	// If useWalletAddress is true, the wallet address will be used to check registration. If false, the node address will be used.
+	// If useWalletAddress is true, only the node address needs to be set and it will be used to check registration.
+	// If false, the wallet must be ready and its address will be used.
 	// Errors include:
 	// - services.ErrNodeAddressNotSet
 	// - services.ErrNeedPassword
@@ -63,8 +104,11 @@ type IConstellationRequirementsProvider interface {
 
 // Provides all services for the Constellation daemon
 type IConstellationServiceProvider interface {
+	IConstellationBeaconApiProvider
 	IConstellationConfigProvider
 	IConstellationManagerProvider
+	IConstellationMonitorProvider
+	IConstellationNotificationProvider
 	IConstellationRequirementsProvider
 	IConstellationWalletProvider
 	ISmartNodeServiceProvider
@@ -84,10 +128,21 @@ type constellationServiceProvider struct {
 	rpMgr     *RocketPoolManager
 	snSp      *smartNodeServiceProvider
 	wallet    *Wallet
+	frMonitor *FeeRecipientMonitor
+	beaconApi *BeaconApiClient
+	txTracker *TransactionTracker
+	notifier  *Notifier
+	health    *HealthTracker
+	events    *EventBroker
+	auditLog  *AuditLog
+	indexer   *EventIndexer
+	keeper    *KeeperTracker
+	reloader  *SettingsReloader
+	resLock   *sync.RWMutex
 }
 
-// Create a new service provider with Constellation daemon-specific features
-func NewConstellationServiceProvider(sp services.IModuleServiceProvider, settingsList []*csconfig.ConstellationSettings) (IConstellationServiceProvider, error) {
+// Create a new service provider with Constellation daemon-specific features, using the network settings loaded from the given folder
+func NewConstellationServiceProvider(sp services.IModuleServiceProvider, settingsFolder string, settingsList []*csconfig.ConstellationSettings) (IConstellationServiceProvider, error) {
 	// Create the resources
 	csCfg, ok := sp.GetModuleConfig().(*csconfig.ConstellationConfig)
 	if !ok {
@@ -96,30 +151,67 @@ func NewConstellationServiceProvider(sp services.IModuleServiceProvider, setting
 	hdCfg := sp.GetHyperdriveConfig()
 	hdRes := sp.GetHyperdriveResources()
 
+	// Let the user know about anything that was changed when their config was upgraded
+	for _, change := range csCfg.GetMigrationChanges() {
+		sp.GetTasksLogger().Logger.Warn(change)
+	}
+
 	// Get the resources from the selected network
-	var csResources *csconfig.MergedResources
+	var settings *csconfig.ConstellationSettings
 	for _, network := range settingsList {
-		if network.Key != hdCfg.Network.Value {
-			continue
+		if network.Key == hdCfg.Network.Value {
+			settings = network
+			break
 		}
-		csResources = &csconfig.MergedResources{
-			MergedResources:        hdRes,
-			ConstellationResources: network.ConstellationResources,
-			SmartNodeResources:     network.SmartNodeResources,
-		}
-		break
 	}
-	if csResources == nil {
+	if settings == nil {
 		return nil, fmt.Errorf("no constellation resources found for selected network [%s]", hdCfg.Network.Value)
 	}
 
-	return NewConstellationServiceProviderFromCustomServices(sp, csCfg, csResources)
+	// Custom networks can leave out anything that can be found on-chain. Use what was discovered the last time the daemon ran if
+	// there is anything; otherwise start with placeholders and discover them once the EC is synced, so startup doesn't wait on it.
+	discoveryPending := false
+	if settings.NeedsDiscovery() {
+		logger := sp.GetTasksLogger().Logger
+		discoveredSettings, discovered, err := settings.WithDiscoveredResources(sp.GetModuleDir())
+		if err != nil {
+			return nil, fmt.Errorf("error loading discovered network resources: %w", err)
+		}
+		if discovered {
+			logger.Info("Using the network resources discovered previously; they'll be checked against the chain once the Execution Client is synced")
+			settings = discoveredSettings
+		} else {
+			logger.Info("Network settings are missing some resources, they'll be discovered from the Constellation Directory once the Execution Client is synced")
+			settings = settings.WithPlaceholderResources()
+			discoveryPending = true
+		}
+	}
+	csResources := csconfig.NewMergedResources(hdRes, settings)
+
+	constellationSp, err := newConstellationServiceProviderImpl(sp, csCfg, csResources)
+	if err != nil {
+		return nil, err
+	}
+	constellationSp.reloader = NewSettingsReloader(constellationSp, settingsFolder, sp.GetTasksLogger().Logger)
+	constellationSp.reloader.discoveryPending.Store(discoveryPending)
+	return constellationSp, nil
 }
 
 // Create a new service provider with Constellation daemon-specific features, using custom services instead of loading them from the module service provider.
-func NewConstellationServiceProviderFromCustomServices(sp services.IModuleServiceProvider, cfg *csconfig.ConstellationConfig, csresources *csconfig.MergedResources) (IConstellationServiceProvider, error) {
+// The settings folder is where reloads get the network settings from; leave it empty if they can't be reloaded.
+func NewConstellationServiceProviderFromCustomServices(sp services.IModuleServiceProvider, cfg *csconfig.ConstellationConfig, csresources *csconfig.MergedResources, settingsFolder string) (IConstellationServiceProvider, error) {
+	constellationSp, err := newConstellationServiceProviderImpl(sp, cfg, csresources)
+	if err != nil {
+		return nil, err
+	}
+	constellationSp.reloader = NewSettingsReloader(constellationSp, settingsFolder, sp.GetTasksLogger().Logger)
+	return constellationSp, nil
+}
+
+// Create the service provider implementation
+func newConstellationServiceProviderImpl(sp services.IModuleServiceProvider, cfg *csconfig.ConstellationConfig, csresources *csconfig.MergedResources) (*constellationServiceProvider, error) {
 	// Create the Constellation manager
-	csMgr, err := NewConstellationManager(csresources.ConstellationResources, sp.GetEthClient(), sp.GetQueryManager(), sp.GetTransactionManager())
+	csMgr, err := NewConstellationManager(csresources.ConstellationResources, sp.GetEthClient(), sp.GetQueryManager(), sp.GetTransactionManager(), sp.GetTasksLogger().Logger)
 	if err != nil {
 		return nil, fmt.Errorf("error creating Constellation manager: %w", err)
 	}
@@ -136,6 +228,36 @@ func NewConstellationServiceProviderFromCustomServices(sp services.IModuleServic
 		return nil, fmt.Errorf("error creating wallet: %w", err)
 	}
 
+	// Create the fee recipient monitor
+	frMonitor, err := NewFeeRecipientMonitor(sp)
+	if err != nil {
+		return nil, fmt.Errorf("error creating fee recipient monitor: %w", err)
+	}
+
+	// Create the transaction tracker
+	txTracker, err := NewTransactionTracker(sp)
+	if err != nil {
+		return nil, fmt.Errorf("error creating transaction tracker: %w", err)
+	}
+
+	// Create the audit log
+	auditLog, err := NewAuditLog(sp)
+	if err != nil {
+		return nil, fmt.Errorf("error creating audit log: %w", err)
+	}
+
+	// Create the event indexer
+	indexer, err := NewEventIndexer(sp, csMgr, rpMgr, csresources.DeploymentBlock, sp.GetTasksLogger().Logger)
+	if err != nil {
+		return nil, fmt.Errorf("error creating event indexer: %w", err)
+	}
+
+	// Create the keeper tracker
+	keeper, err := NewKeeperTracker(sp)
+	if err != nil {
+		return nil, fmt.Errorf("error creating keeper tracker: %w", err)
+	}
+
 	// Make the provider
 	constellationSp := &constellationServiceProvider{
 		IModuleServiceProvider: sp,
@@ -144,6 +266,16 @@ func NewConstellationServiceProviderFromCustomServices(sp services.IModuleServic
 		csMgr:                  csMgr,
 		rpMgr:                  rpMgr,
 		wallet:                 wallet,
+		frMonitor:              frMonitor,
+		beaconApi:              NewBeaconApiClient(sp.GetHyperdriveConfig()),
+		txTracker:              txTracker,
+		notifier:               NewNotifier(cfg.Notifications),
+		health:                 NewHealthTracker(),
+		events:                 NewEventBroker(),
+		auditLog:               auditLog,
+		indexer:                indexer,
+		keeper:                 keeper,
+		resLock:                &sync.RWMutex{},
 	}
 
 	// Create the Smart Node service provider
@@ -164,9 +296,15 @@ func (s *constellationServiceProvider) GetConfig() *csconfig.ConstellationConfig
 }
 
 func (s *constellationServiceProvider) GetResources() *csconfig.MergedResources {
+	s.resLock.RLock()
+	defer s.resLock.RUnlock()
 	return s.resources
 }
 
+func (s *constellationServiceProvider) GetSettingsReloader() *SettingsReloader {
+	return s.reloader
+}
+
 func (s *constellationServiceProvider) GetConstellationManager() *ConstellationManager {
 	return s.csMgr
 }
@@ -176,9 +314,55 @@ func (s *constellationServiceProvider) GetRocketPoolManager() *RocketPoolManager
 }
 
 func (s *constellationServiceProvider) GetSmartNodeServiceProvider() snservices.ISmartNodeServiceProvider {
+	s.resLock.RLock()
+	defer s.resLock.RUnlock()
 	return s.snSp
 }
 
+// Switch to new resources and the Smart Node service provider built from them
+func (s *constellationServiceProvider) setResources(resources *csconfig.MergedResources, snSp *smartNodeServiceProvider) {
+	s.resLock.Lock()
+	defer s.resLock.Unlock()
+	s.resources = resources
+	s.snSp = snSp
+}
+
 func (s *constellationServiceProvider) GetWallet() *Wallet {
 	return s.wallet
 }
+
+func (s *constellationServiceProvider) GetFeeRecipientMonitor() *FeeRecipientMonitor {
+	return s.frMonitor
+}
+
+func (s *constellationServiceProvider) GetBeaconApiClient() *BeaconApiClient {
+	return s.beaconApi
+}
+
+func (s *constellationServiceProvider) GetTransactionTracker() *TransactionTracker {
+	return s.txTracker
+}
+
+func (s *constellationServiceProvider) GetNotifier() *Notifier {
+	return s.notifier
+}
+
+func (s *constellationServiceProvider) GetHealthTracker() *HealthTracker {
+	return s.health
+}
+
+func (s *constellationServiceProvider) GetEventBroker() *EventBroker {
+	return s.events
+}
+
+func (s *constellationServiceProvider) GetAuditLog() *AuditLog {
+	return s.auditLog
+}
+
+func (s *constellationServiceProvider) GetEventIndexer() *EventIndexer {
+	return s.indexer
+}
+
+func (s *constellationServiceProvider) GetKeeperTracker() *KeeperTracker {
+	return s.keeper
+}
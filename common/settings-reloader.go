@@ -0,0 +1,235 @@
+package cscommon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	csconfig "github.com/nodeset-org/hyperdrive-constellation/shared/config"
+	"github.com/rocket-pool/node-manager-core/log"
+	snconfig "github.com/rocket-pool/smartnode/v2/shared/config"
+)
+
+const (
+	// How long to wait after the last change to the settings folder before reloading, so editors that write a file
+	// in several steps only trigger one reload
+	settingsReloadDebounce time.Duration = 2 * time.Second
+)
+
+var (
+	// The daemon wasn't started from a settings folder, so there's nothing to reload from
+	ErrNoSettingsFolder error = errors.New("network settings weren't loaded from a folder, so they can't be reloaded")
+)
+
+// Reloads the network settings files and rebuilds the Constellation and Rocket Pool managers when they change.
+// Reloads can be triggered through the API or by a watcher on the settings folder.
+type SettingsReloader struct {
+	sp     *constellationServiceProvider
+	folder string
+	logger *slog.Logger
+	lock   *sync.Mutex
+
+	// True if the daemon started with placeholders for resources that still need to be discovered
+	discoveryPending atomic.Bool
+}
+
+// Create a new settings reloader for the given folder; leave the folder empty if the settings didn't come from one
+func NewSettingsReloader(sp *constellationServiceProvider, folder string, logger *slog.Logger) *SettingsReloader {
+	return &SettingsReloader{
+		sp:     sp,
+		folder: folder,
+		logger: logger,
+		lock:   &sync.Mutex{},
+	}
+}
+
+// Get the folder the settings are reloaded from; this is empty if they didn't come from one
+func (r *SettingsReloader) GetFolder() string {
+	return r.folder
+}
+
+// Discover the network resources that the settings the daemon started with left out, if that hasn't happened yet, by
+// reloading the settings. Does nothing once they've been discovered.
+// Requires a synced EC to function properly; you're responsible for ensuring it's synced before calling this.
+func (r *SettingsReloader) DiscoverPending() error {
+	if !r.discoveryPending.Load() {
+		return nil
+	}
+	_, err := r.Reload()
+	if err != nil {
+		return fmt.Errorf("error discovering network resources: %w", err)
+	}
+	return nil
+}
+
+// Load the settings files again and switch to them if the selected network's settings changed.
+// The new managers are built and their contracts loaded before anything is replaced, so a bad file or an unreachable
+// contract leaves the daemon running with its current settings.
+// Requires a synced EC to function properly; you're responsible for ensuring it's synced before calling this.
+func (r *SettingsReloader) Reload() (csapi.ServiceReloadSettingsData, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	data := csapi.ServiceReloadSettingsData{}
+	if r.folder == "" {
+		return data, ErrNoSettingsFolder
+	}
+	settingsList, err := csconfig.LoadSettingsFiles(r.folder)
+	if err != nil {
+		return data, fmt.Errorf("error loading network settings: %w", err)
+	}
+
+	// Get the settings for the selected network
+	sp := r.sp
+	network := sp.GetHyperdriveConfig().Network.Value
+	for _, settings := range settingsList {
+		if settings.Key == network {
+			data.Settings = settings
+			break
+		}
+	}
+	if data.Settings == nil {
+		return data, fmt.Errorf("no constellation resources found for selected network [%s]", network)
+	}
+
+	// Fill in anything left out and make sure the rest matches the chain, so they can be compared to the current ones.
+	// Anything that had to be discovered is saved so templating and the next startup can use it.
+	needsDiscovery := data.Settings.NeedsDiscovery()
+	discovered, err := DiscoverResources(data.Settings, sp.GetEthClient(), sp.GetQueryManager())
+	if err != nil {
+		return data, fmt.Errorf("error checking network resources: %w", err)
+	}
+	if needsDiscovery {
+		err = discovered.Save(sp.GetModuleDir())
+		if err != nil {
+			return data, err
+		}
+	}
+	current := sp.GetResources()
+	if reflect.DeepEqual(current.ConstellationResources, data.Settings.ConstellationResources) &&
+		reflect.DeepEqual(current.SmartNodeResources, data.Settings.SmartNodeResources) {
+		sp.csCfg.SetNetworkSettings(settingsList)
+		r.discoveryPending.Store(false)
+		return data, nil
+	}
+	newResources := csconfig.NewMergedResources(sp.GetHyperdriveResources(), data.Settings)
+
+	// Build the new managers
+	csMgr, err := NewConstellationManager(newResources.ConstellationResources, sp.GetEthClient(), sp.GetQueryManager(), sp.GetTransactionManager(), r.logger)
+	if err != nil {
+		return data, fmt.Errorf("error creating Constellation manager: %w", err)
+	}
+	err = csMgr.LoadContracts()
+	if err != nil {
+		return data, fmt.Errorf("error loading Constellation contracts: %w", err)
+	}
+	rpMgr, err := NewRocketPoolManager(newResources, sp.GetEthClient(), sp.GetQueryManager(), sp.GetTransactionManager())
+	if err != nil {
+		return data, fmt.Errorf("error creating Rocket Pool manager: %w", err)
+	}
+	err = rpMgr.RefreshRocketPoolContracts()
+	if err != nil {
+		return data, fmt.Errorf("error refreshing Rocket Pool contracts: %w", err)
+	}
+	snSp, err := newSmartNodeServiceProvider(sp, sp.GetHyperdriveConfig(), sp.csCfg, &snconfig.MergedResources{
+		NetworkResources:   newResources.NetworkResources,
+		SmartNodeResources: newResources.SmartNodeResources,
+	})
+	if err != nil {
+		return data, fmt.Errorf("error creating Smart Node service provider: %w", err)
+	}
+
+	// Switch to them; the existing managers are updated in place since the tasks and routes hold onto them, and each one
+	// publishes its new bindings atomically
+	sp.csMgr.replaceWith(csMgr)
+	sp.rpMgr.replaceWith(rpMgr)
+	sp.setResources(newResources, snSp)
+	sp.csCfg.SetNetworkSettings(settingsList)
+	r.discoveryPending.Store(false)
+	data.Changed = true
+	err = sp.GetEventIndexer().Rebuild(newResources.DeploymentBlock)
+	if err != nil {
+		// The new settings are already in use, so this only affects the history routes until the index is rebuilt
+		r.logger.Warn("Error clearing the event index for the new contracts", log.Err(err))
+	}
+	r.logger.Info("Reloaded network settings",
+		slog.String("network", string(network)),
+		slog.String("deployment", newResources.DeploymentName),
+		slog.String("directory", newResources.Directory.Hex()),
+	)
+	return data, nil
+}
+
+// Watch the settings folder and reload the settings whenever a file in it changes, until the context is cancelled.
+// Does nothing if the settings didn't come from a folder.
+func (r *SettingsReloader) Watch(ctx context.Context, wg *sync.WaitGroup) error {
+	if r.folder == "" {
+		return nil
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("error creating settings folder watcher: %w", err)
+	}
+	err = watcher.Add(r.folder)
+	if err != nil {
+		watcher.Close()
+		return fmt.Errorf("error watching settings folder [%s]: %w", r.folder, err)
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer watcher.Close()
+
+		// Start with a stopped timer that fires once the folder has been quiet for the debounce period
+		timer := time.NewTimer(settingsReloadDebounce)
+		if !timer.Stop() {
+			<-timer.C
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				ext := filepath.Ext(event.Name)
+				if ext != ".yaml" && ext != ".yml" {
+					continue
+				}
+				timer.Reset(settingsReloadDebounce)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				r.logger.Warn("Error watching network settings folder", log.Err(err))
+			case <-timer.C:
+				r.reloadFromWatcher(ctx)
+			}
+		}
+	}()
+	return nil
+}
+
+// Reload the settings after the watcher saw a change, logging the result since there's no caller to return it to
+func (r *SettingsReloader) reloadFromWatcher(ctx context.Context) {
+	err := r.sp.RequireEthClientSynced(ctx)
+	if err != nil {
+		r.logger.Warn("Network settings changed but they can't be reloaded until the Execution Client is synced; use the reload-settings route to retry", log.Err(err))
+		return
+	}
+	_, err = r.Reload()
+	if err != nil {
+		r.logger.Error("Network settings changed but couldn't be reloaded, so the current settings are still in use", log.Err(err))
+	}
+}
@@ -45,7 +45,7 @@ func newSmartNodeServiceProvider(csSp *constellationServiceProvider, hdCfg *hdco
 
 func (p *smartNodeServiceProvider) GetRocketPool() *rocketpool.RocketPool {
 	rpMgr := p.csSp.GetRocketPoolManager()
-	return rpMgr.RocketPool
+	return rpMgr.RocketPool()
 }
 
 func (p *smartNodeServiceProvider) RefreshRocketPoolContracts() error {
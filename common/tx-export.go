@@ -0,0 +1,93 @@
+package cscommon
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/rocket-pool/node-manager-core/eth"
+)
+
+const (
+	// Multiplier for the latest base fee when suggesting a max fee, so the TX stays valid through several full blocks
+	suggestedBaseFeeMultiplier int64 = 2
+)
+
+// Get the transact opts to build transactions for export with. Exported transactions are signed elsewhere, so they're
+// built for the node address and don't need the daemon's wallet to be ready; these opts can simulate transactions but
+// can't sign them.
+func GetExportTransactOpts(nodeAddress common.Address) *bind.TransactOpts {
+	return &bind.TransactOpts{
+		From:   nodeAddress,
+		NoSend: true,
+	}
+}
+
+// Converts the provided transaction infos into unsigned EIP-1559 transactions for offline signing.
+// Nonces are assigned sequentially starting from the sender's pending nonce, and fees are suggested from the latest block.
+func CreateUnsignedTransactions(ctx context.Context, ec eth.IExecutionClient, from common.Address, txInfos []*eth.TransactionInfo) ([]*csapi.UnsignedTransaction, error) {
+	// Get the chain details
+	chainID, err := ec.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting chain ID: %w", err)
+	}
+	nonce, err := ec.PendingNonceAt(ctx, from)
+	if err != nil {
+		return nil, fmt.Errorf("error getting pending nonce for %s: %w", from.Hex(), err)
+	}
+
+	// Get the fee suggestions
+	maxFee, maxPriorityFee, err := SuggestTransactionFees(ctx, ec)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make the TXs
+	unsignedTxs := make([]*csapi.UnsignedTransaction, 0, len(txInfos))
+	for _, txInfo := range txInfos {
+		if txInfo == nil {
+			continue
+		}
+		value := txInfo.Value
+		if value == nil {
+			value = big.NewInt(0)
+		}
+		unsignedTxs = append(unsignedTxs, &csapi.UnsignedTransaction{
+			Type:                 types.DynamicFeeTxType,
+			ChainID:              chainID,
+			From:                 from,
+			To:                   txInfo.To,
+			Nonce:                nonce,
+			Value:                value,
+			Data:                 txInfo.Data,
+			GasLimit:             txInfo.SimulationResult.SafeGasLimit,
+			MaxFeePerGas:         maxFee,
+			MaxPriorityFeePerGas: maxPriorityFee,
+			SimulationError:      txInfo.SimulationResult.SimulationError,
+		})
+		nonce++
+	}
+	return unsignedTxs, nil
+}
+
+// Suggests a max fee and max priority fee for a new transaction based on the latest block
+func SuggestTransactionFees(ctx context.Context, ec eth.IExecutionClient) (*big.Int, *big.Int, error) {
+	header, err := ec.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting latest block header: %w", err)
+	}
+	maxPriorityFee, err := ec.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting suggested priority fee: %w", err)
+	}
+	maxFee := big.NewInt(0)
+	if header.BaseFee != nil {
+		maxFee.Mul(header.BaseFee, big.NewInt(suggestedBaseFeeMultiplier))
+	}
+	maxFee.Add(maxFee, maxPriorityFee)
+	return maxFee, maxPriorityFee, nil
+}
@@ -0,0 +1,327 @@
+package cscommon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/services"
+)
+
+const (
+	transactionTrackerFilename string = "pending_transactions"
+
+	// The percentage that both fees must be raised by for a replacement TX to be accepted by the mempool.
+	// Geth requires at least 10%, so this leaves some headroom for rounding.
+	replacementFeeBumpPercent int64 = 15
+)
+
+var (
+	// The tracker doesn't have a pending transaction with the requested nonce
+	ErrTransactionNotTracked error = errors.New("no pending transaction with that nonce is being tracked")
+)
+
+// A tracked transaction that is no longer pending
+type CompletedTransaction struct {
+	// The transaction as it was last tracked
+	Transaction csapi.TrackedTransaction
+
+	// The receipt of whichever version of the transaction was included, or nil if its nonce was used by an untracked transaction
+	Receipt *types.Receipt
+}
+
+// Persistent state of the transaction tracker
+type transactionTrackerData struct {
+	Transactions []csapi.TrackedTransaction `json:"transactions"`
+}
+
+// Tracks transactions submitted by the daemon until they're included in a block, so they can be replaced if they get stuck.
+// The state is shared between the task loop, which submits and monitors transactions, and the API server, which reports on them.
+type TransactionTracker struct {
+	sp   services.IModuleServiceProvider
+	data transactionTrackerData
+	lock *sync.Mutex
+}
+
+// Create a new transaction tracker, loading its state from disk if present
+func NewTransactionTracker(sp services.IModuleServiceProvider) (*TransactionTracker, error) {
+	t := &TransactionTracker{
+		sp:   sp,
+		lock: &sync.Mutex{},
+	}
+
+	// Check if the data exists
+	dataPath := filepath.Join(sp.GetModuleDir(), transactionTrackerFilename)
+	_, err := os.Stat(dataPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return t, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error checking status of pending transactions file [%s]: %w", dataPath, err)
+	}
+
+	// Read it
+	bytes, err := os.ReadFile(dataPath)
+	if err != nil {
+		return nil, fmt.Errorf("error loading pending transactions: %w", err)
+	}
+	err = json.Unmarshal(bytes, &t.data)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing pending transactions: %w", err)
+	}
+	return t, nil
+}
+
+// Get a copy of the pending transactions
+func (t *TransactionTracker) GetPendingTransactions() []csapi.TrackedTransaction {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	txs := make([]csapi.TrackedTransaction, len(t.data.Transactions))
+	copy(txs, t.data.Transactions)
+	return txs
+}
+
+// Get the pending transaction for the given minipool; the bool will be false if there isn't one
+func (t *TransactionTracker) GetPendingTransactionForMinipool(minipool common.Address) (csapi.TrackedTransaction, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for _, tx := range t.data.Transactions {
+		if tx.Minipool == minipool {
+			return tx, true
+		}
+	}
+	return csapi.TrackedTransaction{}, false
+}
+
+// Start tracking a transaction that was just submitted.
+// The minipool can be left empty if the transaction isn't for a specific minipool, and the deadline can be zero if there isn't one.
+func (t *TransactionTracker) TrackTransaction(tx *types.Transaction, from common.Address, description string, minipool common.Address, deadline time.Time) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := time.Now()
+	t.data.Transactions = append(t.data.Transactions, csapi.TrackedTransaction{
+		Hash:                 tx.Hash(),
+		PreviousHashes:       []common.Hash{},
+		Description:          description,
+		Minipool:             minipool,
+		From:                 from,
+		To:                   *tx.To(),
+		Nonce:                tx.Nonce(),
+		Value:                tx.Value(),
+		Data:                 tx.Data(),
+		GasLimit:             tx.Gas(),
+		MaxFeePerGas:         tx.GasFeeCap(),
+		MaxPriorityFeePerGas: tx.GasTipCap(),
+		SubmissionTime:       now,
+		LastReplacementTime:  now,
+		HasDeadline:          !deadline.IsZero(),
+		Deadline:             deadline,
+	})
+	return t.saveData()
+}
+
+// Check the pending transactions against the chain and stop tracking any whose nonce has been used.
+// Returns the transactions that are no longer pending.
+func (t *TransactionTracker) Refresh(ctx context.Context) ([]CompletedTransaction, error) {
+	ec := t.sp.GetEthClient()
+	pendingTxs := t.GetPendingTransactions()
+
+	// Get the latest nonce of each sender
+	latestNonces := map[common.Address]uint64{}
+	for _, tx := range pendingTxs {
+		if _, exists := latestNonces[tx.From]; exists {
+			continue
+		}
+		nonce, err := ec.NonceAt(ctx, tx.From, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting latest nonce for %s: %w", tx.From.Hex(), err)
+		}
+		latestNonces[tx.From] = nonce
+	}
+
+	// Find the receipts of anything that's been included
+	completed := []CompletedTransaction{}
+	for _, tx := range pendingTxs {
+		if tx.Nonce >= latestNonces[tx.From] {
+			continue
+		}
+		completedTx := CompletedTransaction{
+			Transaction: tx,
+		}
+		for _, hash := range append([]common.Hash{tx.Hash}, tx.PreviousHashes...) {
+			receipt, err := ec.TransactionReceipt(ctx, hash)
+			if errors.Is(err, ethereum.NotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("error getting receipt for transaction %s: %w", hash.Hex(), err)
+			}
+			completedTx.Receipt = receipt
+			break
+		}
+		completed = append(completed, completedTx)
+	}
+	if len(completed) == 0 {
+		return completed, nil
+	}
+
+	// Remove them
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	remaining := []csapi.TrackedTransaction{}
+	for _, tx := range t.data.Transactions {
+		isComplete := false
+		for _, completedTx := range completed {
+			if tx.From == completedTx.Transaction.From && tx.Nonce == completedTx.Transaction.Nonce {
+				isComplete = true
+				break
+			}
+		}
+		if !isComplete {
+			remaining = append(remaining, tx)
+		}
+	}
+	t.data.Transactions = remaining
+	return completed, t.saveData()
+}
+
+// Replace a pending transaction with a copy that pays higher fees, or with a 0-value transfer to the sender if cancelling it.
+// The new fees will be whichever is higher of the minimum replacement bump and the current suggested fees.
+func (t *TransactionTracker) ReplaceTransaction(ctx context.Context, opts *bind.TransactOpts, nonce uint64, cancel bool) (csapi.TrackedTransaction, error) {
+	ec := t.sp.GetEthClient()
+
+	// Get a copy of the TX so the lock isn't held while talking to the EC
+	tracked, exists := t.getPendingTransaction(opts.From, nonce)
+	if !exists {
+		return csapi.TrackedTransaction{}, ErrTransactionNotTracked
+	}
+
+	// Get the new fees
+	suggestedMaxFee, suggestedPriorityFee, err := SuggestTransactionFees(ctx, ec)
+	if err != nil {
+		return csapi.TrackedTransaction{}, err
+	}
+	maxPriorityFee := getMaxBig(bumpReplacementFee(tracked.MaxPriorityFeePerGas), suggestedPriorityFee)
+	maxFee := getMaxBig(bumpReplacementFee(tracked.MaxFeePerGas), suggestedMaxFee)
+	if maxPriorityFee.Cmp(maxFee) > 0 {
+		maxFee = new(big.Int).Set(maxPriorityFee)
+	}
+
+	// Build the replacement
+	chainID, err := ec.ChainID(ctx)
+	if err != nil {
+		return csapi.TrackedTransaction{}, fmt.Errorf("error getting chain ID: %w", err)
+	}
+	to := tracked.To
+	value := tracked.Value
+	data := []byte(tracked.Data)
+	gasLimit := tracked.GasLimit
+	if cancel {
+		to = tracked.From
+		value = big.NewInt(0)
+		data = nil
+		gasLimit = params.TxGas
+	}
+	tx, err := opts.Signer(opts.From, types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		GasTipCap: maxPriorityFee,
+		GasFeeCap: maxFee,
+		Gas:       gasLimit,
+		To:        &to,
+		Value:     value,
+		Data:      data,
+	}))
+	if err != nil {
+		return csapi.TrackedTransaction{}, fmt.Errorf("error signing replacement transaction: %w", err)
+	}
+
+	// Submit it
+	err = ec.SendTransaction(ctx, tx)
+	if err != nil {
+		return csapi.TrackedTransaction{}, fmt.Errorf("error submitting replacement transaction: %w", err)
+	}
+
+	// Update the tracked TX, which may have been replaced or completed while the lock was released
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	var stored *csapi.TrackedTransaction
+	for i := range t.data.Transactions {
+		pendingTx := &t.data.Transactions[i]
+		if pendingTx.From == opts.From && pendingTx.Nonce == nonce {
+			stored = pendingTx
+			break
+		}
+	}
+	if stored == nil {
+		return csapi.TrackedTransaction{}, fmt.Errorf("replacement transaction %s was submitted but its nonce is no longer pending: %w", tx.Hash().Hex(), ErrTransactionNotTracked)
+	}
+	stored.PreviousHashes = append(stored.PreviousHashes, stored.Hash)
+	stored.Hash = tx.Hash()
+	stored.To = to
+	stored.Value = value
+	stored.Data = data
+	stored.GasLimit = gasLimit
+	stored.MaxFeePerGas = maxFee
+	stored.MaxPriorityFeePerGas = maxPriorityFee
+	stored.LastReplacementTime = time.Now()
+	stored.ReplacementCount++
+	stored.IsCancellation = stored.IsCancellation || cancel
+	return *stored, t.saveData()
+}
+
+// Get a copy of the pending transaction from the given sender with the given nonce; the bool will be false if there isn't one
+func (t *TransactionTracker) getPendingTransaction(from common.Address, nonce uint64) (csapi.TrackedTransaction, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for _, tx := range t.data.Transactions {
+		if tx.From == from && tx.Nonce == nonce {
+			return tx, true
+		}
+	}
+	return csapi.TrackedTransaction{}, false
+}
+
+// Write the tracker data to disk
+func (t *TransactionTracker) saveData() error {
+	// Serialize it
+	dataPath := filepath.Join(t.sp.GetModuleDir(), transactionTrackerFilename)
+	bytes, err := json.Marshal(t.data)
+	if err != nil {
+		return fmt.Errorf("error serializing pending transactions: %w", err)
+	}
+
+	// Save it
+	err = os.WriteFile(dataPath, bytes, fileMode)
+	if err != nil {
+		return fmt.Errorf("error saving pending transactions: %w", err)
+	}
+	return nil
+}
+
+// Get the minimum fee a replacement transaction must pay
+func bumpReplacementFee(fee *big.Int) *big.Int {
+	bumped := new(big.Int).Mul(fee, big.NewInt(100+replacementFeeBumpPercent))
+	return bumped.Div(bumped, big.NewInt(100))
+}
+
+// Get the larger of two values
+func getMaxBig(a *big.Int, b *big.Int) *big.Int {
+	if a.Cmp(b) >= 0 {
+		return new(big.Int).Set(a)
+	}
+	return new(big.Int).Set(b)
+}
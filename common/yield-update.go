@@ -0,0 +1,105 @@
+package cscommon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// Timeout for requests to the yield update endpoint
+	yieldUpdateTimeout time.Duration = 10 * time.Second
+
+	// The most a yield update response can be, to guard against misbehaving endpoints
+	maxYieldUpdateSize int64 = 1 << 16
+)
+
+// A signed xrETH yield update for the PoA Constellation Oracle, as published by the oracle's signer
+type YieldUpdate struct {
+	// The total yield Constellation has accrued; this can be negative if there were penalties
+	NewTotalYieldAccrued *big.Int `json:"newTotalYieldAccrued"`
+
+	// The Operator Distributor's oracle error the update was calculated against
+	ExpectedOracleError *big.Int `json:"expectedOracleError"`
+
+	// The time the update was signed, in seconds since the Unix epoch
+	Timestamp int64 `json:"timestamp"`
+
+	// The signer's signature of the update
+	Signature hexutil.Bytes `json:"signature"`
+}
+
+// Get the time the update was signed
+func (u *YieldUpdate) GetTime() time.Time {
+	return time.Unix(u.Timestamp, 0)
+}
+
+// Fetch the latest signed yield update from an endpoint that serves it as JSON
+func FetchYieldUpdate(ctx context.Context, endpoint string) (*YieldUpdate, error) {
+	ctx, cancel := context.WithTimeout(ctx, yieldUpdateTimeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating yield update request: %w", err)
+	}
+	request.Header.Set("Accept", "application/json")
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("error requesting yield update: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return nil, fmt.Errorf("yield update endpoint responded with status %s", response.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(response.Body, maxYieldUpdateSize))
+	if err != nil {
+		return nil, fmt.Errorf("error reading yield update: %w", err)
+	}
+
+	var update YieldUpdate
+	err = json.Unmarshal(body, &update)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing yield update: %w", err)
+	}
+	if update.NewTotalYieldAccrued == nil || update.ExpectedOracleError == nil || len(update.Signature) == 0 {
+		return nil, fmt.Errorf("yield update is missing required fields")
+	}
+	return &update, nil
+}
+
+// Get the address that signed a yield update for the given oracle contract and chain.
+// The signature covers the packed update, oracle address, and chain ID, hashed as an Ethereum signed message.
+func GetYieldUpdateSigner(update *YieldUpdate, oracleAddress common.Address, chainID *big.Int) (common.Address, error) {
+	if len(update.Signature) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("signature is %d bytes but should be %d", len(update.Signature), crypto.SignatureLength)
+	}
+	message := crypto.Keccak256(
+		math.U256Bytes(new(big.Int).Set(update.NewTotalYieldAccrued)),
+		math.U256Bytes(new(big.Int).Set(update.ExpectedOracleError)),
+		math.U256Bytes(big.NewInt(update.Timestamp)),
+		oracleAddress[:],
+		math.U256Bytes(new(big.Int).Set(chainID)),
+	)
+
+	// Undo the Ethereum 'v' offset before recovering the key
+	signature := make([]byte, crypto.SignatureLength)
+	copy(signature, update.Signature)
+	if signature[crypto.RecoveryIDOffset] >= 27 {
+		signature[crypto.RecoveryIDOffset] -= 27
+	}
+	pubkey, err := crypto.SigToPub(accounts.TextHash(message), signature)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("error recovering yield update signer: %w", err)
+	}
+	return crypto.PubkeyToAddress(*pubkey), nil
+}
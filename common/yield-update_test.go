@@ -0,0 +1,67 @@
+package cscommon
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/stretchr/testify/require"
+)
+
+var testOracleAddress = common.HexToAddress("0x9A676e781A523b5d0C0e43731313A708CB607508")
+
+// Make sure the signer of an update packed the way the oracle expects is recovered, including negative yields
+func TestYieldUpdateSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signerAddress := crypto.PubkeyToAddress(key.PublicKey)
+	chainID := big.NewInt(31337)
+
+	for _, yield := range []*big.Int{eth.EthToWei(1.5), big.NewInt(0), eth.EthToWei(-0.25)} {
+		update := &YieldUpdate{
+			NewTotalYieldAccrued: yield,
+			ExpectedOracleError:  eth.EthToWei(0.01),
+			Timestamp:            time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Unix(),
+		}
+		update.Signature = signTestYieldUpdate(t, update, testOracleAddress, chainID, key)
+
+		signer, err := GetYieldUpdateSigner(update, testOracleAddress, chainID)
+		require.NoError(t, err)
+		require.Equal(t, signerAddress, signer, "yield %s", yield.String())
+
+		// Signatures for a different chain or oracle recover to someone else
+		signer, err = GetYieldUpdateSigner(update, testOracleAddress, big.NewInt(1))
+		require.NoError(t, err)
+		require.NotEqual(t, signerAddress, signer)
+		signer, err = GetYieldUpdateSigner(update, common.Address{}, chainID)
+		require.NoError(t, err)
+		require.NotEqual(t, signerAddress, signer)
+	}
+}
+
+// Sign a yield update the way the oracle's admin does: int256 yield, uint256 oracle error, uint256 timestamp,
+// oracle address, and uint256 chain ID, packed and hashed as an Ethereum signed message with the 'v' offset added
+func signTestYieldUpdate(t *testing.T, update *YieldUpdate, oracleAddress common.Address, chainID *big.Int, key *ecdsa.PrivateKey) []byte {
+	yieldBytes := common.LeftPadBytes(update.NewTotalYieldAccrued.Bytes(), 32)
+	if update.NewTotalYieldAccrued.Sign() < 0 {
+		// Two's complement
+		twos := new(big.Int).Add(new(big.Int).Lsh(big.NewInt(1), 256), update.NewTotalYieldAccrued)
+		yieldBytes = common.LeftPadBytes(twos.Bytes(), 32)
+	}
+	message := crypto.Keccak256(
+		yieldBytes,
+		common.LeftPadBytes(update.ExpectedOracleError.Bytes(), 32),
+		common.LeftPadBytes(big.NewInt(update.Timestamp).Bytes(), 32),
+		oracleAddress.Bytes(),
+		common.LeftPadBytes(chainID.Bytes(), 32),
+	)
+	signature, err := crypto.Sign(accounts.TextHash(message), key)
+	require.NoError(t, err)
+	signature[crypto.RecoveryIDOffset] += 27
+	return signature
+}
@@ -154,7 +154,7 @@ func main() {
 		if err != nil {
 			return fmt.Errorf("error creating service provider: %w", err)
 		}
-		constellationSp, err := cscommon.NewConstellationServiceProvider(sp, settingsList)
+		constellationSp, err := cscommon.NewConstellationServiceProvider(sp, settingsFolder, settingsList)
 		if err != nil {
 			return fmt.Errorf("error creating Constellation service provider: %w", err)
 		}
@@ -167,6 +167,12 @@ func main() {
 			return fmt.Errorf("error starting task loop: %w", err)
 		}
 
+		// Reload the network settings when they change
+		err = constellationSp.GetSettingsReloader().Watch(constellationSp.GetBaseContext(), stopWg)
+		if err != nil {
+			return fmt.Errorf("error starting network settings watcher: %w", err)
+		}
+
 		// Start the server after the task loop so it can log into NodeSet before this starts serving registration status checks
 		ip := c.String(ipFlag.Name)
 		port := c.Uint64(portFlag.Name)
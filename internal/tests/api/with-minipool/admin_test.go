@@ -0,0 +1,200 @@
+package with_minipool
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/nodeset-org/hyperdrive-constellation/common/contracts/constellation"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	hdtesting "github.com/nodeset-org/hyperdrive-daemon/testing"
+	batch "github.com/rocket-pool/batch-query"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/stretchr/testify/require"
+)
+
+// An admin TX route and what it should be built for
+type adminTxTest struct {
+	name     string
+	role     string
+	contract common.Address
+	send     func(export bool) (*types.ApiResponse[csapi.AdminTxData], error)
+}
+
+// Make sure a node without any admin roles is flagged instead of getting the TXs
+func TestAdminWithoutRoles(t *testing.T) {
+	// Take a snapshot, revert at the end
+	testMgr := harness.TestManager
+	snapshotName, err := testMgr.CreateCustomSnapshot(hdtesting.Service_EthClients | hdtesting.Service_Filesystem | hdtesting.Service_NodeSet)
+	if err != nil {
+		fail("Error creating custom snapshot: %v", err)
+	}
+	defer nodeset_cleanup(snapshotName)
+	cs := harness.MainNode.GetApiClient()
+
+	// The roles should match the chain, and the node shouldn't have any
+	onChainRoles := getAdminRoles(t, harness.MainNodeAddress)
+	rolesResponse, err := cs.Admin.Roles()
+	require.NoError(t, err)
+	require.Equal(t, harness.MainNodeAddress, rolesResponse.Data.Address)
+	require.Len(t, rolesResponse.Data.Roles, len(onChainRoles))
+	for _, role := range rolesResponse.Data.Roles {
+		require.False(t, role.HasRole, "node shouldn't have %s", role.Role)
+		require.False(t, onChainRoles[role.Role])
+	}
+
+	// Each TX should be flagged as missing its role
+	for _, test := range getAdminTxTests(t) {
+		response, err := test.send(false)
+		require.NoError(t, err, test.name)
+		data := response.Data
+		require.Equal(t, test.role, data.Role, test.name)
+		require.True(t, data.MissingRole, test.name)
+		require.Nil(t, data.TxInfo, test.name)
+		require.Nil(t, data.UnsignedTx, test.name)
+	}
+	t.Log("Every admin TX was flagged as missing its role")
+}
+
+// Make sure a role holder with no wallet loaded, such as a multisig, can build and export each admin TX it has the role for
+func TestAdminExportWithoutWallet(t *testing.T) {
+	// Take a snapshot, revert at the end
+	testMgr := harness.TestManager
+	snapshotName, err := testMgr.CreateCustomSnapshot(hdtesting.Service_EthClients | hdtesting.Service_Filesystem | hdtesting.Service_NodeSet)
+	if err != nil {
+		fail("Error creating custom snapshot: %v", err)
+	}
+	defer nodeset_cleanup(snapshotName)
+	cs := harness.MainNode.GetApiClient()
+	hd := harness.MainNode.GetHyperdriveNode().GetApiClient()
+
+	// Switch the node address to the deployer, which holds the roles from the deployment
+	admin := harness.DeployerOpts.From
+	_, err = hd.Wallet.Masquerade(admin)
+	require.NoError(t, err)
+	t.Logf("Set the node address to %s", admin.Hex())
+	onChainRoles := getAdminRoles(t, admin)
+
+	// The roles route only reads the chain, so it works without a wallet
+	rolesResponse, err := cs.Admin.Roles()
+	require.NoError(t, err)
+	require.Equal(t, admin, rolesResponse.Data.Address)
+	for _, role := range rolesResponse.Data.Roles {
+		require.Equal(t, onChainRoles[role.Role], role.HasRole, role.Role)
+	}
+
+	// Each TX should be exported for the node address if it has the role
+	tests := getAdminTxTests(t)
+	built := 0
+	for _, test := range tests {
+		// Building it for the daemon to sign needs the wallet
+		_, err := test.send(false)
+		require.Error(t, err, test.name)
+
+		response, err := test.send(true)
+		require.NoError(t, err, test.name)
+		data := response.Data
+		require.Equal(t, test.role, data.Role, test.name)
+		if !onChainRoles[test.role] {
+			require.True(t, data.MissingRole, test.name)
+			require.Nil(t, data.UnsignedTx, test.name)
+			continue
+		}
+		require.False(t, data.MissingRole, test.name)
+		require.Equal(t, test.contract, data.Contract, test.name)
+		require.NotNil(t, data.TxInfo, test.name)
+		require.Equal(t, test.contract, data.TxInfo.To, test.name)
+		require.NotNil(t, data.UnsignedTx, test.name)
+		require.Equal(t, admin, data.UnsignedTx.From, test.name)
+		require.Equal(t, test.contract, data.UnsignedTx.To, test.name)
+		require.Equal(t, []byte(data.TxInfo.Data), []byte(data.UnsignedTx.Data), test.name)
+		built++
+		t.Logf("Exported %s for %s", test.name, admin.Hex())
+	}
+
+	// The deployer sets the max validators in the other tests, so it has to have at least that role
+	require.NotZero(t, built)
+}
+
+// Get the admin TX routes, with arguments that don't change anything, and the role and contract each one should use
+func getAdminTxTests(t *testing.T) []adminTxTest {
+	sp := harness.MainNode.GetServiceProvider()
+	cs := harness.MainNode.GetApiClient()
+	contracts := sp.GetConstellationManager().Contracts()
+	addresses := sp.GetConstellationManager().GetContractAddresses()
+	recipient := harness.DeployerOpts.From
+	protocol := constellation.Protocol{
+		Whitelist:           addresses["Whitelist"],
+		WethVault:           addresses["WethVault"],
+		RplVault:            addresses["RplVault"],
+		OperatorDistributor: addresses["OperatorDistributor"],
+		MerkleClaimStreamer: addresses["MerkleClaimStreamer"],
+		Oracle:              addresses["PoAConstellationOracle"],
+		PriceFetcher:        addresses["PriceFetcher"],
+		SuperNode:           addresses["SuperNodeAccount"],
+		Weth:                harness.Bindings.Weth.Address,
+	}
+	return []adminTxTest{
+		{name: "set lock amount", role: "TIMELOCK_SHORT", contract: contracts.SuperNodeAccount.Address, send: func(export bool) (*types.ApiResponse[csapi.AdminTxData], error) {
+			return cs.Admin.SetLockAmount(big.NewInt(1e18), export)
+		}},
+		{name: "set max validators", role: "TIMELOCK_MED", contract: contracts.SuperNodeAccount.Address, send: func(export bool) (*types.ApiResponse[csapi.AdminTxData], error) {
+			return cs.Admin.SetMaxValidators(2, export)
+		}},
+		{name: "set max WETH/RPL ratio", role: "TIMELOCK_SHORT", contract: addresses["WethVault"], send: func(export bool) (*types.ApiResponse[csapi.AdminTxData], error) {
+			return cs.Admin.SetMaxWethRplRatio(big.NewInt(1e18), export)
+		}},
+		{name: "set treasury fee", role: "TIMELOCK_MED", contract: addresses["WethVault"], send: func(export bool) (*types.ApiResponse[csapi.AdminTxData], error) {
+			return cs.Admin.SetTreasuryFee(big.NewInt(1e17), export)
+		}},
+		{name: "set node operator fee", role: "TIMELOCK_MED", contract: addresses["WethVault"], send: func(export bool) (*types.ApiResponse[csapi.AdminTxData], error) {
+			return cs.Admin.SetNodeOperatorFee(big.NewInt(1e17), export)
+		}},
+		{name: "set min WETH/RPL ratio", role: "TIMELOCK_SHORT", contract: addresses["RplVault"], send: func(export bool) (*types.ApiResponse[csapi.AdminTxData], error) {
+			return cs.Admin.SetMinWethRplRatio(big.NewInt(1e17), export)
+		}},
+		{name: "set target stake ratio", role: "ADMIN_ROLE", contract: contracts.OperatorDistributor.Address, send: func(export bool) (*types.ApiResponse[csapi.AdminTxData], error) {
+			return cs.Admin.SetTargetStakeRatio(big.NewInt(1e18), export)
+		}},
+		{name: "claim ETH", role: "TREASURER_ROLE", contract: contracts.Treasury.Address, send: func(export bool) (*types.ApiResponse[csapi.AdminTxData], error) {
+			return cs.Admin.ClaimEth(recipient, export)
+		}},
+		{name: "claim token", role: "TREASURER_ROLE", contract: contracts.Treasury.Address, send: func(export bool) (*types.ApiResponse[csapi.AdminTxData], error) {
+			return cs.Admin.ClaimToken(harness.Bindings.Weth.Address, recipient, export)
+		}},
+		{name: "set all", role: "ADMIN_ROLE", contract: contracts.Directory.Address, send: func(export bool) (*types.ApiResponse[csapi.AdminTxData], error) {
+			return cs.Admin.SetAll(protocol, export)
+		}},
+	}
+}
+
+// Get which of the admin roles an account holds, straight from the contracts
+func getAdminRoles(t *testing.T, account common.Address) map[string]bool {
+	sp := harness.MainNode.GetServiceProvider()
+	contracts := sp.GetConstellationManager().Contracts()
+	directoryRoles := map[string][32]byte{
+		"ADMIN_ROLE":        constellation.AdminRole,
+		"ADMIN_SERVER_ROLE": constellation.AdminServerRole,
+		"ADMIN_ORACLE_ROLE": constellation.AdminOracleRole,
+		"TIMELOCK_SHORT":    constellation.TimelockShortRole,
+		"TIMELOCK_MED":      constellation.TimelockMediumRole,
+		"TIMELOCK_LONG":     constellation.TimelockLongRole,
+	}
+	results := map[string]*bool{}
+	err := sp.GetQueryManager().Query(func(mc *batch.MultiCaller) error {
+		for name, hash := range directoryRoles {
+			results[name] = new(bool)
+			contracts.Directory.HasRole(mc, results[name], hash, account)
+		}
+		results["TREASURER_ROLE"] = new(bool)
+		contracts.Treasury.HasRole(mc, results["TREASURER_ROLE"], constellation.TreasurerRole, account)
+		return nil
+	}, nil)
+	require.NoError(t, err)
+
+	roles := map[string]bool{}
+	for name, hasRole := range results {
+		roles[name] = *hasRole
+	}
+	return roles
+}
@@ -0,0 +1,110 @@
+package with_minipool
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	hdtesting "github.com/nodeset-org/hyperdrive-daemon/testing"
+	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/stretchr/testify/require"
+)
+
+// Make sure state-changing calls are recorded in the audit log and the hash chain holds up
+func TestAuditLog(t *testing.T) {
+	// Take a snapshot, revert at the end
+	testMgr := harness.TestManager
+	snapshotName, err := testMgr.CreateCustomSnapshot(hdtesting.Service_EthClients | hdtesting.Service_Filesystem | hdtesting.Service_NodeSet)
+	if err != nil {
+		fail("Error creating custom snapshot: %v", err)
+	}
+	defer nodeset_cleanup(snapshotName)
+
+	cs := harness.MainNode.GetApiClient()
+	start := time.Now().Add(-time.Second)
+
+	// Make an audited call; the result doesn't matter, only that it gets recorded
+	_, _ = cs.Minipool.Stake()
+
+	// Check the log
+	response, err := cs.Service.GetAuditLog(start, time.Time{})
+	require.NoError(t, err)
+	data := response.Data
+	require.True(t, data.ChainIntact)
+	require.NotEmpty(t, data.Entries)
+	entry := data.Entries[len(data.Entries)-1]
+	require.Equal(t, "GET", entry.Method)
+	require.Equal(t, "/minipool/stake", entry.Route)
+	require.Equal(t, data.TotalEntries-1, entry.Sequence)
+	t.Logf("Stake call was recorded as audit log entry %d (hash %s)", entry.Sequence, entry.Hash.Hex())
+
+	// Make sure nothing is returned for a range that ends before the call
+	response, err = cs.Service.GetAuditLog(time.Time{}, start)
+	require.NoError(t, err)
+	for _, entry := range response.Data.Entries {
+		require.False(t, entry.Time.After(start))
+	}
+}
+
+// Make sure the hash of a broadcast transaction is recorded with the call that broadcast it
+func TestAuditLogTxHash(t *testing.T) {
+	// Take a snapshot, revert at the end
+	testMgr := harness.TestManager
+	snapshotName, err := testMgr.CreateCustomSnapshot(hdtesting.Service_EthClients | hdtesting.Service_Filesystem | hdtesting.Service_NodeSet)
+	if err != nil {
+		fail("Error creating custom snapshot: %v", err)
+	}
+	defer nodeset_cleanup(snapshotName)
+
+	sp := harness.MainNode.GetServiceProvider()
+	cs := harness.MainNode.GetApiClient()
+	ec := sp.GetEthClient()
+	start := time.Now().Add(-time.Second)
+
+	// Sign a simple transfer with the deployer's key
+	key, err := harness.KeyGenerator.GetEthPrivateKey(0)
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	nonce, err := ec.PendingNonceAt(context.Background(), from)
+	require.NoError(t, err)
+	chainID, err := ec.ChainID(context.Background())
+	require.NoError(t, err)
+	to := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(chainID), &types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		GasTipCap: eth.GweiToWei(1),
+		GasFeeCap: eth.GweiToWei(100),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(1),
+	})
+	require.NoError(t, err)
+	signedTx, err := tx.MarshalBinary()
+	require.NoError(t, err)
+
+	// Submit it
+	submitResponse, err := cs.Tx.SubmitSigned(signedTx)
+	require.NoError(t, err)
+	require.Equal(t, tx.Hash(), submitResponse.Data.TxHash)
+
+	// The entry should have its hash
+	response, err := cs.Service.GetAuditLog(start, time.Time{})
+	require.NoError(t, err)
+	require.True(t, response.Data.ChainIntact)
+	require.NotEmpty(t, response.Data.Entries)
+	entry := response.Data.Entries[len(response.Data.Entries)-1]
+	require.Equal(t, "/tx/submit-signed", entry.Route)
+	require.NotNil(t, entry.TxHash)
+	require.Equal(t, tx.Hash(), *entry.TxHash)
+
+	// It should be tracked too
+	pendingResponse, err := cs.Tx.GetPending()
+	require.NoError(t, err)
+	require.Len(t, pendingResponse.Data.Transactions, 1)
+	require.Equal(t, tx.Hash(), pendingResponse.Data.Transactions[0].Hash)
+}
@@ -0,0 +1,119 @@
+package with_minipool
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	hdtesting "github.com/nodeset-org/hyperdrive-daemon/testing"
+	batch "github.com/rocket-pool/batch-query"
+	"github.com/stretchr/testify/require"
+)
+
+// Make sure the event index picks up the minipool the node created, and the routes only report what's been indexed
+func TestMinipoolEvents(t *testing.T) {
+	// Take a snapshot, revert at the end
+	testMgr := harness.TestManager
+	snapshotName, err := testMgr.CreateCustomSnapshot(hdtesting.Service_EthClients | hdtesting.Service_Filesystem | hdtesting.Service_NodeSet)
+	if err != nil {
+		fail("Error creating custom snapshot: %v", err)
+	}
+	defer nodeset_cleanup(snapshotName)
+
+	// Get some services
+	sp := harness.MainNode.GetServiceProvider()
+	cs := harness.MainNode.GetApiClient()
+	ec := sp.GetEthClient()
+	ctx := context.Background()
+
+	// Catch the index up the way the task loop does
+	status := updateEventIndex(t, sp)
+	latestBlock, err := ec.BlockNumber(ctx)
+	require.NoError(t, err)
+	require.Equal(t, latestBlock, status.LatestBlock)
+
+	// The route reports the index's progress
+	response, err := cs.Node.MinipoolEvents(0)
+	require.NoError(t, err)
+	data := response.Data
+	require.Equal(t, harness.MainNodeAddress, data.NodeAddress)
+	require.Equal(t, status.IndexedBlock, data.IndexedBlock)
+	require.Equal(t, status.LatestBlock, data.LatestBlock)
+	require.True(t, data.IsCaughtUp)
+	require.Empty(t, data.Destroyed)
+
+	// The only minipool is the one made during setup, and the event matches its creation TX
+	require.Len(t, data.Created, 1)
+	event := data.Created[0]
+	require.Equal(t, mp.Common().Address, event.Minipool)
+	require.Equal(t, harness.MainNodeAddress, event.Operator)
+	receipt, err := ec.TransactionReceipt(ctx, event.TxHash)
+	require.NoError(t, err)
+	require.Equal(t, event.Block, receipt.BlockNumber.Uint64())
+	t.Logf("Minipool %s was created in block %d", event.Minipool.Hex(), event.Block)
+
+	// Nothing should be returned from after the minipool was created
+	response, err = cs.Node.MinipoolEvents(event.Block + 1)
+	require.NoError(t, err)
+	require.Empty(t, response.Data.Created)
+}
+
+// Make sure the proxy events end with each contract's current implementation
+func TestProxyEvents(t *testing.T) {
+	// Take a snapshot, revert at the end
+	testMgr := harness.TestManager
+	snapshotName, err := testMgr.CreateCustomSnapshot(hdtesting.Service_EthClients | hdtesting.Service_Filesystem | hdtesting.Service_NodeSet)
+	if err != nil {
+		fail("Error creating custom snapshot: %v", err)
+	}
+	defer nodeset_cleanup(snapshotName)
+
+	// Get some services
+	sp := harness.MainNode.GetServiceProvider()
+	cs := harness.MainNode.GetApiClient()
+	contracts := sp.GetConstellationManager().Contracts()
+
+	status := updateEventIndex(t, sp)
+	response, err := cs.Network.ProxyEvents(0)
+	require.NoError(t, err)
+	data := response.Data
+	require.Equal(t, status.IndexedBlock, data.IndexedBlock)
+	require.True(t, data.IsCaughtUp)
+
+	// The last upgrade of each proxy should point to the implementation it has now
+	implementations := map[string]*common.Address{
+		"Whitelist":           new(common.Address),
+		"SuperNodeAccount":    new(common.Address),
+		"OperatorDistributor": new(common.Address),
+		"WethVault":           new(common.Address),
+		"RplVault":            new(common.Address),
+	}
+	err = sp.GetQueryManager().Query(func(mc *batch.MultiCaller) error {
+		contracts.Whitelist.GetImplementation(mc, implementations["Whitelist"])
+		contracts.SuperNodeAccount.GetImplementation(mc, implementations["SuperNodeAccount"])
+		contracts.OperatorDistributor.GetImplementation(mc, implementations["OperatorDistributor"])
+		contracts.WethVault.GetImplementation(mc, implementations["WethVault"])
+		contracts.RplVault.GetImplementation(mc, implementations["RplVault"])
+		return nil
+	}, nil)
+	require.NoError(t, err)
+	latestUpgrades := map[string]csapi.ProxyUpgradeEvent{}
+	for _, upgrade := range data.Upgrades {
+		latestUpgrades[upgrade.Contract] = upgrade
+	}
+	for name, implementation := range implementations {
+		upgrade, exists := latestUpgrades[name]
+		require.True(t, exists, "no upgrades were indexed for %s", name)
+		require.Equal(t, *implementation, upgrade.Implementation, "latest upgrade of %s doesn't match its implementation", name)
+	}
+}
+
+// Run an index update and make sure it caught up to the chain
+func updateEventIndex(t *testing.T, sp cscommon.IConstellationServiceProvider) cscommon.EventIndexStatus {
+	status, err := sp.GetEventIndexer().Update(context.Background())
+	require.NoError(t, err)
+	require.True(t, status.IsCaughtUp())
+	return status
+}
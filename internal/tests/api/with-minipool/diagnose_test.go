@@ -0,0 +1,113 @@
+package with_minipool
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	hdtesting "github.com/nodeset-org/hyperdrive-daemon/testing"
+	batch "github.com/rocket-pool/batch-query"
+	"github.com/stretchr/testify/require"
+)
+
+// Make sure the diagnostics pass the registration checks for a node that's already made a minipool
+func TestDiagnose(t *testing.T) {
+	// Take a snapshot, revert at the end
+	testMgr := harness.TestManager
+	snapshotName, err := testMgr.CreateCustomSnapshot(hdtesting.Service_EthClients | hdtesting.Service_Filesystem | hdtesting.Service_NodeSet)
+	if err != nil {
+		fail("Error creating custom snapshot: %v", err)
+	}
+	defer nodeset_cleanup(snapshotName)
+
+	// Get some services
+	sp := harness.MainNode.GetServiceProvider()
+	cs := harness.MainNode.GetApiClient()
+	csMgr := sp.GetConstellationManager()
+	require.NoError(t, csMgr.LoadContracts())
+
+	// Get the expected values from the chain
+	var activeValidatorCount *big.Int
+	var maxValidators *big.Int
+	var lockThreshold *big.Int
+	err = sp.GetQueryManager().Query(func(mc *batch.MultiCaller) error {
+		csMgr.Contracts().Whitelist.GetActiveValidatorCountForOperator(mc, &activeValidatorCount, harness.MainNodeAddress)
+		csMgr.Contracts().SuperNodeAccount.GetMaxValidators(mc, &maxValidators)
+		csMgr.Contracts().SuperNodeAccount.LockThreshold(mc, &lockThreshold)
+		return nil
+	}, nil)
+	require.NoError(t, err)
+	balance, err := sp.GetEthClient().BalanceAt(context.Background(), harness.MainNodeAddress, nil)
+	require.NoError(t, err)
+
+	// Run the diagnostics
+	response, err := cs.Node.Diagnose()
+	require.NoError(t, err)
+	data := response.Data
+	require.Equal(t, harness.MainNodeAddress, data.WalletAddress)
+	require.Equal(t, harness.MainNodeAddress, data.RegisteredAddress)
+	require.Equal(t, 0, activeValidatorCount.Cmp(data.ActiveValidatorCount))
+	require.Equal(t, 0, maxValidators.Cmp(data.MaxValidators))
+	require.Equal(t, 0, lockThreshold.Cmp(data.LockThreshold))
+	require.Equal(t, 0, balance.Cmp(data.NodeBalance))
+
+	// Check the checklist
+	checks := getDiagnosticChecks(t, data)
+	require.Len(t, checks, 7)
+	require.Equal(t, csapi.NodeDiagnosticStatus_Pass, checks[csapi.NodeDiagnosticCheck_Wallet].Status)
+	require.Equal(t, csapi.NodeDiagnosticStatus_Pass, checks[csapi.NodeDiagnosticCheck_NodeSetRegistration].Status)
+	require.Equal(t, csapi.NodeDiagnosticStatus_Pass, checks[csapi.NodeDiagnosticCheck_RegisteredAddress].Status)
+	require.Equal(t, csapi.NodeDiagnosticStatus_Pass, checks[csapi.NodeDiagnosticCheck_Whitelist].Status)
+	require.Empty(t, checks[csapi.NodeDiagnosticCheck_Whitelist].Remediation)
+}
+
+// Make sure the diagnostics still run without a loaded wallet, reporting it as a failed check
+func TestDiagnoseWithoutWallet(t *testing.T) {
+	// Take a snapshot, revert at the end
+	testMgr := harness.TestManager
+	snapshotName, err := testMgr.CreateCustomSnapshot(hdtesting.Service_EthClients | hdtesting.Service_Filesystem | hdtesting.Service_NodeSet)
+	if err != nil {
+		fail("Error creating custom snapshot: %v", err)
+	}
+	defer nodeset_cleanup(snapshotName)
+
+	// Get some services
+	cs := harness.MainNode.GetApiClient()
+	hd := harness.MainNode.GetHyperdriveNode().GetApiClient()
+
+	// Switch the node address to one the daemon doesn't have the key for
+	externalAddress := common.HexToAddress("0x18e0e9f5c1e8a1b7f6c7d4a7d1cbbd0c1bbd7e01")
+	_, err = hd.Wallet.Masquerade(externalAddress)
+	require.NoError(t, err)
+	t.Logf("Set the node address to %s", externalAddress.Hex())
+
+	// Run the diagnostics
+	response, err := cs.Node.Diagnose()
+	require.NoError(t, err)
+	data := response.Data
+	require.False(t, data.Passed)
+	require.Equal(t, externalAddress, data.WalletAddress)
+	require.Equal(t, 0, data.ActiveValidatorCount.Sign())
+	require.Equal(t, 0, data.NodeBalance.Sign())
+
+	// The wallet check fails, and the on-chain checks run against the node address
+	checks := getDiagnosticChecks(t, data)
+	require.Len(t, checks, 7)
+	require.Equal(t, csapi.NodeDiagnosticStatus_Fail, checks[csapi.NodeDiagnosticCheck_Wallet].Status)
+	require.NotEmpty(t, checks[csapi.NodeDiagnosticCheck_Wallet].Remediation)
+	require.Equal(t, csapi.NodeDiagnosticStatus_Fail, checks[csapi.NodeDiagnosticCheck_Whitelist].Status)
+	require.Equal(t, csapi.NodeDiagnosticStatus_Fail, checks[csapi.NodeDiagnosticCheck_Balance].Status)
+}
+
+// Get the diagnostic checks by ID, making sure each one only shows up once
+func getDiagnosticChecks(t *testing.T, data *csapi.NodeDiagnoseData) map[string]csapi.NodeDiagnosticCheck {
+	checks := map[string]csapi.NodeDiagnosticCheck{}
+	for _, check := range data.Checks {
+		require.NotContains(t, checks, check.ID)
+		checks[check.ID] = check
+		t.Logf("%s: %s (%s)", check.Title, check.Status, check.Details)
+	}
+	return checks
+}
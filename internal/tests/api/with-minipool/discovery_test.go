@@ -0,0 +1,85 @@
+package with_minipool
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	csconfig "github.com/nodeset-org/hyperdrive-constellation/shared/config"
+	cstesting "github.com/nodeset-org/hyperdrive-constellation/testing"
+	hdtesting "github.com/nodeset-org/hyperdrive-daemon/testing"
+	"github.com/stretchr/testify/require"
+)
+
+// Make sure the resources left out of the settings are discovered from the Directory on the test chain
+func TestDiscoverResources(t *testing.T) {
+	sp := harness.MainNode.GetServiceProvider()
+	settings := newDirectoryOnlySettings(t)
+	require.True(t, settings.NeedsDiscovery())
+
+	discovered, err := cscommon.DiscoverResources(settings, sp.GetEthClient(), sp.GetQueryManager())
+	require.NoError(t, err)
+	require.False(t, settings.NeedsDiscovery())
+	require.Equal(t, common.HexToAddress(cstesting.RocketStorageAddress), *settings.ConstellationResources.RocketStorage)
+	require.Equal(t, common.HexToAddress(cstesting.SmoothingPoolAddress), *settings.ConstellationResources.FeeRecipient)
+	require.Equal(t, common.HexToAddress(cstesting.RocketStorageAddress), settings.SmartNodeResources.StorageAddress)
+	require.Equal(t, common.HexToAddress(cstesting.RplAddress), settings.SmartNodeResources.RplTokenAddress)
+	require.Equal(t, common.HexToAddress(cstesting.RethAddress), settings.SmartNodeResources.RethAddress)
+	require.Equal(t, common.HexToAddress(cstesting.SmoothingPoolAddress), discovered.FeeRecipient)
+	require.True(t, discovered.Matches(settings))
+}
+
+// Make sure settings with an address that doesn't match the chain are caught
+func TestDiscoverResourcesMismatch(t *testing.T) {
+	sp := harness.MainNode.GetServiceProvider()
+	settings := newDirectoryOnlySettings(t)
+	wrongFeeRecipient := common.HexToAddress(cstesting.RethAddress)
+	settings.ConstellationResources.FeeRecipient = &wrongFeeRecipient
+
+	_, err := cscommon.DiscoverResources(settings, sp.GetEthClient(), sp.GetQueryManager())
+	require.ErrorIs(t, err, cscommon.ErrResourceMismatch)
+	require.ErrorContains(t, err, "constellationResources.feeRecipient")
+}
+
+// Make sure reloading settings that leave out the discoverable resources saves the discovered ones for templating
+func TestReloadSettingsSavesDiscoveredResources(t *testing.T) {
+	// Take a snapshot, revert at the end
+	testMgr := harness.TestManager
+	snapshotName, err := testMgr.CreateCustomSnapshot(hdtesting.Service_EthClients | hdtesting.Service_Filesystem | hdtesting.Service_NodeSet)
+	if err != nil {
+		fail("Error creating custom snapshot: %v", err)
+	}
+	defer nodeset_cleanup(snapshotName)
+	sp := harness.MainNode.GetServiceProvider()
+	cs := harness.MainNode.GetApiClient()
+	original := loadTestSettings(t)
+	defer restoreTestSettings(t, original)
+
+	// The discovered addresses are the same as the configured ones, so nothing changes
+	err = cstesting.SaveTestSettingsFile(sp.GetSettingsReloader().GetFolder(), newDirectoryOnlySettings(t))
+	require.NoError(t, err)
+	response, err := cs.Service.ReloadSettings()
+	require.NoError(t, err)
+	require.False(t, response.Data.Changed)
+
+	// They should be saved for templating
+	discovered, err := csconfig.LoadDiscoveredResources(sp.GetModuleDir())
+	require.NoError(t, err)
+	require.NotNil(t, discovered)
+	require.Equal(t, *original.ConstellationResources.Directory, discovered.Directory)
+	require.Equal(t, common.HexToAddress(cstesting.RocketStorageAddress), discovered.RocketStorage)
+	require.Equal(t, common.HexToAddress(cstesting.SmoothingPoolAddress), discovered.FeeRecipient)
+}
+
+// Get a copy of the test network settings with everything that can be discovered left out
+func newDirectoryOnlySettings(t *testing.T) *csconfig.ConstellationSettings {
+	original := loadTestSettings(t)
+	require.True(t, original.IsCustomNetwork())
+	res := *original.ConstellationResources
+	res.RocketStorage = nil
+	res.FeeRecipient = nil
+	return &csconfig.ConstellationSettings{
+		Key:                    original.Key,
+		ConstellationResources: &res,
+	}
+}
@@ -0,0 +1,85 @@
+package with_minipool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	hdtesting "github.com/nodeset-org/hyperdrive-daemon/testing"
+	"github.com/stretchr/testify/require"
+)
+
+// Make sure published events are streamed to subscribers, and missed events are replayed on reconnect
+func TestEventStream(t *testing.T) {
+	// Take a snapshot, revert at the end
+	testMgr := harness.TestManager
+	snapshotName, err := testMgr.CreateCustomSnapshot(hdtesting.Service_EthClients | hdtesting.Service_Filesystem | hdtesting.Service_NodeSet)
+	if err != nil {
+		fail("Error creating custom snapshot: %v", err)
+	}
+	defer nodeset_cleanup(snapshotName)
+
+	// Get some services
+	sp := harness.MainNode.GetServiceProvider()
+	cs := harness.MainNode.GetApiClient()
+	broker := sp.GetEventBroker()
+	mpAddress := mp.Common().Address
+
+	// Subscribe
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, errs, err := cs.Events.Subscribe(ctx, 0)
+	require.NoError(t, err)
+
+	// Publish an event and wait for it
+	broker.Publish(csapi.Event{
+		Type:        csapi.EventType_TaskError,
+		NodeAddress: harness.MainNodeAddress,
+		Minipool:    mpAddress,
+		Task:        t.Name(),
+		Error:       "test error",
+	})
+	var first csapi.Event
+	select {
+	case first = <-events:
+		require.Equal(t, csapi.EventType_TaskError, first.Type)
+		require.Equal(t, harness.MainNodeAddress, first.NodeAddress)
+		require.Equal(t, mpAddress, first.Minipool)
+		require.Equal(t, t.Name(), first.Task)
+		require.Equal(t, "test error", first.Error)
+		require.NotZero(t, first.ID)
+		require.False(t, first.Time.IsZero())
+		t.Logf("Received event %d", first.ID)
+	case err := <-errs:
+		t.Fatalf("Event stream failed: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Didn't receive the event")
+	}
+	cancel()
+
+	// Publish another while disconnected, then resume from the first one
+	broker.Publish(csapi.Event{
+		Type:        csapi.EventType_ExitUploaded,
+		NodeAddress: harness.MainNodeAddress,
+		Minipool:    mpAddress,
+	})
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	events, errs, err = cs.Events.Subscribe(ctx, first.ID)
+	require.NoError(t, err)
+	select {
+	case event := <-events:
+		require.Equal(t, first.ID+1, event.ID)
+		require.Equal(t, csapi.EventType_ExitUploaded, event.Type)
+		require.Equal(t, harness.MainNodeAddress, event.NodeAddress)
+		require.Equal(t, mpAddress, event.Minipool)
+		require.Empty(t, event.Task)
+		require.Empty(t, event.Error)
+		t.Logf("Missed event %d was replayed", event.ID)
+	case err := <-errs:
+		t.Fatalf("Event stream failed: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Missed event wasn't replayed")
+	}
+}
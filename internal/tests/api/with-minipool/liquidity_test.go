@@ -0,0 +1,113 @@
+package with_minipool
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	hdtesting "github.com/nodeset-org/hyperdrive-daemon/testing"
+	batch "github.com/rocket-pool/batch-query"
+	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/stretchr/testify/require"
+)
+
+// Make sure the liquidity forecast matches the chain, and never gets worse with more deposits
+func TestLiquidityForecast(t *testing.T) {
+	// Take a snapshot, revert at the end
+	testMgr := harness.TestManager
+	snapshotName, err := testMgr.CreateCustomSnapshot(hdtesting.Service_EthClients | hdtesting.Service_Filesystem | hdtesting.Service_NodeSet)
+	if err != nil {
+		fail("Error creating custom snapshot: %v", err)
+	}
+	defer nodeset_cleanup(snapshotName)
+
+	// Get some services
+	sp := harness.MainNode.GetServiceProvider()
+	cs := harness.MainNode.GetApiClient()
+	csMgr := sp.GetConstellationManager()
+	require.NoError(t, csMgr.LoadContracts())
+
+	// Get the expected values from the chain
+	var bond *big.Int
+	err = sp.GetQueryManager().Query(func(mc *batch.MultiCaller) error {
+		csMgr.Contracts().SuperNodeAccount.Bond(mc, &bond)
+		return nil
+	}, nil)
+	require.NoError(t, err)
+	odBalance, err := sp.GetEthClient().BalanceAt(context.Background(), csMgr.Contracts().OperatorDistributor.Address, nil)
+	require.NoError(t, err)
+
+	// Check the forecast without deposits
+	response, err := cs.Network.Liquidity(nil, nil)
+	require.NoError(t, err)
+	base := response.Data
+	require.Equal(t, 0, bond.Cmp(base.MinipoolBond))
+	require.Equal(t, 0, new(big.Int).Sub(eth.EthToWei(32), bond).Cmp(base.BorrowedEthPerMinipool))
+	require.Equal(t, 0, odBalance.Cmp(base.OperatorDistributorEthBalance))
+	require.Equal(t, 0, base.EthDeposit.Sign())
+	require.Equal(t, 0, base.RplDeposit.Sign())
+	checkLiquidityScenario(t, base, base.Current, odBalance, base.OperatorDistributorRplBalance)
+	checkLiquidityScenario(t, base, base.WithVaultDeposits,
+		new(big.Int).Add(odBalance, base.WethVaultSurplus),
+		new(big.Int).Add(base.OperatorDistributorRplBalance, base.RplVaultSurplus),
+	)
+	require.GreaterOrEqual(t, base.WithVaultDeposits.MinipoolCount, base.Current.MinipoolCount)
+	t.Logf("Constellation can fund %d minipools now (limited by %s), or %d with the vaults' current surplus",
+		base.Current.MinipoolCount, base.Current.LimitedBy, base.WithVaultDeposits.MinipoolCount,
+	)
+
+	// Check it with deposits; the current scenario shouldn't change
+	ethDeposit := eth.EthToWei(100)
+	rplDeposit := eth.EthToWei(10000)
+	response, err = cs.Network.Liquidity(ethDeposit, rplDeposit)
+	require.NoError(t, err)
+	withDeposits := response.Data
+	require.Equal(t, 0, ethDeposit.Cmp(withDeposits.EthDeposit))
+	require.Equal(t, 0, rplDeposit.Cmp(withDeposits.RplDeposit))
+	require.Equal(t, base.Current.MinipoolCount, withDeposits.Current.MinipoolCount)
+	require.Equal(t, base.Current.LimitedBy, withDeposits.Current.LimitedBy)
+	require.Equal(t, 0, base.Current.AvailableEth.Cmp(withDeposits.Current.AvailableEth))
+	require.Equal(t, 0, base.Current.AvailableRpl.Cmp(withDeposits.Current.AvailableRpl))
+	require.GreaterOrEqual(t, withDeposits.WethVaultSurplus.Cmp(base.WethVaultSurplus), 0)
+	require.GreaterOrEqual(t, withDeposits.RplVaultSurplus.Cmp(base.RplVaultSurplus), 0)
+	checkLiquidityScenario(t, withDeposits, withDeposits.WithVaultDeposits,
+		new(big.Int).Add(odBalance, withDeposits.WethVaultSurplus),
+		new(big.Int).Add(withDeposits.OperatorDistributorRplBalance, withDeposits.RplVaultSurplus),
+	)
+	require.GreaterOrEqual(t, withDeposits.WithVaultDeposits.MinipoolCount, base.WithVaultDeposits.MinipoolCount)
+	t.Logf("With deposits of 100 ETH and 10000 RPL, Constellation could fund %d minipools", withDeposits.WithVaultDeposits.MinipoolCount)
+}
+
+// Make sure a liquidity scenario is consistent with the amounts it was given
+func checkLiquidityScenario(t *testing.T, data *csapi.NetworkLiquidityData, scenario csapi.NetworkLiquidityScenario, expectedEth *big.Int, expectedRpl *big.Int) {
+	require.Equal(t, 0, expectedEth.Cmp(scenario.AvailableEth))
+	require.Equal(t, 0, expectedRpl.Cmp(scenario.AvailableRpl))
+
+	// The ETH has to cover the bonds of every minipool it can fund
+	fundedEth := new(big.Int).Mul(data.MinipoolBond, big.NewInt(int64(scenario.MinipoolCount)))
+	require.LessOrEqual(t, fundedEth.Cmp(scenario.AvailableEth), 0)
+
+	// The reported shortfall for the next minipool has to match what's missing
+	nextEth := new(big.Int).Add(fundedEth, data.MinipoolBond)
+	expectedEthShortfall := big.NewInt(0)
+	if nextEth.Cmp(scenario.AvailableEth) > 0 {
+		expectedEthShortfall.Sub(nextEth, scenario.AvailableEth)
+	}
+	require.Equal(t, 0, expectedEthShortfall.Cmp(scenario.NextEthShortfall))
+
+	switch scenario.LimitedBy {
+	case csapi.NetworkLiquidityLimit_Rpl:
+		require.Equal(t, 1, scenario.NextRplShortfall.Sign())
+		expectedShortfallEth := new(big.Int).Mul(scenario.NextRplShortfall, data.RplPrice)
+		expectedShortfallEth.Div(expectedShortfallEth, eth.EthToWei(1))
+		require.Equal(t, 0, expectedShortfallEth.Cmp(scenario.NextRplShortfallEth))
+	case csapi.NetworkLiquidityLimit_Eth:
+		require.Equal(t, 0, scenario.NextRplShortfall.Sign())
+		require.Equal(t, 1, scenario.NextEthShortfall.Sign())
+	default:
+		require.Equal(t, 0, scenario.NextRplShortfall.Sign())
+		require.Equal(t, 0, scenario.NextEthShortfall.Sign())
+		require.True(t, scenario.AtSearchLimit)
+	}
+}
@@ -76,8 +76,8 @@ func createMinipool(salt *big.Int, node *cstesting.ConstellationNode, nodeAddres
 	var rplPrice *big.Int
 	var minipoolBond *big.Int
 	err := qMgr.Query(func(mc *batch.MultiCaller) error {
-		csMgr.PriceFetcher.GetRplPrice(mc, &rplPrice)
-		csMgr.SuperNodeAccount.Bond(mc, &minipoolBond)
+		csMgr.Contracts().PriceFetcher.GetRplPrice(mc, &rplPrice)
+		csMgr.Contracts().SuperNodeAccount.Bond(mc, &minipoolBond)
 		return nil
 	}, nil,
 		bindings.RpSuperNode.Exists,
@@ -127,13 +127,13 @@ func createMinipool(salt *big.Int, node *cstesting.ConstellationNode, nodeAddres
 	wethAmount, rplAmount := getDepositAmounts(1)
 
 	// Deposit WETH to the WETH vault
-	err = cstestutils.DepositToWethVaultBeforeTest(harness, csMgr.WethVault, bindings.Weth, wethAmount, deployerOpts)
+	err = cstestutils.DepositToWethVaultBeforeTest(harness, csMgr.Contracts().WethVault, bindings.Weth, wethAmount, deployerOpts)
 	if err != nil {
 		fail("error depositing WETH to the WETH vault: %v", err)
 	}
 
 	// Deposit RPL to the RPL vault
-	err = cstestutils.DepositToRplVaultBeforeTest(harness, csMgr.RplVault, bindings.Rpl, rplAmount, deployerOpts)
+	err = cstestutils.DepositToRplVaultBeforeTest(harness, csMgr.Contracts().RplVault, bindings.Rpl, rplAmount, deployerOpts)
 	if err != nil {
 		fail("error depositing RPL to the RPL vault: %v", err)
 	}
@@ -202,11 +202,11 @@ func getDepositAmounts(minipoolCount int) (*big.Int, *big.Int) {
 	var rplReserveRatio *big.Int
 	var mintFee *big.Int
 	err := qMgr.Query(func(mc *batch.MultiCaller) error {
-		csMgr.PriceFetcher.GetRplPrice(mc, &rplPerEth)
-		csMgr.SuperNodeAccount.Bond(mc, &minipoolBond)
-		csMgr.WethVault.GetLiquidityReservePercent(mc, &ethReserveRatio)
-		csMgr.RplVault.GetLiquidityReservePercent(mc, &rplReserveRatio)
-		csMgr.WethVault.GetMintFee(mc, &mintFee)
+		csMgr.Contracts().PriceFetcher.GetRplPrice(mc, &rplPerEth)
+		csMgr.Contracts().SuperNodeAccount.Bond(mc, &minipoolBond)
+		csMgr.Contracts().WethVault.GetLiquidityReservePercent(mc, &ethReserveRatio)
+		csMgr.Contracts().RplVault.GetLiquidityReservePercent(mc, &rplReserveRatio)
+		csMgr.Contracts().WethVault.GetMintFee(mc, &mintFee)
 		return nil
 	}, nil,
 		bindings.RpSuperNode.RplStake,
@@ -229,7 +229,7 @@ func getDepositAmounts(minipoolCount int) (*big.Int, *big.Int) {
 	totalEthMatched := bindings.RpSuperNode.EthMatched.Get()
 	ethAmount := new(big.Int).Add(totalEthMatched, totalEthBorrow)
 	err = qMgr.Query(func(mc *batch.MultiCaller) error {
-		csMgr.OperatorDistributor.CalculateRplStakeShortfall(mc, &rplShortfall, bindings.RpSuperNode.RplStake.Get(), ethAmount)
+		csMgr.Contracts().OperatorDistributor.CalculateRplStakeShortfall(mc, &rplShortfall, bindings.RpSuperNode.RplStake.Get(), ethAmount)
 		return nil
 	}, nil)
 	if err != nil {
@@ -6,14 +6,17 @@ import (
 	"runtime/debug"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
 	cstestutils "github.com/nodeset-org/hyperdrive-constellation/internal/tests/utils"
 	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
 	cstasks "github.com/nodeset-org/hyperdrive-constellation/tasks"
 	hdtesting "github.com/nodeset-org/hyperdrive-daemon/testing"
 	batch "github.com/rocket-pool/batch-query"
 	"github.com/rocket-pool/node-manager-core/eth"
+	rptypes "github.com/rocket-pool/rocketpool-go/v2/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -37,11 +40,11 @@ func TestDuplicateSalts(t *testing.T) {
 
 	// Deposit RPL to the RPL vault
 	rplAmount := eth.EthToWei(3200)
-	cstestutils.DepositToRplVault(t, testMgr, csMgr.RplVault, bindings.Rpl, rplAmount, deployerOpts)
+	cstestutils.DepositToRplVault(t, testMgr, csMgr.Contracts().RplVault, bindings.Rpl, rplAmount, deployerOpts)
 
 	// Deposit WETH to the WETH vault
 	wethAmount := eth.EthToWei(90)
-	cstestutils.DepositToWethVault(t, testMgr, csMgr.WethVault, bindings.Weth, wethAmount, deployerOpts)
+	cstestutils.DepositToWethVault(t, testMgr, csMgr.Contracts().WethVault, bindings.Weth, wethAmount, deployerOpts)
 
 	// Try making another one with the same salt, it should fail
 	_, err = cs.Minipool.Create(standardSalt, false, false)
@@ -66,7 +69,7 @@ func TestSkipLiquidityCheck(t *testing.T) {
 	cs := mainNode.GetApiClient()
 
 	// Set max validators to 2
-	txInfo, err := csMgr.SuperNodeAccount.SetMaxValidators(common.Big2, deployerOpts)
+	txInfo, err := csMgr.Contracts().SuperNodeAccount.SetMaxValidators(common.Big2, deployerOpts)
 	require.NoError(t, err)
 	testMgr.MineTx(t, txInfo, deployerOpts, "Set max validators to 2")
 
@@ -106,14 +109,14 @@ func TestSkipBalanceCheck(t *testing.T) {
 
 	// Deposit RPL to the RPL vault
 	rplAmount := eth.EthToWei(3200)
-	cstestutils.DepositToRplVault(t, testMgr, csMgr.RplVault, bindings.Rpl, rplAmount, deployerOpts)
+	cstestutils.DepositToRplVault(t, testMgr, csMgr.Contracts().RplVault, bindings.Rpl, rplAmount, deployerOpts)
 
 	// Deposit WETH to the WETH vault
 	wethAmount := eth.EthToWei(90)
-	cstestutils.DepositToWethVault(t, testMgr, csMgr.WethVault, bindings.Weth, wethAmount, deployerOpts)
+	cstestutils.DepositToWethVault(t, testMgr, csMgr.Contracts().WethVault, bindings.Weth, wethAmount, deployerOpts)
 
 	// Set max validators to 2
-	txInfo, err := csMgr.SuperNodeAccount.SetMaxValidators(common.Big2, deployerOpts)
+	txInfo, err := csMgr.Contracts().SuperNodeAccount.SetMaxValidators(common.Big2, deployerOpts)
 	require.NoError(t, err)
 	testMgr.MineTx(t, txInfo, deployerOpts, "Set max validators to 2")
 
@@ -141,6 +144,47 @@ func TestSkipBalanceCheck(t *testing.T) {
 	t.Logf("MP create failed with skip-balance check off")
 }
 
+// Make sure minipools can be created and closed in export mode when the node address is an external wallet, so the
+// daemon's wallet isn't ready
+func TestExportWithoutWallet(t *testing.T) {
+	// Take a snapshot, revert at the end
+	testMgr := harness.TestManager
+	mainNode := harness.MainNode
+	snapshotName, err := testMgr.CreateCustomSnapshot(hdtesting.Service_EthClients | hdtesting.Service_Filesystem | hdtesting.Service_NodeSet)
+	if err != nil {
+		fail("Error creating custom snapshot: %v", err)
+	}
+	defer nodeset_cleanup(snapshotName)
+
+	// Get some services
+	cs := mainNode.GetApiClient()
+	hd := mainNode.GetHyperdriveNode().GetApiClient()
+
+	// Switch the node address to one the daemon doesn't have the key for
+	externalAddress := common.HexToAddress("0x18e0e9f5c1e8a1b7f6c7d4a7d1cbbd0c1bbd7e01")
+	_, err = hd.Wallet.Masquerade(externalAddress)
+	require.NoError(t, err)
+	t.Logf("Set the node address to %s", externalAddress.Hex())
+
+	// Regular creation needs the wallet
+	_, err = cs.Minipool.Create(big.NewInt(3), true, true)
+	require.Error(t, err)
+	t.Logf("Minipool creation failed without a ready wallet as expected: %v", err)
+
+	// Exported creation runs against the node address instead; it isn't whitelisted, so it can't create
+	createResponse, err := cs.Minipool.ExportCreate(big.NewInt(3), true, true)
+	require.NoError(t, err)
+	require.True(t, createResponse.Data.NotWhitelistedWithConstellation)
+	require.False(t, createResponse.Data.CanCreate)
+	require.Nil(t, createResponse.Data.UnsignedTx)
+	t.Logf("Exported minipool creation ran for the node address without a ready wallet")
+
+	// Exported close gets past the wallet requirement and checks the node address's registration
+	_, err = cs.Minipool.ExportClose([]common.Address{mp.Common().Address})
+	require.ErrorContains(t, err, cscommon.ErrNotRegisteredWithConstellation.Error())
+	t.Logf("Exported minipool close checked the node address's registration without a ready wallet")
+}
+
 // Check if the manual signed exit upload command works as expected
 func TestSignedExitUpload_Manual(t *testing.T) {
 	// Take a snapshot, revert at the end
@@ -294,7 +338,7 @@ func TestSignedExitUpload_TaskAfterManual(t *testing.T) {
 	require.NoError(t, err)
 
 	// Make a 2nd minipool
-	txInfo, err := csMgr.SuperNodeAccount.SetMaxValidators(common.Big2, harness.DeployerOpts)
+	txInfo, err := csMgr.Contracts().SuperNodeAccount.SetMaxValidators(common.Big2, harness.DeployerOpts)
 	require.NoError(t, err)
 	testMgr.MineTx(t, txInfo, harness.DeployerOpts, "Set max validators to 2")
 
@@ -302,8 +346,8 @@ func TestSignedExitUpload_TaskAfterManual(t *testing.T) {
 	wethAmount, rplAmount := getDepositAmounts(2)
 
 	// Deposit to the vaults
-	cstestutils.DepositToRplVault(t, testMgr, csMgr.RplVault, harness.Bindings.Rpl, rplAmount, harness.DeployerOpts)
-	cstestutils.DepositToWethVault(t, testMgr, csMgr.WethVault, harness.Bindings.Weth, wethAmount, harness.DeployerOpts)
+	cstestutils.DepositToRplVault(t, testMgr, csMgr.Contracts().RplVault, harness.Bindings.Rpl, rplAmount, harness.DeployerOpts)
+	cstestutils.DepositToWethVault(t, testMgr, csMgr.Contracts().WethVault, harness.Bindings.Weth, wethAmount, harness.DeployerOpts)
 
 	// Make another minipool
 	salt2 := big.NewInt(0x90de5e702)
@@ -412,3 +456,99 @@ func nodeset_cleanup(snapshotName string) {
 		fail("Error reloading constellation wallet: %v", err)
 	}
 }
+
+// Make sure the stake task won't act on a network snapshot whose block was reorged out, and stakes once it has a new one
+func TestStakeAfterReorg(t *testing.T) {
+	// Take a snapshot, revert at the end
+	testMgr := harness.TestManager
+	mainNode := harness.MainNode
+	mainNodeAddress := harness.MainNodeAddress
+	snapshotName, err := testMgr.CreateCustomSnapshot(hdtesting.Service_EthClients | hdtesting.Service_Filesystem | hdtesting.Service_NodeSet)
+	if err != nil {
+		fail("Error creating custom snapshot: %v", err)
+	}
+	defer nodeset_cleanup(snapshotName)
+
+	// Get some services
+	sp := mainNode.GetServiceProvider()
+	qMgr := sp.GetQueryManager()
+	hd := mainNode.GetHyperdriveNode().GetApiClient()
+	csMgr := sp.GetConstellationManager()
+	bindings := harness.Bindings
+
+	// Use a fixed max fee so the task doesn't need a gas oracle
+	hdCfg := sp.GetHyperdriveConfig()
+	oldMaxFee := hdCfg.AutoTxMaxFee.Value
+	hdCfg.AutoTxMaxFee.Value = 10
+	defer func() {
+		hdCfg.AutoTxMaxFee.Value = oldMaxFee
+	}()
+
+	// Make a 2nd minipool and wait out its scrub period
+	txInfo, err := csMgr.Contracts().SuperNodeAccount.SetMaxValidators(common.Big2, harness.DeployerOpts)
+	require.NoError(t, err)
+	testMgr.MineTx(t, txInfo, harness.DeployerOpts, "Set max validators to 2")
+	wethAmount, rplAmount := getDepositAmounts(2)
+	cstestutils.DepositToRplVault(t, testMgr, csMgr.Contracts().RplVault, bindings.Rpl, rplAmount, harness.DeployerOpts)
+	cstestutils.DepositToWethVault(t, testMgr, csMgr.Contracts().WethVault, bindings.Weth, wethAmount, harness.DeployerOpts)
+	mp2 := cstestutils.CreateMinipool(t, testMgr, mainNode, mainNodeAddress, big.NewInt(0x90de5e703), bindings.RpSuperNode, bindings.MinipoolManager)
+	mp2Common := mp2.Common()
+	err = qMgr.Query(nil, nil, bindings.OracleDaoManager.Settings.Minipool.ScrubPeriod)
+	require.NoError(t, err)
+	secondsPerSlot := time.Duration(testMgr.GetBeaconMockManager().GetConfig().SecondsPerSlot) * time.Second
+	err = testMgr.AdvanceSlots(uint(bindings.OracleDaoManager.Settings.Minipool.ScrubPeriod.Formatted()/secondsPerSlot), false)
+	require.NoError(t, err)
+	err = testMgr.CommitBlock()
+	require.NoError(t, err)
+
+	// Make the tasks
+	logger := sp.GetTasksLogger()
+	ctx := logger.CreateContextWithLogger(sp.GetBaseContext())
+	snapshotTask := cstasks.NewNetworkSnapshotTask(ctx, sp, logger)
+	stakeTask := cstasks.NewStakeMinipoolsTask(ctx, sp, logger)
+	walletResponse, err := hd.Wallet.Status()
+	require.NoError(t, err)
+	walletStatus := walletResponse.Data.WalletStatus
+
+	// Take a network snapshot on a block that's about to be reorged out
+	reorgSnapshotName, err := testMgr.CreateCustomSnapshot(hdtesting.Service_EthClients)
+	require.NoError(t, err)
+	err = testMgr.CommitBlock()
+	require.NoError(t, err)
+	snapshot, err := snapshotTask.Run(&walletStatus)
+	require.NoError(t, err)
+	reorgedBlock := snapshot.ExecutionBlockHeader
+
+	// Replace that block with a different one at the same height
+	err = testMgr.RevertToCustomSnapshot(reorgSnapshotName)
+	require.NoError(t, err)
+	err = testMgr.AdvanceSlots(1, false)
+	require.NoError(t, err)
+	err = testMgr.CommitBlock()
+	require.NoError(t, err)
+	header, err := sp.GetEthClient().HeaderByNumber(context.Background(), reorgedBlock.Number)
+	require.NoError(t, err)
+	require.NotEqual(t, reorgedBlock.Hash(), header.Hash())
+	t.Logf("Block %s was reorged from %s to %s", reorgedBlock.Number.String(), reorgedBlock.Hash().Hex(), header.Hash().Hex())
+
+	// The task should refuse to stake from the old snapshot
+	err = stakeTask.Run(snapshot)
+	require.ErrorIs(t, err, cstasks.ErrSnapshotReorged)
+	_, isPending := sp.GetTransactionTracker().GetPendingTransactionForMinipool(mp2Common.Address)
+	require.False(t, isPending)
+	err = qMgr.Query(nil, nil, mp2Common.Status)
+	require.NoError(t, err)
+	require.Equal(t, rptypes.MinipoolStatus_Prelaunch, mp2Common.Status.Formatted())
+
+	// It should stake with a new snapshot
+	snapshot, err = snapshotTask.Run(&walletStatus)
+	require.NoError(t, err)
+	err = stakeTask.Run(snapshot)
+	require.NoError(t, err)
+	err = testMgr.CommitBlock()
+	require.NoError(t, err)
+	err = qMgr.Query(nil, nil, mp2Common.Status)
+	require.NoError(t, err)
+	require.Equal(t, rptypes.MinipoolStatus_Staking, mp2Common.Status.Formatted())
+	t.Logf("Minipool %s was staked after the reorg", mp2Common.Address.Hex())
+}
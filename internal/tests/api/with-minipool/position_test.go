@@ -0,0 +1,98 @@
+package with_minipool
+
+import (
+	"encoding/csv"
+	"math/big"
+	"strings"
+	"testing"
+
+	hdtesting "github.com/nodeset-org/hyperdrive-daemon/testing"
+	batch "github.com/rocket-pool/batch-query"
+	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/rocket-pool/rocketpool-go/v2/rewards"
+	"github.com/stretchr/testify/require"
+)
+
+// Make sure the node's position matches its minipool on-chain and the CSV export lines up with it
+func TestNodePosition(t *testing.T) {
+	// Take a snapshot, revert at the end
+	testMgr := harness.TestManager
+	snapshotName, err := testMgr.CreateCustomSnapshot(hdtesting.Service_EthClients | hdtesting.Service_Filesystem | hdtesting.Service_NodeSet)
+	if err != nil {
+		fail("Error creating custom snapshot: %v", err)
+	}
+	defer nodeset_cleanup(snapshotName)
+
+	// Get some services
+	sp := harness.MainNode.GetServiceProvider()
+	cs := harness.MainNode.GetApiClient()
+	csMgr := sp.GetConstellationManager()
+	require.NoError(t, csMgr.LoadContracts())
+	rewardsPool, err := rewards.NewRewardsPool(sp.GetRocketPoolManager().RocketPool())
+	require.NoError(t, err)
+
+	// Get the expected values from the chain
+	mpCommon := mp.Common()
+	var lockup *big.Int
+	err = sp.GetQueryManager().Query(func(mc *batch.MultiCaller) error {
+		csMgr.Contracts().SuperNodeAccount.LockedEth(mc, &lockup, mpCommon.Address)
+		eth.AddQueryablesToMulticall(mc,
+			mpCommon.NodeDepositBalance,
+			rewardsPool.RewardIndex,
+		)
+		return nil
+	}, nil)
+	require.NoError(t, err)
+
+	// Get the position
+	response, err := cs.Node.ExportPosition(1)
+	require.NoError(t, err)
+	data := response.Data
+	require.Equal(t, harness.MainNodeAddress, data.NodeAddress)
+	require.Equal(t, csMgr.Contracts().SuperNodeAccount.Address, data.SuperNodeAddress)
+	require.Equal(t, uint64(1), data.AprDays)
+
+	// The node has the one minipool from the setup
+	require.Len(t, data.Minipools, 1)
+	position := data.Minipools[0]
+	require.Equal(t, mpCommon.Address, position.Address)
+	require.Equal(t, 0, lockup.Cmp(position.Lockup))
+	require.Equal(t, 0, mpCommon.NodeDepositBalance.Get().Cmp(position.BondShare))
+	require.Equal(t, 0, lockup.Cmp(data.TotalLockup))
+	require.Equal(t, 0, position.BondShare.Cmp(data.TotalBondShare))
+	require.Equal(t, 0, position.AccruedOperatorFees.Cmp(data.TotalAccruedOperatorFees))
+	t.Logf("Minipool %s has a lockup of %s wei and a bond share of %s wei", position.Address.Hex(), position.Lockup.String(), position.BondShare.String())
+
+	// Every interval since the deployment is reported, and nothing has been claimed on the test network
+	intervalCount := rewardsPool.RewardIndex.Formatted()
+	require.LessOrEqual(t, data.FirstRewardInterval, intervalCount)
+	require.Len(t, data.RewardIntervals, int(intervalCount-data.FirstRewardInterval))
+	for i, interval := range data.RewardIntervals {
+		require.Equal(t, data.FirstRewardInterval+uint64(i), interval.Index)
+	}
+	require.Equal(t, uint64(len(data.RewardIntervals)), data.ClaimedRewardIntervals+data.UnclaimedRewardIntervals)
+	require.Equal(t, uint64(0), data.ClaimedRewardIntervals)
+	require.Equal(t, 0, data.TotalClaimedEth.Sign())
+	require.Equal(t, 0, data.TotalClaimedRpl.Sign())
+
+	// One header row, one row per minipool, and a totals row
+	records, err := csv.NewReader(strings.NewReader(data.Csv)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	require.Equal(t, "Minipool", records[0][0])
+	require.Equal(t, position.Address.Hex(), records[1][0])
+	require.Equal(t, string(position.Status), records[1][2])
+	require.Equal(t, 0, parseCsvEth(t, records[1][4]).Cmp(position.Lockup))
+	require.Equal(t, 0, parseCsvEth(t, records[1][5]).Cmp(position.BondShare))
+	require.Equal(t, "Total", records[2][0])
+	require.Equal(t, 0, parseCsvEth(t, records[2][4]).Cmp(data.TotalLockup))
+}
+
+// Parse an exact decimal ETH amount from the CSV export into wei
+func parseCsvEth(t *testing.T, value string) *big.Int {
+	amount, ok := new(big.Rat).SetString(value)
+	require.True(t, ok, "invalid ETH amount [%s]", value)
+	amount.Mul(amount, new(big.Rat).SetInt(eth.EthToWei(1)))
+	require.True(t, amount.IsInt(), "ETH amount [%s] has more than 18 decimals", value)
+	return amount.Num()
+}
@@ -0,0 +1,126 @@
+package with_minipool
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	csconfig "github.com/nodeset-org/hyperdrive-constellation/shared/config"
+	cstesting "github.com/nodeset-org/hyperdrive-constellation/testing"
+	hdtesting "github.com/nodeset-org/hyperdrive-daemon/testing"
+	"github.com/stretchr/testify/require"
+)
+
+// Make sure reloading unchanged settings does nothing, and reloading changed ones switches everything over to them
+func TestReloadSettings(t *testing.T) {
+	// Take a snapshot, revert at the end
+	testMgr := harness.TestManager
+	snapshotName, err := testMgr.CreateCustomSnapshot(hdtesting.Service_EthClients | hdtesting.Service_Filesystem | hdtesting.Service_NodeSet)
+	if err != nil {
+		fail("Error creating custom snapshot: %v", err)
+	}
+	defer nodeset_cleanup(snapshotName)
+	sp := harness.MainNode.GetServiceProvider()
+	cs := harness.MainNode.GetApiClient()
+	original := loadTestSettings(t)
+	defer restoreTestSettings(t, original)
+
+	// Nothing changed yet
+	response, err := cs.Service.ReloadSettings()
+	require.NoError(t, err)
+	require.False(t, response.Data.Changed)
+
+	// Move the deployment block up
+	header, err := sp.GetEthClient().HeaderByNumber(context.Background(), nil)
+	require.NoError(t, err)
+	deploymentBlock := header.Number.Uint64()
+	changedResources := *original.ConstellationResources
+	changedResources.DeploymentBlock = deploymentBlock
+	changed := *original
+	changed.ConstellationResources = &changedResources
+	err = cstesting.SaveTestSettingsFile(sp.GetSettingsReloader().GetFolder(), &changed)
+	require.NoError(t, err)
+
+	// Everything should be using the new settings
+	response, err = cs.Service.ReloadSettings()
+	require.NoError(t, err)
+	require.True(t, response.Data.Changed)
+	require.Equal(t, deploymentBlock, sp.GetResources().DeploymentBlock)
+	require.Equal(t, deploymentBlock, sp.GetEventIndexer().GetStatus().StartBlock)
+	require.False(t, sp.GetEventIndexer().GetStatus().IsIndexed)
+	require.Equal(t, *original.ConstellationResources.Directory, sp.GetConstellationManager().Contracts().Directory.Address)
+	require.Equal(t, original.SmartNodeResources.StorageAddress, sp.GetSmartNodeServiceProvider().GetResources().StorageAddress)
+	settingsResponse, err := cs.Service.GetNetworkSettings()
+	require.NoError(t, err)
+	require.Equal(t, deploymentBlock, settingsResponse.Data.Settings.ConstellationResources.DeploymentBlock)
+
+	// The routes should still work with the new managers
+	statusResponse, err := cs.Minipool.Status()
+	require.NoError(t, err)
+	require.Len(t, statusResponse.Data.Minipools, 1)
+	t.Logf("Reloaded the settings with the deployment block moved to %d", deploymentBlock)
+}
+
+// Make sure bad settings are rejected and the daemon keeps running with its current ones
+func TestReloadSettingsRejected(t *testing.T) {
+	// Take a snapshot, revert at the end
+	testMgr := harness.TestManager
+	snapshotName, err := testMgr.CreateCustomSnapshot(hdtesting.Service_EthClients | hdtesting.Service_Filesystem | hdtesting.Service_NodeSet)
+	if err != nil {
+		fail("Error creating custom snapshot: %v", err)
+	}
+	defer nodeset_cleanup(snapshotName)
+	sp := harness.MainNode.GetServiceProvider()
+	cs := harness.MainNode.GetApiClient()
+	folder := sp.GetSettingsReloader().GetFolder()
+	original := loadTestSettings(t)
+	defer restoreTestSettings(t, original)
+	originalResources := sp.GetResources()
+
+	// A file that can't be parsed
+	err = os.WriteFile(filepath.Join(folder, cstesting.SettingsFilename), []byte("key: ["), 0644)
+	require.NoError(t, err)
+	_, err = cs.Service.ReloadSettings()
+	require.Error(t, err)
+	t.Logf("Unparseable settings were rejected: %v", err)
+
+	// A Directory that isn't a contract
+	badResources := *original.ConstellationResources
+	badResources.Directory = &harness.MainNodeAddress
+	bad := *original
+	bad.ConstellationResources = &badResources
+	err = cstesting.SaveTestSettingsFile(folder, &bad)
+	require.NoError(t, err)
+	_, err = cs.Service.ReloadSettings()
+	require.Error(t, err)
+	t.Logf("Settings with a bad Directory were rejected: %v", err)
+
+	// Nothing should have changed
+	require.Same(t, originalResources, sp.GetResources())
+	require.Equal(t, *original.ConstellationResources.Directory, sp.GetConstellationManager().Contracts().Directory.Address)
+	require.NotEqual(t, common.Address{}, sp.GetConstellationManager().Contracts().SuperNodeAccount.Address)
+	statusResponse, err := cs.Minipool.Status()
+	require.NoError(t, err)
+	require.Len(t, statusResponse.Data.Minipools, 1)
+}
+
+// Load the network settings the main node was started with
+func loadTestSettings(t *testing.T) *csconfig.ConstellationSettings {
+	folder := harness.MainNode.GetServiceProvider().GetSettingsReloader().GetFolder()
+	require.NotEmpty(t, folder)
+	settingsList, err := csconfig.LoadSettingsFiles(folder)
+	require.NoError(t, err)
+	require.Len(t, settingsList, 1)
+	return settingsList[0]
+}
+
+// Put the original network settings back and reload them
+func restoreTestSettings(t *testing.T, original *csconfig.ConstellationSettings) {
+	folder := harness.MainNode.GetServiceProvider().GetSettingsReloader().GetFolder()
+	err := cstesting.SaveTestSettingsFile(folder, original)
+	require.NoError(t, err)
+	_, err = harness.MainNode.GetApiClient().Service.ReloadSettings()
+	require.NoError(t, err)
+}
@@ -0,0 +1,116 @@
+package with_minipool
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	cstasks "github.com/nodeset-org/hyperdrive-constellation/tasks"
+	hdtesting "github.com/nodeset-org/hyperdrive-daemon/testing"
+	"github.com/nodeset-org/nodeset-client-go/utils"
+	batch "github.com/rocket-pool/batch-query"
+	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/stretchr/testify/require"
+)
+
+// Make sure a yield update signed by the oracle admin is accepted by the reporter and the oracle contract, which checks
+// that the daemon packs the signed message the same way the contract does
+func TestReportYield(t *testing.T) {
+	// Take a snapshot, revert at the end
+	testMgr := harness.TestManager
+	snapshotName, err := testMgr.CreateCustomSnapshot(hdtesting.Service_EthClients | hdtesting.Service_Filesystem | hdtesting.Service_NodeSet)
+	if err != nil {
+		fail("Error creating custom snapshot: %v", err)
+	}
+	defer nodeset_cleanup(snapshotName)
+
+	// Get some services
+	sp := harness.MainNode.GetServiceProvider()
+	hd := harness.MainNode.GetHyperdriveNode().GetApiClient()
+	csMgr := sp.GetConstellationManager()
+	ec := sp.GetEthClient()
+	oracle := csMgr.Contracts().PoAConstellationOracle
+
+	// Sign an update with the deployer's key, which has the ADMIN_ORACLE_ROLE
+	adminKey, err := harness.KeyGenerator.GetEthPrivateKey(0)
+	require.NoError(t, err)
+	var oracleError *big.Int
+	err = sp.GetQueryManager().Query(func(mc *batch.MultiCaller) error {
+		csMgr.Contracts().OperatorDistributor.GetOracleError(mc, &oracleError)
+		return nil
+	}, nil)
+	require.NoError(t, err)
+	header, err := ec.HeaderByNumber(context.Background(), nil)
+	require.NoError(t, err)
+	chainID, err := ec.ChainID(context.Background())
+	require.NoError(t, err)
+	update := cscommon.YieldUpdate{
+		NewTotalYieldAccrued: eth.EthToWei(0.001),
+		ExpectedOracleError:  oracleError,
+		Timestamp:            int64(header.Time),
+	}
+	message := crypto.Keccak256(
+		common.LeftPadBytes(update.NewTotalYieldAccrued.Bytes(), 32),
+		common.LeftPadBytes(update.ExpectedOracleError.Bytes(), 32),
+		common.LeftPadBytes(big.NewInt(update.Timestamp).Bytes(), 32),
+		oracle.Address.Bytes(),
+		common.LeftPadBytes(chainID.Bytes(), 32),
+	)
+	update.Signature, err = utils.CreateSignature(message, adminKey)
+	require.NoError(t, err)
+
+	// Serve it and point the reporter at it
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(update)
+	}))
+	defer server.Close()
+	csCfg := sp.GetConfig()
+	oldEnabled := csCfg.OracleReporterEnabled.Value
+	oldEndpoint := csCfg.OracleReporterEndpoint.Value
+	csCfg.OracleReporterEnabled.Value = true
+	csCfg.OracleReporterEndpoint.Value = server.URL
+	defer func() {
+		csCfg.OracleReporterEnabled.Value = oldEnabled
+		csCfg.OracleReporterEndpoint.Value = oldEndpoint
+	}()
+
+	// Run the reporter
+	logger := sp.GetTasksLogger()
+	ctx := logger.CreateContextWithLogger(sp.GetBaseContext())
+	reportTask := cstasks.NewReportYieldTask(ctx, sp, logger)
+	walletResponse, err := hd.Wallet.Status()
+	require.NoError(t, err)
+	walletStatus := walletResponse.Data.WalletStatus
+	err = reportTask.Run(&walletStatus)
+	require.NoError(t, err)
+	err = testMgr.CommitBlock()
+	require.NoError(t, err)
+
+	// The oracle should have the new yield
+	var totalYield *big.Int
+	var lastUpdated *big.Int
+	err = sp.GetQueryManager().Query(func(mc *batch.MultiCaller) error {
+		oracle.GetTotalYieldAccrued(mc, &totalYield)
+		oracle.GetLastUpdatedTotalYieldAccrued(mc, &lastUpdated)
+		return nil
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, update.NewTotalYieldAccrued, totalYield)
+	require.Equal(t, update.Timestamp, lastUpdated.Int64())
+	t.Logf("The yield update was relayed, total yield accrued is now %.6f ETH", eth.WeiToEth(totalYield))
+
+	// Once it's mined, running it again shouldn't submit the same update twice
+	completed, err := sp.GetTransactionTracker().Refresh(ctx)
+	require.NoError(t, err)
+	require.Len(t, completed, 1)
+	err = reportTask.Run(&walletStatus)
+	require.NoError(t, err)
+	require.Empty(t, sp.GetTransactionTracker().GetPendingTransactions())
+}
@@ -133,8 +133,8 @@ func TestMain(m *testing.M) {
 	deployment := nsDB.Constellation.AddDeployment(
 		res.DeploymentName,
 		new(big.Int).SetUint64(uint64(res.ChainID)),
-		csMgr.Whitelist.Address,
-		csMgr.SuperNodeAccount.Address,
+		csMgr.Contracts().Whitelist.Address,
+		csMgr.Contracts().SuperNodeAccount.Address,
 	)
 	deployment.SetAdminPrivateKey(deployerKey)
 
@@ -67,7 +67,7 @@ func Test3_ComplexRoundTrip(t *testing.T) {
 
 	// Deposit RPL to the RPL vault
 	rplAmount := eth.EthToWei(4000)
-	cstestutils.DepositToRplVault(t, testMgr, csMgr.RplVault, bindings.Rpl, rplAmount, deployerOpts)
+	cstestutils.DepositToRplVault(t, testMgr, csMgr.Contracts().RplVault, bindings.Rpl, rplAmount, deployerOpts)
 	printTickInfo(t, sp)
 
 	// Deposit WETH to the WETH vault
@@ -75,10 +75,10 @@ func Test3_ComplexRoundTrip(t *testing.T) {
 	wethAmount := eth.EthToWei(100)
 	var xrEthBalance *big.Int
 	var mintFee *big.Int
-	cstestutils.DepositToWethVault(t, testMgr, csMgr.WethVault, bindings.Weth, wethAmount, deployerOpts)
+	cstestutils.DepositToWethVault(t, testMgr, csMgr.Contracts().WethVault, bindings.Weth, wethAmount, deployerOpts)
 	err = qMgr.Query(func(mc *batch.MultiCaller) error {
-		csMgr.WethVault.BalanceOf(mc, &xrEthBalance, deployerOpts.From)
-		csMgr.WethVault.GetMintFee(mc, &mintFee)
+		csMgr.Contracts().WethVault.BalanceOf(mc, &xrEthBalance, deployerOpts.From)
+		csMgr.Contracts().WethVault.GetMintFee(mc, &mintFee)
 		return nil
 	}, nil)
 	require.NoError(t, err)
@@ -129,7 +129,7 @@ func Test3_ComplexRoundTrip(t *testing.T) {
 	// Get some state
 	var nextMinipoolAddress common.Address
 	err = qMgr.Query(func(mc *batch.MultiCaller) error {
-		csMgr.OperatorDistributor.GetNextMinipool(mc, &nextMinipoolAddress)
+		csMgr.Contracts().OperatorDistributor.GetNextMinipool(mc, &nextMinipoolAddress)
 		return nil
 	}, nil,
 		bindings.OracleDaoManager.Settings.Minipool.ScrubPeriod,
@@ -171,15 +171,15 @@ func Test3_ComplexRoundTrip(t *testing.T) {
 	// Update the oracle report
 	var expectedOracleError *big.Int
 	err = qMgr.Query(func(mc *batch.MultiCaller) error {
-		csMgr.OperatorDistributor.GetOracleError(mc, &expectedOracleError)
+		csMgr.Contracts().OperatorDistributor.GetOracleError(mc, &expectedOracleError)
 		return nil
 	}, nil)
 	require.NoError(t, err)
 	chainID := new(big.Int).SetUint64(testMgr.GetBeaconMockManager().GetConfig().ChainID)
 	newTime := time.Now().Add(timeToAdvance)
-	sig, err := createXrEthOracleSignature(totalYieldAccrued, expectedOracleError, newTime, csMgr.PoAConstellationOracle.Address, chainID, deployerKey)
+	sig, err := createXrEthOracleSignature(totalYieldAccrued, expectedOracleError, newTime, csMgr.Contracts().PoAConstellationOracle.Address, chainID, deployerKey)
 	require.NoError(t, err)
-	txInfo, err := csMgr.PoAConstellationOracle.SetTotalYieldAccrued(totalYieldAccrued, oracleError, sig, newTime, deployerOpts)
+	txInfo, err := csMgr.Contracts().PoAConstellationOracle.SetTotalYieldAccrued(totalYieldAccrued, oracleError, sig, newTime, deployerOpts)
 	require.NoError(t, err)
 	testMgr.MineTx(t, txInfo, deployerOpts, "Updated the xrETH Oracle")
 	printTickInfo(t, sp)
@@ -188,20 +188,20 @@ func Test3_ComplexRoundTrip(t *testing.T) {
 	numerator := new(big.Int).Add(xrEthBalance, totalYieldAccrued)
 	numerator.Mul(numerator, oneEth)
 	expectedRatio := new(big.Int).Div(numerator, xrEthBalance)
-	xrEthPriceAccordingToVault := getTokenPrice(t, qMgr, csMgr.WethVault)
+	xrEthPriceAccordingToVault := getTokenPrice(t, qMgr, csMgr.Contracts().WethVault)
 	requireApproxEqual(t, expectedRatio, xrEthPriceAccordingToVault)
 	t.Logf("The new ETH:xrETH price according to the token is %.10f (%s wei)", eth.WeiToEth(xrEthPriceAccordingToVault), xrEthPriceAccordingToVault.String())
 
 	// Redeem 5 xrETH
 	xrEthRedeemAmount := eth.EthToWei(5)
-	wethReturned := redeemToken(t, qMgr, txMgr, csMgr.WethVault, xrEthRedeemAmount, false, deployerOpts)
+	wethReturned := redeemToken(t, qMgr, txMgr, csMgr.Contracts().WethVault, xrEthRedeemAmount, false, deployerOpts)
 	expectedAmount = new(big.Int).Mul(xrEthRedeemAmount, xrEthPriceAccordingToVault)
 	expectedAmount.Div(expectedAmount, oneEth)
 	requireApproxEqual(t, expectedAmount, wethReturned)
 	t.Logf("Redeemed %.6f xrETH (%s wei) for %.6f WETH (%s wei)", eth.WeiToEth(xrEthRedeemAmount), xrEthRedeemAmount.String(), eth.WeiToEth(wethReturned), wethReturned.String())
 	expectedMpIndex++
 	err = qMgr.Query(func(mc *batch.MultiCaller) error {
-		csMgr.OperatorDistributor.GetNextMinipool(mc, &nextMinipoolAddress)
+		csMgr.Contracts().OperatorDistributor.GetNextMinipool(mc, &nextMinipoolAddress)
 		return nil
 	}, nil)
 	require.NoError(t, err)
@@ -212,14 +212,14 @@ func Test3_ComplexRoundTrip(t *testing.T) {
 
 	// Redeem 5 xRPL
 	xRplRedeemAmount := eth.EthToWei(5)
-	rplReturned := redeemToken(t, qMgr, txMgr, csMgr.RplVault, xRplRedeemAmount, false, deployerOpts)
+	rplReturned := redeemToken(t, qMgr, txMgr, csMgr.Contracts().RplVault, xRplRedeemAmount, false, deployerOpts)
 	expectedAmount = xRplRedeemAmount
 	require.Equal(t, expectedAmount, rplReturned)
 	t.Logf("Redeemed %.6f xRPL (%s wei) for %.6f RPL (%s wei)", eth.WeiToEth(xRplRedeemAmount), xRplRedeemAmount.String(), eth.WeiToEth(rplReturned), rplReturned.String())
 	expectedMpIndex++
-	//nextMinipoolAddress, err = csMgr.OperatorDistributor.GetNextMinipoolDebug()
+	//nextMinipoolAddress, err = csMgr.Contracts().OperatorDistributor.GetNextMinipoolDebug()
 	err = qMgr.Query(func(mc *batch.MultiCaller) error {
-		csMgr.OperatorDistributor.GetNextMinipool(mc, &nextMinipoolAddress)
+		csMgr.Contracts().OperatorDistributor.GetNextMinipool(mc, &nextMinipoolAddress)
 		return nil
 	}, nil)
 	require.NoError(t, err)
@@ -248,9 +248,9 @@ func Test3_ComplexRoundTrip(t *testing.T) {
 		var mpCount *big.Int
 		var nextMpIndex *big.Int
 		err := qMgr.Query(func(mc *batch.MultiCaller) error {
-			csMgr.OperatorDistributor.GetNextMinipool(mc, &nextMinipoolAddress)
-			csMgr.SuperNodeAccount.GetMinipoolCount(mc, &mpCount)
-			csMgr.OperatorDistributor.GetNextMinipoolIndex(mc, &nextMpIndex)
+			csMgr.Contracts().OperatorDistributor.GetNextMinipool(mc, &nextMinipoolAddress)
+			csMgr.Contracts().SuperNodeAccount.GetMinipoolCount(mc, &mpCount)
+			csMgr.Contracts().OperatorDistributor.GetNextMinipoolIndex(mc, &nextMpIndex)
 			return nil
 		}, nil)
 		require.NoError(t, err)
@@ -258,7 +258,7 @@ func Test3_ComplexRoundTrip(t *testing.T) {
 		t.Logf("The next minipool to tick is %s as expected (index %d)", nextMinipoolAddress.Hex(), expectedMpIndex)
 		t.Logf("The minipool count is %d, next index = %d", mpCount.Int64(), nextMpIndex.Int64())
 
-		txInfo, err := csMgr.OperatorDistributor.ProcessNextMinipool(deployerOpts)
+		txInfo, err := csMgr.Contracts().OperatorDistributor.ProcessNextMinipool(deployerOpts)
 		require.NoError(t, err)
 		testMgr.MineTx(t, txInfo, deployerOpts, fmt.Sprintf("Processed the next minipool (tick %d)", i+1))
 
@@ -271,21 +271,21 @@ func Test3_ComplexRoundTrip(t *testing.T) {
 
 	// Update the xrETH Oracle again
 	err = qMgr.Query(func(mc *batch.MultiCaller) error {
-		csMgr.OperatorDistributor.GetOracleError(mc, &expectedOracleError)
+		csMgr.Contracts().OperatorDistributor.GetOracleError(mc, &expectedOracleError)
 		return nil
 	}, nil)
 	require.NoError(t, err)
 	totalYieldAccrued, oracleError = calculateXrEthOracleTotalYieldAccrued(t, sp, bindings)
 	newTime = newTime.Add(time.Hour)
 	t.Logf("The new total yield accrued to report is %.10f (%s wei)", eth.WeiToEth(totalYieldAccrued), totalYieldAccrued.String())
-	sig, err = createXrEthOracleSignature(totalYieldAccrued, expectedOracleError, newTime, csMgr.PoAConstellationOracle.Address, chainID, deployerKey)
+	sig, err = createXrEthOracleSignature(totalYieldAccrued, expectedOracleError, newTime, csMgr.Contracts().PoAConstellationOracle.Address, chainID, deployerKey)
 	require.NoError(t, err)
-	txInfo, err = csMgr.PoAConstellationOracle.SetTotalYieldAccrued(totalYieldAccrued, oracleError, sig, newTime, deployerOpts)
+	txInfo, err = csMgr.Contracts().PoAConstellationOracle.SetTotalYieldAccrued(totalYieldAccrued, oracleError, sig, newTime, deployerOpts)
 	require.NoError(t, err)
 	testMgr.MineTx(t, txInfo, deployerOpts, "Updated the xrETH Oracle")
 
 	// Verify the new ETH:xrETH price
-	xrEthPriceAccordingToVault = getTokenPrice(t, qMgr, csMgr.WethVault)
+	xrEthPriceAccordingToVault = getTokenPrice(t, qMgr, csMgr.Contracts().WethVault)
 	requireApproxEqual(t, expectedRatio, xrEthPriceAccordingToVault)
 	t.Logf("The new ETH:xrETH price according to the token is %.10f (%s wei)", eth.WeiToEth(xrEthPriceAccordingToVault), xrEthPriceAccordingToVault.String())
 
@@ -309,7 +309,7 @@ func Test3_ComplexRoundTrip(t *testing.T) {
 	preBalance, err := ec.BalanceAt(context.Background(), treasuryRecipient, nil)
 	require.NoError(t, err)
 
-	txInfo, err = csMgr.Treasury.ClaimEth(treasuryRecipient, adminOpts)
+	txInfo, err = csMgr.Contracts().Treasury.ClaimEth(treasuryRecipient, adminOpts)
 	require.NoError(t, err)
 	testMgr.MineTx(t, txInfo, adminOpts, "Treasury claimed ETH rewards")
 
@@ -351,15 +351,15 @@ func Test4_SimpleNOConcurrency(t *testing.T) {
 	wethAmount, rplAmount := getDepositAmounts(t, bindings, testMgr.GetNode().GetServiceProvider(), 1)
 
 	// Deposit WETH to the WETH vault
-	cstestutils.DepositToWethVault(t, testMgr, csMgr.WethVault, bindings.Weth, wethAmount, deployerOpts)
+	cstestutils.DepositToWethVault(t, testMgr, csMgr.Contracts().WethVault, bindings.Weth, wethAmount, deployerOpts)
 
 	// Deposit RPL to the RPL vault
 	initialAmount := eth.EthToWei(300)
-	cstestutils.DepositToRplVault(t, testMgr, csMgr.RplVault, bindings.Rpl, initialAmount, deployerOpts)
+	cstestutils.DepositToRplVault(t, testMgr, csMgr.Contracts().RplVault, bindings.Rpl, initialAmount, deployerOpts)
 
 	// Deposit RPL to the RPL vault
 	remainder := new(big.Int).Sub(rplAmount, initialAmount)
-	cstestutils.DepositToRplVault(t, testMgr, csMgr.RplVault, bindings.Rpl, remainder, deployerOpts)
+	cstestutils.DepositToRplVault(t, testMgr, csMgr.Contracts().RplVault, bindings.Rpl, remainder, deployerOpts)
 
 	// Build the minipool creation TXs
 	_, hashes := cstestutils.BuildAndSubmitCreateMinipoolTxs(t, deployment, nodes, nodeAddresses, 1, nil, bindings.RpSuperNode)
@@ -422,10 +422,10 @@ func Test5_ComplexNOConcurrency(t *testing.T) {
 	wethAmount, rplAmount := getDepositAmounts(t, bindings, sp, 10) // Enough for 10 minipools but no more
 
 	// Deposit WETH to the WETH vault
-	cstestutils.DepositToWethVault(t, testMgr, csMgr.WethVault, bindings.Weth, wethAmount, deployerOpts)
+	cstestutils.DepositToWethVault(t, testMgr, csMgr.Contracts().WethVault, bindings.Weth, wethAmount, deployerOpts)
 
 	// Deposit RPL to the RPL vault
-	cstestutils.DepositToRplVault(t, testMgr, csMgr.RplVault, bindings.Rpl, rplAmount, deployerOpts)
+	cstestutils.DepositToRplVault(t, testMgr, csMgr.Contracts().RplVault, bindings.Rpl, rplAmount, deployerOpts)
 
 	// Build the wave 1 minipool creation TXs
 	wave1Nodes := nodes[:5]
@@ -514,7 +514,7 @@ func Test13_OrderlyStressTest(t *testing.T) {
 	// Get the current RPL price
 	var rplPerEth *big.Int
 	err = qMgr.Query(func(mc *batch.MultiCaller) error {
-		csMgr.PriceFetcher.GetRplPrice(mc, &rplPerEth)
+		csMgr.Contracts().PriceFetcher.GetRplPrice(mc, &rplPerEth)
 		return nil
 	}, nil)
 	require.NoError(t, err)
@@ -524,10 +524,10 @@ func Test13_OrderlyStressTest(t *testing.T) {
 	ethDepositAmount := eth.EthToWei(1000)
 	var xrEthBalance *big.Int
 	var mintFee *big.Int
-	cstestutils.DepositToWethVault(t, testMgr, csMgr.WethVault, bindings.Weth, ethDepositAmount, deployerOpts)
+	cstestutils.DepositToWethVault(t, testMgr, csMgr.Contracts().WethVault, bindings.Weth, ethDepositAmount, deployerOpts)
 	err = qMgr.Query(func(mc *batch.MultiCaller) error {
-		csMgr.WethVault.BalanceOf(mc, &xrEthBalance, deployerOpts.From)
-		csMgr.WethVault.GetMintFee(mc, &mintFee)
+		csMgr.Contracts().WethVault.BalanceOf(mc, &xrEthBalance, deployerOpts.From)
+		csMgr.Contracts().WethVault.GetMintFee(mc, &mintFee)
 		return nil
 	}, nil)
 	require.NoError(t, err)
@@ -544,7 +544,7 @@ func Test13_OrderlyStressTest(t *testing.T) {
 	rplDepositAmount.Mul(rplDepositAmount, twentyPercent)
 	rplDepositAmount.Div(rplDepositAmount, oneEth)
 	rplDepositAmount.Div(rplDepositAmount, oneEth)
-	cstestutils.DepositToRplVault(t, testMgr, csMgr.RplVault, bindings.Rpl, rplDepositAmount, deployerOpts)
+	cstestutils.DepositToRplVault(t, testMgr, csMgr.Contracts().RplVault, bindings.Rpl, rplDepositAmount, deployerOpts)
 
 	// Create some subnodes
 	nodes, nodeAddresses, err := createNodesForTest(t, 2, eth.EthToWei(50))
@@ -552,7 +552,7 @@ func Test13_OrderlyStressTest(t *testing.T) {
 
 	// Set max minipools per node
 	wave1MinipoolsPerNode := 4
-	txInfo, err := csMgr.SuperNodeAccount.SetMaxValidators(big.NewInt(int64(wave1MinipoolsPerNode)), deployerOpts)
+	txInfo, err := csMgr.Contracts().SuperNodeAccount.SetMaxValidators(big.NewInt(int64(wave1MinipoolsPerNode)), deployerOpts)
 	require.NoError(t, err)
 	testMgr.MineTx(t, txInfo, deployerOpts, fmt.Sprintf("Set the max validators to %d", wave1MinipoolsPerNode))
 
@@ -601,14 +601,14 @@ func Test13_OrderlyStressTest(t *testing.T) {
 
 	// Redeem 10 xrETH
 	xrEthRedeemAmount := eth.EthToWei(10)
-	wethReturned := redeemToken(t, qMgr, txMgr, csMgr.WethVault, xrEthRedeemAmount, false, deployerOpts)
+	wethReturned := redeemToken(t, qMgr, txMgr, csMgr.Contracts().WethVault, xrEthRedeemAmount, false, deployerOpts)
 	require.Equal(t, xrEthRedeemAmount, wethReturned)
 	t.Logf("Redeemed %.6f xrETH (%s wei) for %.6f WETH (%s wei)", eth.WeiToEth(xrEthRedeemAmount), xrEthRedeemAmount.String(), eth.WeiToEth(wethReturned), wethReturned.String())
 	printTickInfo(t, sp)
 
 	// Redeem 100 xrRPL
 	xRplRedeemAmount := eth.EthToWei(100)
-	rplReturned := redeemToken(t, qMgr, txMgr, csMgr.RplVault, xRplRedeemAmount, false, deployerOpts)
+	rplReturned := redeemToken(t, qMgr, txMgr, csMgr.Contracts().RplVault, xRplRedeemAmount, false, deployerOpts)
 	require.Equal(t, xRplRedeemAmount, rplReturned)
 	t.Logf("Redeemed %.6f xRPL (%s wei) for %.6f RPL (%s wei)", eth.WeiToEth(xRplRedeemAmount), xRplRedeemAmount.String(), eth.WeiToEth(rplReturned), rplReturned.String())
 	printTickInfo(t, sp)
@@ -667,14 +667,14 @@ func Test13_OrderlyStressTest(t *testing.T) {
 	// Update the oracle report
 	var expectedOracleError *big.Int
 	err = qMgr.Query(func(mc *batch.MultiCaller) error {
-		csMgr.OperatorDistributor.GetOracleError(mc, &expectedOracleError)
+		csMgr.Contracts().OperatorDistributor.GetOracleError(mc, &expectedOracleError)
 		return nil
 	}, nil)
 	require.NoError(t, err)
 	chainID := new(big.Int).SetUint64(testMgr.GetBeaconMockManager().GetConfig().ChainID)
-	sig, err := createXrEthOracleSignature(totalYieldAccrued, expectedOracleError, sigTime, csMgr.PoAConstellationOracle.Address, chainID, deployerKey)
+	sig, err := createXrEthOracleSignature(totalYieldAccrued, expectedOracleError, sigTime, csMgr.Contracts().PoAConstellationOracle.Address, chainID, deployerKey)
 	require.NoError(t, err)
-	txInfo, err = csMgr.PoAConstellationOracle.SetTotalYieldAccrued(totalYieldAccrued, oracleError, sig, sigTime, deployerOpts)
+	txInfo, err = csMgr.Contracts().PoAConstellationOracle.SetTotalYieldAccrued(totalYieldAccrued, oracleError, sig, sigTime, deployerOpts)
 	require.NoError(t, err)
 	testMgr.MineTx(t, txInfo, adminOpts, "Updated the xrETH Oracle")
 	printTickInfo(t, sp)
@@ -684,7 +684,7 @@ func Test13_OrderlyStressTest(t *testing.T) {
 	numerator := new(big.Int).Add(originalAmount, totalYieldAccrued)
 	numerator.Mul(numerator, oneEth)
 	expectedXrEthPrice := new(big.Int).Div(numerator, originalAmount)
-	xrEthPriceAccordingToVault := getTokenPrice(t, qMgr, csMgr.WethVault)
+	xrEthPriceAccordingToVault := getTokenPrice(t, qMgr, csMgr.Contracts().WethVault)
 	requireApproxEqual(t, expectedXrEthPrice, xrEthPriceAccordingToVault)
 	t.Logf("The new ETH:xrETH price according to the token is %.10f (%s wei)", eth.WeiToEth(xrEthPriceAccordingToVault), xrEthPriceAccordingToVault.String())
 
@@ -701,8 +701,8 @@ func Test13_OrderlyStressTest(t *testing.T) {
 
 		// Do a merkle claim
 		merkleCfg := createMerkleClaimConfig(t, sp, bindings, rewardsSubmission)
-		constellationRewards := rewardsMap[csMgr.SuperNodeAccount.Address]
-		txInfo, err = csMgr.SuperNodeAccount.MerkleClaim(
+		constellationRewards := rewardsMap[csMgr.Contracts().SuperNodeAccount.Address]
+		txInfo, err = csMgr.Contracts().SuperNodeAccount.MerkleClaim(
 			[]*big.Int{rewardsSubmission.RewardIndex},
 			[]*big.Int{constellationRewards.CollateralRpl},
 			[]*big.Int{constellationRewards.SmoothingPoolEth},
@@ -724,7 +724,7 @@ func Test13_OrderlyStressTest(t *testing.T) {
 		expectedMpIndex := 2
 		var nextMinipoolAddress common.Address
 		err = qMgr.Query(func(mc *batch.MultiCaller) error {
-			csMgr.OperatorDistributor.GetNextMinipool(mc, &nextMinipoolAddress)
+			csMgr.Contracts().OperatorDistributor.GetNextMinipool(mc, &nextMinipoolAddress)
 			return nil
 		}, nil)
 		require.NoError(t, err)
@@ -770,7 +770,7 @@ func Test13_OrderlyStressTest(t *testing.T) {
 		numerator = new(big.Int).Add(originalAmount, xRplShareOfRewards)
 		numerator.Mul(numerator, oneEth)
 		expectedXRplPrice := new(big.Int).Div(numerator, originalAmount)
-		xRplPriceAccordingToVault := getTokenPrice(t, qMgr, csMgr.RplVault)
+		xRplPriceAccordingToVault := getTokenPrice(t, qMgr, csMgr.Contracts().RplVault)
 		requireApproxEqual(t, expectedXRplPrice, xRplPriceAccordingToVault)
 		t.Logf("The new RPL:xRPL price according to the token is %.10f (%s wei), which matches the expected value", eth.WeiToEth(xRplPriceAccordingToVault), xRplPriceAccordingToVault.String())
 
@@ -780,7 +780,7 @@ func Test13_OrderlyStressTest(t *testing.T) {
 		numerator.Add(numerator, xrEthShareOfRewards)
 		numerator.Mul(numerator, oneEth)
 		expectedXrEthPrice = new(big.Int).Div(numerator, originalAmount)
-		xrEthPriceAccordingToVault = getTokenPrice(t, qMgr, csMgr.WethVault)
+		xrEthPriceAccordingToVault = getTokenPrice(t, qMgr, csMgr.Contracts().WethVault)
 		requireApproxEqual(t, expectedXrEthPrice, xrEthPriceAccordingToVault)
 		t.Logf("The new ETH:xrETH price according to the token is %.10f (%s wei)", eth.WeiToEth(xrEthPriceAccordingToVault), xrEthPriceAccordingToVault.String())
 
@@ -795,7 +795,7 @@ func Test13_OrderlyStressTest(t *testing.T) {
 
 		// Set max minipools per node
 		wave2MaxMinipoolsPerNode := 5
-		txInfo, err = csMgr.SuperNodeAccount.SetMaxValidators(big.NewInt(int64(wave2MaxMinipoolsPerNode)), deployerOpts)
+		txInfo, err = csMgr.Contracts().SuperNodeAccount.SetMaxValidators(big.NewInt(int64(wave2MaxMinipoolsPerNode)), deployerOpts)
 		require.NoError(t, err)
 		testMgr.MineTx(t, txInfo, deployerOpts, fmt.Sprintf("Set the max validators to %d", wave2MaxMinipoolsPerNode))
 		printTickInfo(t, sp)
@@ -917,13 +917,13 @@ func Test13_OrderlyStressTest(t *testing.T) {
 		t.Logf("Node 1 exited minipool %s as well", extraMinipool.MinipoolAddress.Hex())
 
 		// Tick the spite minipool
-		txInfo, err = csMgr.OperatorDistributor.ProcessMinipool(spiteMinipool.MinipoolAddress, deployerOpts)
+		txInfo, err = csMgr.Contracts().OperatorDistributor.ProcessMinipool(spiteMinipool.MinipoolAddress, deployerOpts)
 		require.NoError(t, err)
 		testMgr.MineTx(t, txInfo, deployerOpts, fmt.Sprintf("Ticked minipool %s", spiteMinipool.MinipoolAddress.Hex()))
 
 		// Verify the next minipool hasn't changed
 		err = qMgr.Query(func(mc *batch.MultiCaller) error {
-			csMgr.OperatorDistributor.GetNextMinipool(mc, &nextMinipoolAddress)
+			csMgr.Contracts().OperatorDistributor.GetNextMinipool(mc, &nextMinipoolAddress)
 			return nil
 		}, nil)
 		require.NoError(t, err)
@@ -933,14 +933,14 @@ func Test13_OrderlyStressTest(t *testing.T) {
 		// Deposit into the RPL vault
 		rplDepositAmount = eth.EthToWei(1000)
 		deployerOpts.Nonce = nil
-		err = testMgr.Constellation_DepositToRplVault(csMgr.RplVault, rplDepositAmount, deployerOpts, deployerOpts)
+		err = testMgr.Constellation_DepositToRplVault(csMgr.Contracts().RplVault, rplDepositAmount, deployerOpts, deployerOpts)
 		require.NoError(t, err)
 		t.Logf("Deposited %.6f ETH (%s wei) into the RPL vault", eth.WeiToEth(rplDepositAmount), rplDepositAmount.String())
 
 		// Attempt to deposit into the WETH vault - should fail
 		ethDepositAmount = eth.EthToWei(2000)
 		deployerOpts.Nonce = nil
-		err = testMgr.Constellation_DepositToWethVault(bindings.Weth, csMgr.WethVault, ethDepositAmount, deployerOpts)
+		err = testMgr.Constellation_DepositToWethVault(bindings.Weth, csMgr.Contracts().WethVault, ethDepositAmount, deployerOpts)
 		deployerOpts.Nonce = nil
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "failed with status 0")
@@ -948,7 +948,7 @@ func Test13_OrderlyStressTest(t *testing.T) {
 
 		// Redeem 8 xrETH
 		xrEthRedeemAmount = eth.EthToWei(8)
-		wethReturned2 := redeemToken(t, qMgr, txMgr, csMgr.WethVault, xrEthRedeemAmount, false, deployerOpts)
+		wethReturned2 := redeemToken(t, qMgr, txMgr, csMgr.Contracts().WethVault, xrEthRedeemAmount, false, deployerOpts)
 		expectedAmount = new(big.Int).Mul(xrEthRedeemAmount, expectedXrEthPrice)
 		expectedAmount.Div(expectedAmount, oneEth)
 		requireApproxEqual(t, expectedAmount, wethReturned2)
@@ -956,7 +956,7 @@ func Test13_OrderlyStressTest(t *testing.T) {
 
 		// Redeem 100 xrRPL
 		xRplRedeemAmount = eth.EthToWei(100)
-		rplReturned2 := redeemToken(t, qMgr, txMgr, csMgr.RplVault, xRplRedeemAmount, false, deployerOpts)
+		rplReturned2 := redeemToken(t, qMgr, txMgr, csMgr.Contracts().RplVault, xRplRedeemAmount, false, deployerOpts)
 		expectedAmount = new(big.Int).Mul(xRplRedeemAmount, expectedXRplPrice)
 		expectedAmount.Div(expectedAmount, oneEth)
 		requireApproxEqualWithTolerance(t, expectedAmount, rplReturned2, big.NewInt(100))
@@ -965,7 +965,7 @@ func Test13_OrderlyStressTest(t *testing.T) {
 		// Verify post-tick interval details
 		expectedMpIndex = 5
 		err = qMgr.Query(func(mc *batch.MultiCaller) error {
-			csMgr.OperatorDistributor.GetNextMinipool(mc, &nextMinipoolAddress)
+			csMgr.Contracts().OperatorDistributor.GetNextMinipool(mc, &nextMinipoolAddress)
 			return nil
 		}, nil)
 		require.NoError(t, err)
@@ -975,7 +975,7 @@ func Test13_OrderlyStressTest(t *testing.T) {
 		// Tick all the minipools to collect rewards
 		totalMpCount := wave1MinipoolsPerNode*len(nodes) + wave2MinipoolsPerNode*len(wave2Nodes)
 		for i := 0; i < totalMpCount; i++ {
-			txInfo, err := csMgr.OperatorDistributor.ProcessNextMinipool(deployerOpts)
+			txInfo, err := csMgr.Contracts().OperatorDistributor.ProcessNextMinipool(deployerOpts)
 			require.NoError(t, err)
 			testMgr.MineTx(t, txInfo, deployerOpts, fmt.Sprintf("Executed tick %d", i))
 		}
@@ -983,7 +983,7 @@ func Test13_OrderlyStressTest(t *testing.T) {
 		// Verify post-tick details
 		expectedMpIndex = 7 // Went up by 2 since we exited 2 minipools
 		err = qMgr.Query(func(mc *batch.MultiCaller) error {
-			csMgr.OperatorDistributor.GetNextMinipool(mc, &nextMinipoolAddress)
+			csMgr.Contracts().OperatorDistributor.GetNextMinipool(mc, &nextMinipoolAddress)
 			return nil
 		}, nil)
 		require.NoError(t, err)
@@ -1003,7 +1003,7 @@ func Test13_OrderlyStressTest(t *testing.T) {
 		preBalance, err := ec.BalanceAt(context.Background(), treasuryRecipient, nil)
 		require.NoError(t, err)
 
-		txInfo, err = csMgr.Treasury.ClaimEth(treasuryRecipient, adminOpts)
+		txInfo, err = csMgr.Contracts().Treasury.ClaimEth(treasuryRecipient, adminOpts)
 		require.NoError(t, err)
 		testMgr.MineTx(t, txInfo, adminOpts, "Treasury claimed ETH rewards")
 
@@ -1025,9 +1025,9 @@ func Test13_OrderlyStressTest(t *testing.T) {
 		}, nil)
 		require.NoError(t, err)
 
-		rplContract, err := sp.GetRocketPoolManager().RocketPool.GetContract(rocketpool.ContractName_RocketTokenRPL)
+		rplContract, err := sp.GetRocketPoolManager().RocketPool().GetContract(rocketpool.ContractName_RocketTokenRPL)
 		require.NoError(t, err)
-		txInfo, err = csMgr.Treasury.ClaimToken(rplContract.Address, treasuryRecipient, adminOpts)
+		txInfo, err = csMgr.Contracts().Treasury.ClaimToken(rplContract.Address, treasuryRecipient, adminOpts)
 		require.NoError(t, err)
 		testMgr.MineTx(t, txInfo, adminOpts, "Treasury claimed RPL rewards")
 
@@ -1074,10 +1074,10 @@ func Test15_StakingTest(t *testing.T) {
 	wethAmount, rplDepositAmount := getDepositAmounts(t, bindings, sp, 10) // Enough for 10 minipools
 
 	// Deposit RPL to the RPL vault
-	cstestutils.DepositToRplVault(t, testMgr, csMgr.RplVault, bindings.Rpl, rplDepositAmount, deployerOpts)
+	cstestutils.DepositToRplVault(t, testMgr, csMgr.Contracts().RplVault, bindings.Rpl, rplDepositAmount, deployerOpts)
 
 	// Deposit WETH to the WETH vault
-	cstestutils.DepositToWethVault(t, testMgr, csMgr.WethVault, bindings.Weth, wethAmount, deployerOpts)
+	cstestutils.DepositToWethVault(t, testMgr, csMgr.Contracts().WethVault, bindings.Weth, wethAmount, deployerOpts)
 
 	// Create salts
 	salts := make([][]*big.Int, 15)
@@ -1240,7 +1240,7 @@ func TestGetMinipools(t *testing.T) {
 
 	// Set the max validator count
 	minipoolCount := 1000
-	txInfo, err := csMgr.SuperNodeAccount.SetMaxValidators(big.NewInt(int64(minipoolCount)), deployerOpts)
+	txInfo, err := csMgr.Contracts().SuperNodeAccount.SetMaxValidators(big.NewInt(int64(minipoolCount)), deployerOpts)
 	require.NoError(t, err)
 	testMgr.MineTx(t, txInfo, deployerOpts, fmt.Sprintf("Set the max validators to %d", minipoolCount))
 
@@ -1291,10 +1291,10 @@ func TestGetMinipools(t *testing.T) {
 	wethAmount, rplAmount := getDepositAmounts(t, bindings, testMgr.GetNode().GetServiceProvider(), minipoolCount)
 
 	// Deposit WETH to the WETH vault
-	cstestutils.DepositToWethVault(t, testMgr, csMgr.WethVault, bindings.Weth, wethAmount, deployerOpts)
+	cstestutils.DepositToWethVault(t, testMgr, csMgr.Contracts().WethVault, bindings.Weth, wethAmount, deployerOpts)
 
 	// Deposit RPL to the RPL vault
-	cstestutils.DepositToRplVault(t, testMgr, csMgr.RplVault, bindings.Rpl, rplAmount, deployerOpts)
+	cstestutils.DepositToRplVault(t, testMgr, csMgr.Contracts().RplVault, bindings.Rpl, rplAmount, deployerOpts)
 
 	// Make the salts
 	salts := make([]*big.Int, minipoolCount)
@@ -1348,7 +1348,7 @@ func TestGetMinipools(t *testing.T) {
 		blsSig := beacon.ValidatorSignature(depositData.Signature)
 
 		// Make the TX, reusing the dummy deposit data for all validators for speed
-		txInfos[i], err = csMgr.SuperNodeAccount.CreateMinipool(
+		txInfos[i], err = csMgr.Contracts().SuperNodeAccount.CreateMinipool(
 			pubkey,
 			blsSig,
 			depositDataRoot,
@@ -1405,7 +1405,7 @@ func TestGetMinipools(t *testing.T) {
 	// Get the list of minipools from SNA
 	var minipoolAddressesFromSna []common.Address
 	err = qMgr.Query(func(mc *batch.MultiCaller) error {
-		csMgr.SuperNodeAccount.GetSubNodeMinipools(mc, &minipoolAddressesFromSna, mainNodeAddress)
+		csMgr.Contracts().SuperNodeAccount.GetSubNodeMinipools(mc, &minipoolAddressesFromSna, mainNodeAddress)
 		return nil
 	}, nil)
 	require.NoError(t, err)
@@ -1428,8 +1428,8 @@ func runPreflightChecks(t *testing.T, bindings *cstestutils.ContractBindings) {
 	var rplPrice *big.Int
 	var minipoolBond *big.Int
 	err := qMgr.Query(func(mc *batch.MultiCaller) error {
-		csMgr.PriceFetcher.GetRplPrice(mc, &rplPrice)
-		csMgr.SuperNodeAccount.Bond(mc, &minipoolBond)
+		csMgr.Contracts().PriceFetcher.GetRplPrice(mc, &rplPrice)
+		csMgr.Contracts().SuperNodeAccount.Bond(mc, &minipoolBond)
 		return nil
 	}, nil,
 		bindings.RpSuperNode.Exists,
@@ -1528,11 +1528,11 @@ func getDepositAmounts(t *testing.T, bindings *cstestutils.ContractBindings, sp
 	var rplReserveRatio *big.Int
 	var mintFee *big.Int
 	err := qMgr.Query(func(mc *batch.MultiCaller) error {
-		csMgr.PriceFetcher.GetRplPrice(mc, &rplPerEth)
-		csMgr.SuperNodeAccount.Bond(mc, &minipoolBond)
-		csMgr.WethVault.GetLiquidityReservePercent(mc, &ethReserveRatio)
-		csMgr.RplVault.GetLiquidityReservePercent(mc, &rplReserveRatio)
-		csMgr.WethVault.GetMintFee(mc, &mintFee)
+		csMgr.Contracts().PriceFetcher.GetRplPrice(mc, &rplPerEth)
+		csMgr.Contracts().SuperNodeAccount.Bond(mc, &minipoolBond)
+		csMgr.Contracts().WethVault.GetLiquidityReservePercent(mc, &ethReserveRatio)
+		csMgr.Contracts().RplVault.GetLiquidityReservePercent(mc, &rplReserveRatio)
+		csMgr.Contracts().WethVault.GetMintFee(mc, &mintFee)
 		return nil
 	}, nil,
 		bindings.RpSuperNode.RplStake,
@@ -1553,7 +1553,7 @@ func getDepositAmounts(t *testing.T, bindings *cstestutils.ContractBindings, sp
 	totalEthMatched := bindings.RpSuperNode.EthMatched.Get()
 	ethAmount := new(big.Int).Add(totalEthMatched, totalEthBorrow)
 	err = qMgr.Query(func(mc *batch.MultiCaller) error {
-		csMgr.OperatorDistributor.CalculateRplStakeShortfall(mc, &rplShortfall, bindings.RpSuperNode.RplStake.Get(), ethAmount)
+		csMgr.Contracts().OperatorDistributor.CalculateRplStakeShortfall(mc, &rplShortfall, bindings.RpSuperNode.RplStake.Get(), ethAmount)
 		return nil
 	}, nil)
 	require.NoError(t, err)
@@ -1588,13 +1588,13 @@ func setCoverageRatios(t *testing.T, sp cscommon.IConstellationServiceProvider,
 
 	submissions := []*eth.TransactionSubmission{}
 	if maxWethRplRatio != nil {
-		txInfo, err := csMgr.WethVault.SetMaxWethRplRatio(maxWethRplRatio, deployerOpts)
+		txInfo, err := csMgr.Contracts().WethVault.SetMaxWethRplRatio(maxWethRplRatio, deployerOpts)
 		submission, err := eth.CreateTxSubmissionFromInfo(txInfo, err)
 		require.NoError(t, err)
 		submissions = append(submissions, submission)
 	}
 	if minWethRplRatio != nil {
-		txInfo, err := csMgr.RplVault.SetMinWethRplRatio(minWethRplRatio, deployerOpts)
+		txInfo, err := csMgr.Contracts().RplVault.SetMinWethRplRatio(minWethRplRatio, deployerOpts)
 		submission, err := eth.CreateTxSubmissionFromInfo(txInfo, err)
 		require.NoError(t, err)
 		submissions = append(submissions, submission)
@@ -1617,10 +1617,10 @@ func setCoverageRatios(t *testing.T, sp cscommon.IConstellationServiceProvider,
 	var newMax *big.Int
 	err = qMgr.Query(func(mc *batch.MultiCaller) error {
 		if minWethRplRatio != nil {
-			csMgr.RplVault.GetMinWethRplRatio(mc, &newMin)
+			csMgr.Contracts().RplVault.GetMinWethRplRatio(mc, &newMin)
 		}
 		if maxWethRplRatio != nil {
-			csMgr.WethVault.GetMaxWethRplRatio(mc, &newMax)
+			csMgr.Contracts().WethVault.GetMaxWethRplRatio(mc, &newMax)
 		}
 		return nil
 	}, nil)
@@ -1648,13 +1648,13 @@ func setLiquidityReservePercents(t *testing.T, sp cscommon.IConstellationService
 
 	submissions := []*eth.TransactionSubmission{}
 	if wethVault != nil {
-		txInfo, err := csMgr.WethVault.SetLiquidityReservePercent(wethVault, deployerOpts)
+		txInfo, err := csMgr.Contracts().WethVault.SetLiquidityReservePercent(wethVault, deployerOpts)
 		submission, err := eth.CreateTxSubmissionFromInfo(txInfo, err)
 		require.NoError(t, err)
 		submissions = append(submissions, submission)
 	}
 	if rplVault != nil {
-		txInfo, err := csMgr.RplVault.SetLiquidityReservePercent(rplVault, deployerOpts)
+		txInfo, err := csMgr.Contracts().RplVault.SetLiquidityReservePercent(rplVault, deployerOpts)
 		submission, err := eth.CreateTxSubmissionFromInfo(txInfo, err)
 		require.NoError(t, err)
 		submissions = append(submissions, submission)
@@ -1678,10 +1678,10 @@ func setLiquidityReservePercents(t *testing.T, sp cscommon.IConstellationService
 	var newRplVaultSetting *big.Int
 	err = qMgr.Query(func(mc *batch.MultiCaller) error {
 		if wethVault != nil {
-			csMgr.WethVault.GetLiquidityReservePercent(mc, &newWethVaultSetting)
+			csMgr.Contracts().WethVault.GetLiquidityReservePercent(mc, &newWethVaultSetting)
 		}
 		if rplVault != nil {
-			csMgr.RplVault.GetLiquidityReservePercent(mc, &newRplVaultSetting)
+			csMgr.Contracts().RplVault.GetLiquidityReservePercent(mc, &newRplVaultSetting)
 		}
 		return nil
 	}, nil)
@@ -1859,8 +1859,8 @@ func calculateXrEthOracleTotalYieldAccrued(t *testing.T, sp cscommon.IConstellat
 	var minipoolCountBig *big.Int
 	var oracleError *big.Int
 	err := qMgr.Query(func(mc *batch.MultiCaller) error {
-		csMgr.SuperNodeAccount.GetMinipoolCount(mc, &minipoolCountBig)
-		csMgr.OperatorDistributor.GetOracleError(mc, &oracleError)
+		csMgr.Contracts().SuperNodeAccount.GetMinipoolCount(mc, &minipoolCountBig)
+		csMgr.Contracts().OperatorDistributor.GetOracleError(mc, &oracleError)
 		return nil
 	}, nil)
 	require.NoError(t, err)
@@ -1871,7 +1871,7 @@ func calculateXrEthOracleTotalYieldAccrued(t *testing.T, sp cscommon.IConstellat
 	addresses := make([]common.Address, minipoolCount)
 	err = qMgr.BatchQuery(minipoolCount, addressBatchSize, func(mc *batch.MultiCaller, index int) error {
 		indexBig := big.NewInt(int64(index))
-		csMgr.SuperNodeAccount.GetMinipoolAddress(mc, &addresses[index], indexBig)
+		csMgr.Contracts().SuperNodeAccount.GetMinipoolAddress(mc, &addresses[index], indexBig)
 		return nil
 	}, nil)
 	require.NoError(t, err)
@@ -1899,7 +1899,7 @@ func calculateXrEthOracleTotalYieldAccrued(t *testing.T, sp cscommon.IConstellat
 			mpCommon.NodeDepositBalance,
 			mpCommon.NodeRefundBalance,
 		)
-		csMgr.SuperNodeAccount.GetMinipoolData(mc, &csMinipools[index].ConstellationData, mpCommon.Address)
+		csMgr.Contracts().SuperNodeAccount.GetMinipoolData(mc, &csMinipools[index].ConstellationData, mpCommon.Address)
 		return nil
 	}, nil)
 	require.NoError(t, err)
@@ -2146,7 +2146,7 @@ func executeRpRewardsInterval(t *testing.T, sp cscommon.IConstellationServicePro
 			OracleDaoRpl:     odaoAmountPerNode,
 			SmoothingPoolEth: common.Big0,
 		},
-		csMgr.SuperNodeAccount.Address: {
+		csMgr.Contracts().SuperNodeAccount.Address: {
 			CollateralRpl:    nodeAmount,
 			OracleDaoRpl:     common.Big0,
 			SmoothingPoolEth: nodeSpShare,
@@ -2218,7 +2218,7 @@ func createRewardsMap(t *testing.T, sp cscommon.IConstellationServiceProvider, b
 	// Services
 	qMgr := sp.GetQueryManager()
 	txMgr := sp.GetTransactionManager()
-	rp := sp.GetRocketPoolManager().RocketPool
+	rp := sp.GetRocketPoolManager().RocketPool()
 	rplBinding := bindings.Rpl
 	vault := bindings.RocketVault
 	rewardsPool := bindings.RewardsPool
@@ -2362,7 +2362,7 @@ func createMerkleClaimConfig(t *testing.T, sp cscommon.IConstellationServiceProv
 		avgNodeFeeBytes[:],
 		avgRplTreasuryFeeBytes[:],
 		sigGenesisTimeBytes[:],
-		csMgr.SuperNodeAccount.Address[:],
+		csMgr.Contracts().SuperNodeAccount.Address[:],
 		nonceBytes[:],
 		chainIDBytes[:],
 	)
@@ -2394,7 +2394,7 @@ func getAvgFeesForBlock(t *testing.T, sp cscommon.IConstellationServiceProvider,
 	// Get the total minipool count and minipool launch balance
 	var minipoolCountBig *big.Int
 	err := qMgr.Query(func(mc *batch.MultiCaller) error {
-		csMgr.SuperNodeAccount.GetMinipoolCount(mc, &minipoolCountBig)
+		csMgr.Contracts().SuperNodeAccount.GetMinipoolCount(mc, &minipoolCountBig)
 		return nil
 	}, opts)
 	require.NoError(t, err)
@@ -2405,7 +2405,7 @@ func getAvgFeesForBlock(t *testing.T, sp cscommon.IConstellationServiceProvider,
 	addresses := make([]common.Address, minipoolCount)
 	err = qMgr.BatchQuery(minipoolCount, addressBatchSize, func(mc *batch.MultiCaller, index int) error {
 		indexBig := big.NewInt(int64(index))
-		csMgr.SuperNodeAccount.GetMinipoolAddress(mc, &addresses[index], indexBig)
+		csMgr.Contracts().SuperNodeAccount.GetMinipoolAddress(mc, &addresses[index], indexBig)
 		return nil
 	}, opts)
 	require.NoError(t, err)
@@ -2431,7 +2431,7 @@ func getAvgFeesForBlock(t *testing.T, sp cscommon.IConstellationServiceProvider,
 			mpCommon.Pubkey,
 			mpCommon.IsFinalised,
 		)
-		csMgr.SuperNodeAccount.GetMinipoolData(mc, &csMinipools[index].ConstellationData, mpCommon.Address)
+		csMgr.Contracts().SuperNodeAccount.GetMinipoolData(mc, &csMinipools[index].ConstellationData, mpCommon.Address)
 		return nil
 	}, opts)
 	require.NoError(t, err)
@@ -2500,7 +2500,7 @@ func printTickInfo(t *testing.T, sp cscommon.IConstellationServiceProvider) {
 
 	var nextMinipool common.Address
 	err := qMgr.Query(func(mc *batch.MultiCaller) error {
-		csMgr.OperatorDistributor.GetNextMinipool(mc, &nextMinipool)
+		csMgr.Contracts().OperatorDistributor.GetNextMinipool(mc, &nextMinipool)
 		return nil
 	}, nil)
 	require.NoError(t, err)
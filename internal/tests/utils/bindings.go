@@ -42,7 +42,7 @@ type ContractBindings struct {
 // Create a new contract bindings instance
 func CreateBindings(sp cscommon.IConstellationServiceProvider) (*ContractBindings, error) {
 	// Services
-	rp := sp.GetRocketPoolManager().RocketPool
+	rp := sp.GetRocketPoolManager().RocketPool()
 	csMgr := sp.GetConstellationManager()
 	ec := sp.GetEthClient()
 	qMgr := sp.GetQueryManager()
@@ -91,12 +91,12 @@ func CreateBindings(sp cscommon.IConstellationServiceProvider) (*ContractBinding
 	}
 
 	// Constellation
-	supernodeAddress := csMgr.SuperNodeAccount.Address
+	supernodeAddress := csMgr.Contracts().SuperNodeAccount.Address
 	var wethAddress common.Address
 	var nodeSetOperatorRewardsDistributorAddress common.Address
 	err = qMgr.Query(func(mc *batch.MultiCaller) error {
-		csMgr.Directory.GetWethAddress(mc, &wethAddress)
-		csMgr.Directory.GetOperatorRewardAddress(mc, &nodeSetOperatorRewardsDistributorAddress)
+		csMgr.Contracts().Directory.GetWethAddress(mc, &wethAddress)
+		csMgr.Contracts().Directory.GetOperatorRewardAddress(mc, &nodeSetOperatorRewardsDistributorAddress)
 		return nil
 	}, nil)
 	if err != nil {
@@ -141,8 +141,8 @@ func CreateStandardTestHarness(options *StandardTestHarnessOptions) (*StandardTe
 	deployment := nsDB.Constellation.AddDeployment(
 		res.DeploymentName,
 		new(big.Int).SetUint64(uint64(res.ChainID)),
-		csMgr.Whitelist.Address,
-		csMgr.SuperNodeAccount.Address,
+		csMgr.Contracts().Whitelist.Address,
+		csMgr.Contracts().SuperNodeAccount.Address,
 	)
 	deployment.SetAdminPrivateKey(deployerKey)
 	nsDB.SetSecretEncryptionIdentity(hdtesting.EncryptionIdentity)
@@ -59,7 +59,7 @@ func DepositToRplVaultBeforeTest(testHarness *StandardTestHarness, rplVault cont
 	var rvRplBalance *big.Int
 	var newXrplBalance *big.Int
 	err = qMgr.Query(func(mc *batch.MultiCaller) error {
-		rpl.BalanceOf(mc, &odRplBalance, csMgr.OperatorDistributor.Address)
+		rpl.BalanceOf(mc, &odRplBalance, csMgr.Contracts().OperatorDistributor.Address)
 		rpl.BalanceOf(mc, &rvRplBalance, rplVault.Address())
 		rplVault.BalanceOf(mc, &newXrplBalance, opts.From)
 		return nil
@@ -102,7 +102,7 @@ func DepositToWethVaultBeforeTest(testHarness *StandardTestHarness, wethVault co
 	logger.Info(fmt.Sprintf("Deposited %.6f WETH into the WETH vault", eth.WeiToEth(amount)))
 
 	// Verify OperatorDistributor WETH balance has been updated
-	odEthBalance, err := ec.BalanceAt(context.Background(), csMgr.OperatorDistributor.Address, nil)
+	odEthBalance, err := ec.BalanceAt(context.Background(), csMgr.Contracts().OperatorDistributor.Address, nil)
 	if err != nil {
 		return err
 	}
@@ -425,7 +425,7 @@ func DepositToRplVault(t *testing.T, testMgr *cstesting.ConstellationTestManager
 	var rvRplBalance *big.Int
 	var newXrplBalance *big.Int
 	err = qMgr.Query(func(mc *batch.MultiCaller) error {
-		rpl.BalanceOf(mc, &odRplBalance, csMgr.OperatorDistributor.Address)
+		rpl.BalanceOf(mc, &odRplBalance, csMgr.Contracts().OperatorDistributor.Address)
 		rpl.BalanceOf(mc, &rvRplBalance, rplVault.Address())
 		rplVault.BalanceOf(mc, &newXrplBalance, opts.From)
 		return nil
@@ -455,7 +455,7 @@ func DepositToWethVault(t *testing.T, testMgr *cstesting.ConstellationTestManage
 	t.Logf("Deposited %.6f WETH into the WETH vault", eth.WeiToEth(amount))
 
 	// Verify OperatorDistributor WETH balance has been updated
-	odEthBalance, err := ec.BalanceAt(context.Background(), csMgr.OperatorDistributor.Address, nil)
+	odEthBalance, err := ec.BalanceAt(context.Background(), csMgr.Contracts().OperatorDistributor.Address, nil)
 	require.NoError(t, err)
 	var evWethBalance *big.Int
 	err = qMgr.Query(func(mc *batch.MultiCaller) error {
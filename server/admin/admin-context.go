@@ -0,0 +1,145 @@
+package csadmin
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	"github.com/nodeset-org/hyperdrive-constellation/common/contracts/constellation"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/services"
+	batch "github.com/rocket-pool/batch-query"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/rocket-pool/node-manager-core/wallet"
+)
+
+// An access control role required by one of the admin functions
+type adminRole struct {
+	// The role's name in the contracts
+	name string
+
+	// The role's hash
+	hash [32]byte
+
+	// True if the role is granted by the Treasury rather than the Directory
+	isTreasuryRole bool
+}
+
+// The roles the admin functions check for
+var (
+	adminRoleAdmin         = adminRole{name: "ADMIN_ROLE", hash: constellation.AdminRole}
+	adminRoleTimelockShort = adminRole{name: "TIMELOCK_SHORT", hash: constellation.TimelockShortRole}
+	adminRoleTimelockMed   = adminRole{name: "TIMELOCK_MED", hash: constellation.TimelockMediumRole}
+	adminRoleTimelockLong  = adminRole{name: "TIMELOCK_LONG", hash: constellation.TimelockLongRole}
+	adminRoleTreasurer     = adminRole{name: "TREASURER_ROLE", hash: constellation.TreasurerRole, isTreasuryRole: true}
+
+	// Every role, in the order they're reported
+	allAdminRoles = []adminRole{
+		adminRoleAdmin,
+		{name: "ADMIN_SERVER_ROLE", hash: constellation.AdminServerRole},
+		{name: "ADMIN_ORACLE_ROLE", hash: constellation.AdminOracleRole},
+		adminRoleTimelockShort,
+		adminRoleTimelockMed,
+		adminRoleTimelockLong,
+		adminRoleTreasurer,
+	}
+)
+
+// Add a check for whether the account holds the role to a multicall
+func (r adminRole) addHasRoleCall(csMgr *cscommon.ConstellationManager, mc *batch.MultiCaller, out *bool, account common.Address) {
+	if r.isTreasuryRole {
+		csMgr.Contracts().Treasury.HasRole(mc, out, r.hash, account)
+		return
+	}
+	csMgr.Contracts().Directory.HasRole(mc, out, r.hash, account)
+}
+
+// Get the contract that grants the role
+func (r adminRole) getContract(csMgr *cscommon.ConstellationManager) common.Address {
+	if r.isTreasuryRole {
+		return csMgr.Contracts().Treasury.Address
+	}
+	return csMgr.Contracts().Directory.Address
+}
+
+// Check that the node address holds the role required by an admin function, then build its transaction.
+// If the node doesn't have the role, the response is flagged instead of building the transaction.
+// Exported transactions are signed elsewhere, such as by a multisig or hardware wallet, so they only need the node
+// address instead of a ready wallet.
+func (h *AdminHandler) prepareAdminTx(
+	data *csapi.AdminTxData,
+	walletStatus wallet.WalletStatus,
+	opts *bind.TransactOpts,
+	export bool,
+	role adminRole,
+	createTx func(csMgr *cscommon.ConstellationManager, opts *bind.TransactOpts) (*eth.TransactionInfo, error),
+) (types.ResponseStatus, error) {
+	sp := h.serviceProvider
+	ctx := h.ctx
+	csMgr := sp.GetConstellationManager()
+	qMgr := sp.GetQueryManager()
+
+	// Requirements
+	if export {
+		err := sp.RequireNodeAddress(walletStatus)
+		if err != nil {
+			return types.ResponseStatus_AddressNotPresent, err
+		}
+	} else {
+		err := sp.RequireWalletReady(walletStatus)
+		if err != nil {
+			return types.ResponseStatus_WalletNotReady, err
+		}
+	}
+	err := sp.RequireEthClientSynced(ctx)
+	if err != nil {
+		if errors.Is(err, services.ErrExecutionClientNotSynced) {
+			return types.ResponseStatus_ClientsNotSynced, err
+		}
+		return types.ResponseStatus_Error, err
+	}
+	err = csMgr.LoadContracts()
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error loading Constellation contracts: %w", err)
+	}
+
+	// Check the role
+	nodeAddress := walletStatus.Address.NodeAddress
+	var hasRole bool
+	err = qMgr.Query(func(mc *batch.MultiCaller) error {
+		role.addHasRoleCall(csMgr, mc, &hasRole, nodeAddress)
+		return nil
+	}, nil)
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error checking %s role: %w", role.name, err)
+	}
+	data.Role = role.name
+	data.MissingRole = !hasRole
+	if data.MissingRole {
+		return types.ResponseStatus_Success, nil
+	}
+
+	// Get the TX
+	if export {
+		opts = cscommon.GetExportTransactOpts(nodeAddress)
+	}
+	data.TxInfo, err = createTx(csMgr, opts)
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error creating TX: %w", err)
+	}
+	data.Contract = data.TxInfo.To
+	data.SimulationRevert = cscommon.GetSimulationRevert(ctx, sp.GetEthClient(), nodeAddress, data.TxInfo)
+
+	// Export it for offline signing if requested
+	if export {
+		unsignedTxs, err := cscommon.CreateUnsignedTransactions(ctx, sp.GetEthClient(), nodeAddress, []*eth.TransactionInfo{data.TxInfo})
+		if err != nil {
+			return types.ResponseStatus_Error, fmt.Errorf("error exporting TX: %w", err)
+		}
+		data.UnsignedTx = unsignedTxs[0]
+	}
+	return types.ResponseStatus_Success, nil
+}
@@ -0,0 +1,60 @@
+package csadmin
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/server"
+	nmcserver "github.com/rocket-pool/node-manager-core/api/server"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/rocket-pool/node-manager-core/utils/input"
+	"github.com/rocket-pool/node-manager-core/wallet"
+)
+
+// ===============
+// === Factory ===
+// ===============
+
+type adminClaimEthContextFactory struct {
+	handler *AdminHandler
+}
+
+func (f *adminClaimEthContextFactory) Create(args url.Values) (*adminClaimEthContext, error) {
+	c := &adminClaimEthContext{
+		handler: f.handler,
+	}
+	inputErrs := []error{
+		nmcserver.ValidateArg("recipient", args, input.ValidateAddress, &c.recipient),
+		nmcserver.ValidateOptionalArg("export", args, input.ValidateBool, &c.export, nil),
+	}
+	return c, errors.Join(inputErrs...)
+}
+
+func (f *adminClaimEthContextFactory) RegisterRoute(router *mux.Router) {
+	server.RegisterQuerylessGet[*adminClaimEthContext, csapi.AdminTxData](
+		router, "claim-eth", f, f.handler.logger.Logger, f.handler.serviceProvider,
+	)
+}
+
+// ===============
+// === Context ===
+// ===============
+
+type adminClaimEthContext struct {
+	handler   *AdminHandler
+	recipient common.Address
+	export    bool
+}
+
+// Send all of the ETH in the Treasury to the recipient
+func (c *adminClaimEthContext) PrepareData(data *csapi.AdminTxData, walletStatus wallet.WalletStatus, opts *bind.TransactOpts) (types.ResponseStatus, error) {
+	return c.handler.prepareAdminTx(data, walletStatus, opts, c.export, adminRoleTreasurer, func(csMgr *cscommon.ConstellationManager, opts *bind.TransactOpts) (*eth.TransactionInfo, error) {
+		return csMgr.Contracts().Treasury.ClaimEth(c.recipient, opts)
+	})
+}
@@ -0,0 +1,62 @@
+package csadmin
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/server"
+	nmcserver "github.com/rocket-pool/node-manager-core/api/server"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/rocket-pool/node-manager-core/utils/input"
+	"github.com/rocket-pool/node-manager-core/wallet"
+)
+
+// ===============
+// === Factory ===
+// ===============
+
+type adminClaimTokenContextFactory struct {
+	handler *AdminHandler
+}
+
+func (f *adminClaimTokenContextFactory) Create(args url.Values) (*adminClaimTokenContext, error) {
+	c := &adminClaimTokenContext{
+		handler: f.handler,
+	}
+	inputErrs := []error{
+		nmcserver.ValidateArg("token", args, input.ValidateAddress, &c.token),
+		nmcserver.ValidateArg("recipient", args, input.ValidateAddress, &c.recipient),
+		nmcserver.ValidateOptionalArg("export", args, input.ValidateBool, &c.export, nil),
+	}
+	return c, errors.Join(inputErrs...)
+}
+
+func (f *adminClaimTokenContextFactory) RegisterRoute(router *mux.Router) {
+	server.RegisterQuerylessGet[*adminClaimTokenContext, csapi.AdminTxData](
+		router, "claim-token", f, f.handler.logger.Logger, f.handler.serviceProvider,
+	)
+}
+
+// ===============
+// === Context ===
+// ===============
+
+type adminClaimTokenContext struct {
+	handler   *AdminHandler
+	token     common.Address
+	recipient common.Address
+	export    bool
+}
+
+// Send all of the Treasury's balance of an ERC20 token to the recipient
+func (c *adminClaimTokenContext) PrepareData(data *csapi.AdminTxData, walletStatus wallet.WalletStatus, opts *bind.TransactOpts) (types.ResponseStatus, error) {
+	return c.handler.prepareAdminTx(data, walletStatus, opts, c.export, adminRoleTreasurer, func(csMgr *cscommon.ConstellationManager, opts *bind.TransactOpts) (*eth.TransactionInfo, error) {
+		return csMgr.Contracts().Treasury.ClaimToken(c.token, c.recipient, opts)
+	})
+}
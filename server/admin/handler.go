@@ -0,0 +1,48 @@
+package csadmin
+
+import (
+	"context"
+
+	"github.com/gorilla/mux"
+	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	"github.com/rocket-pool/node-manager-core/api/server"
+	"github.com/rocket-pool/node-manager-core/log"
+)
+
+// Handler for the routes that build transactions for Constellation's admin functions.
+// These are only registered if the admin routes are enabled in the config.
+type AdminHandler struct {
+	logger          *log.Logger
+	ctx             context.Context
+	serviceProvider cscommon.IConstellationServiceProvider
+	factories       []server.IContextFactory
+}
+
+func NewAdminHandler(logger *log.Logger, ctx context.Context, serviceProvider cscommon.IConstellationServiceProvider) *AdminHandler {
+	h := &AdminHandler{
+		logger:          logger,
+		ctx:             ctx,
+		serviceProvider: serviceProvider,
+	}
+	h.factories = []server.IContextFactory{
+		&adminClaimEthContextFactory{h},
+		&adminClaimTokenContextFactory{h},
+		&adminRolesContextFactory{h},
+		&adminSetAllContextFactory{h},
+		&adminSetLockAmountContextFactory{h},
+		&adminSetMaxValidatorsContextFactory{h},
+		&adminSetMaxWethRplRatioContextFactory{h},
+		&adminSetMinWethRplRatioContextFactory{h},
+		&adminSetNodeOperatorFeeContextFactory{h},
+		&adminSetTargetStakeRatioContextFactory{h},
+		&adminSetTreasuryFeeContextFactory{h},
+	}
+	return h
+}
+
+func (h *AdminHandler) RegisterRoutes(router *mux.Router) {
+	subrouter := router.PathPrefix("/admin").Subrouter()
+	for _, factory := range h.factories {
+		factory.RegisterRoute(subrouter)
+	}
+}
@@ -0,0 +1,91 @@
+package csadmin
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/gorilla/mux"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/server"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/services"
+	batch "github.com/rocket-pool/batch-query"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/wallet"
+)
+
+// ===============
+// === Factory ===
+// ===============
+
+type adminRolesContextFactory struct {
+	handler *AdminHandler
+}
+
+func (f *adminRolesContextFactory) Create(args url.Values) (*adminRolesContext, error) {
+	c := &adminRolesContext{
+		handler: f.handler,
+	}
+	return c, nil
+}
+
+func (f *adminRolesContextFactory) RegisterRoute(router *mux.Router) {
+	server.RegisterQuerylessGet[*adminRolesContext, csapi.AdminRolesData](
+		router, "roles", f, f.handler.logger.Logger, f.handler.serviceProvider,
+	)
+}
+
+// ===============
+// === Context ===
+// ===============
+
+type adminRolesContext struct {
+	handler *AdminHandler
+}
+
+func (c *adminRolesContext) PrepareData(data *csapi.AdminRolesData, walletStatus wallet.WalletStatus, opts *bind.TransactOpts) (types.ResponseStatus, error) {
+	sp := c.handler.serviceProvider
+	ctx := c.handler.ctx
+	csMgr := sp.GetConstellationManager()
+	qMgr := sp.GetQueryManager()
+
+	// Requirements; this only reads the chain, so it works for admins that sign elsewhere and have no wallet loaded
+	err := sp.RequireNodeAddress(walletStatus)
+	if err != nil {
+		return types.ResponseStatus_AddressNotPresent, err
+	}
+	err = sp.RequireEthClientSynced(ctx)
+	if err != nil {
+		if errors.Is(err, services.ErrExecutionClientNotSynced) {
+			return types.ResponseStatus_ClientsNotSynced, err
+		}
+		return types.ResponseStatus_Error, err
+	}
+	err = csMgr.LoadContracts()
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error loading Constellation contracts: %w", err)
+	}
+
+	// Check each role
+	data.Address = walletStatus.Address.NodeAddress
+	hasRoles := make([]bool, len(allAdminRoles))
+	err = qMgr.Query(func(mc *batch.MultiCaller) error {
+		for i, role := range allAdminRoles {
+			role.addHasRoleCall(csMgr, mc, &hasRoles[i], data.Address)
+		}
+		return nil
+	}, nil)
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error checking roles: %w", err)
+	}
+	data.Roles = make([]csapi.AdminRoleStatus, len(allAdminRoles))
+	for i, role := range allAdminRoles {
+		data.Roles[i] = csapi.AdminRoleStatus{
+			Role:     role.name,
+			Contract: role.getContract(csMgr),
+			HasRole:  hasRoles[i],
+		}
+	}
+	return types.ResponseStatus_Success, nil
+}
@@ -0,0 +1,72 @@
+package csadmin
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/gorilla/mux"
+	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	"github.com/nodeset-org/hyperdrive-constellation/common/contracts/constellation"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/server"
+	nmcserver "github.com/rocket-pool/node-manager-core/api/server"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/rocket-pool/node-manager-core/utils/input"
+	"github.com/rocket-pool/node-manager-core/wallet"
+)
+
+// ===============
+// === Factory ===
+// ===============
+
+type adminSetAllContextFactory struct {
+	handler *AdminHandler
+}
+
+func (f *adminSetAllContextFactory) Create(args url.Values) (*adminSetAllContext, error) {
+	c := &adminSetAllContext{
+		handler: f.handler,
+	}
+	// Every address is required since setAll replaces the whole protocol at once
+	inputErrs := []error{
+		nmcserver.ValidateArg("whitelist", args, input.ValidateAddress, &c.protocol.Whitelist),
+		nmcserver.ValidateArg("weth-vault", args, input.ValidateAddress, &c.protocol.WethVault),
+		nmcserver.ValidateArg("rpl-vault", args, input.ValidateAddress, &c.protocol.RplVault),
+		nmcserver.ValidateArg("operator-distributor", args, input.ValidateAddress, &c.protocol.OperatorDistributor),
+		nmcserver.ValidateArg("merkle-claim-streamer", args, input.ValidateAddress, &c.protocol.MerkleClaimStreamer),
+		nmcserver.ValidateArg("operator-reward", args, input.ValidateAddress, &c.protocol.OperatorReward),
+		nmcserver.ValidateArg("oracle", args, input.ValidateAddress, &c.protocol.Oracle),
+		nmcserver.ValidateArg("price-fetcher", args, input.ValidateAddress, &c.protocol.PriceFetcher),
+		nmcserver.ValidateArg("super-node", args, input.ValidateAddress, &c.protocol.SuperNode),
+		nmcserver.ValidateArg("rocket-storage", args, input.ValidateAddress, &c.protocol.RocketStorage),
+		nmcserver.ValidateArg("weth", args, input.ValidateAddress, &c.protocol.Weth),
+		nmcserver.ValidateArg("sanctions", args, input.ValidateAddress, &c.protocol.Sanctions),
+		nmcserver.ValidateOptionalArg("export", args, input.ValidateBool, &c.export, nil),
+	}
+	return c, errors.Join(inputErrs...)
+}
+
+func (f *adminSetAllContextFactory) RegisterRoute(router *mux.Router) {
+	server.RegisterQuerylessGet[*adminSetAllContext, csapi.AdminTxData](
+		router, "set-all", f, f.handler.logger.Logger, f.handler.serviceProvider,
+	)
+}
+
+// ===============
+// === Context ===
+// ===============
+
+type adminSetAllContext struct {
+	handler  *AdminHandler
+	protocol constellation.Protocol
+	export   bool
+}
+
+// Point the Directory at a new set of protocol contracts
+func (c *adminSetAllContext) PrepareData(data *csapi.AdminTxData, walletStatus wallet.WalletStatus, opts *bind.TransactOpts) (types.ResponseStatus, error) {
+	return c.handler.prepareAdminTx(data, walletStatus, opts, c.export, adminRoleAdmin, func(csMgr *cscommon.ConstellationManager, opts *bind.TransactOpts) (*eth.TransactionInfo, error) {
+		return csMgr.Contracts().Directory.SetAll(c.protocol, opts)
+	})
+}
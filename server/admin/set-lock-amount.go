@@ -0,0 +1,60 @@
+package csadmin
+
+import (
+	"errors"
+	"math/big"
+	"net/url"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/gorilla/mux"
+	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/server"
+	nmcserver "github.com/rocket-pool/node-manager-core/api/server"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/rocket-pool/node-manager-core/utils/input"
+	"github.com/rocket-pool/node-manager-core/wallet"
+)
+
+// ===============
+// === Factory ===
+// ===============
+
+type adminSetLockAmountContextFactory struct {
+	handler *AdminHandler
+}
+
+func (f *adminSetLockAmountContextFactory) Create(args url.Values) (*adminSetLockAmountContext, error) {
+	c := &adminSetLockAmountContext{
+		handler: f.handler,
+	}
+	inputErrs := []error{
+		nmcserver.ValidateArg("amount", args, input.ValidatePositiveOrZeroWeiAmount, &c.amount),
+		nmcserver.ValidateOptionalArg("export", args, input.ValidateBool, &c.export, nil),
+	}
+	return c, errors.Join(inputErrs...)
+}
+
+func (f *adminSetLockAmountContextFactory) RegisterRoute(router *mux.Router) {
+	server.RegisterQuerylessGet[*adminSetLockAmountContext, csapi.AdminTxData](
+		router, "set-lock-amount", f, f.handler.logger.Logger, f.handler.serviceProvider,
+	)
+}
+
+// ===============
+// === Context ===
+// ===============
+
+type adminSetLockAmountContext struct {
+	handler *AdminHandler
+	amount  *big.Int
+	export  bool
+}
+
+// Set the amount of ETH, in wei, that sub-node operators have to lock when creating a minipool
+func (c *adminSetLockAmountContext) PrepareData(data *csapi.AdminTxData, walletStatus wallet.WalletStatus, opts *bind.TransactOpts) (types.ResponseStatus, error) {
+	return c.handler.prepareAdminTx(data, walletStatus, opts, c.export, adminRoleTimelockShort, func(csMgr *cscommon.ConstellationManager, opts *bind.TransactOpts) (*eth.TransactionInfo, error) {
+		return csMgr.Contracts().SuperNodeAccount.SetLockAmount(c.amount, opts)
+	})
+}
@@ -0,0 +1,60 @@
+package csadmin
+
+import (
+	"errors"
+	"math/big"
+	"net/url"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/gorilla/mux"
+	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/server"
+	nmcserver "github.com/rocket-pool/node-manager-core/api/server"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/rocket-pool/node-manager-core/utils/input"
+	"github.com/rocket-pool/node-manager-core/wallet"
+)
+
+// ===============
+// === Factory ===
+// ===============
+
+type adminSetMaxValidatorsContextFactory struct {
+	handler *AdminHandler
+}
+
+func (f *adminSetMaxValidatorsContextFactory) Create(args url.Values) (*adminSetMaxValidatorsContext, error) {
+	c := &adminSetMaxValidatorsContext{
+		handler: f.handler,
+	}
+	inputErrs := []error{
+		nmcserver.ValidateArg("max-validators", args, input.ValidateBigInt, &c.maxValidators),
+		nmcserver.ValidateOptionalArg("export", args, input.ValidateBool, &c.export, nil),
+	}
+	return c, errors.Join(inputErrs...)
+}
+
+func (f *adminSetMaxValidatorsContextFactory) RegisterRoute(router *mux.Router) {
+	server.RegisterQuerylessGet[*adminSetMaxValidatorsContext, csapi.AdminTxData](
+		router, "set-max-validators", f, f.handler.logger.Logger, f.handler.serviceProvider,
+	)
+}
+
+// ===============
+// === Context ===
+// ===============
+
+type adminSetMaxValidatorsContext struct {
+	handler       *AdminHandler
+	maxValidators *big.Int
+	export        bool
+}
+
+// Set the most minipools a single sub-node operator can run
+func (c *adminSetMaxValidatorsContext) PrepareData(data *csapi.AdminTxData, walletStatus wallet.WalletStatus, opts *bind.TransactOpts) (types.ResponseStatus, error) {
+	return c.handler.prepareAdminTx(data, walletStatus, opts, c.export, adminRoleTimelockMed, func(csMgr *cscommon.ConstellationManager, opts *bind.TransactOpts) (*eth.TransactionInfo, error) {
+		return csMgr.Contracts().SuperNodeAccount.SetMaxValidators(c.maxValidators, opts)
+	})
+}
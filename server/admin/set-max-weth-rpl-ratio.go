@@ -0,0 +1,60 @@
+package csadmin
+
+import (
+	"errors"
+	"math/big"
+	"net/url"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/gorilla/mux"
+	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/server"
+	nmcserver "github.com/rocket-pool/node-manager-core/api/server"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/rocket-pool/node-manager-core/utils/input"
+	"github.com/rocket-pool/node-manager-core/wallet"
+)
+
+// ===============
+// === Factory ===
+// ===============
+
+type adminSetMaxWethRplRatioContextFactory struct {
+	handler *AdminHandler
+}
+
+func (f *adminSetMaxWethRplRatioContextFactory) Create(args url.Values) (*adminSetMaxWethRplRatioContext, error) {
+	c := &adminSetMaxWethRplRatioContext{
+		handler: f.handler,
+	}
+	inputErrs := []error{
+		nmcserver.ValidateArg("ratio", args, input.ValidatePositiveOrZeroWeiAmount, &c.ratio),
+		nmcserver.ValidateOptionalArg("export", args, input.ValidateBool, &c.export, nil),
+	}
+	return c, errors.Join(inputErrs...)
+}
+
+func (f *adminSetMaxWethRplRatioContextFactory) RegisterRoute(router *mux.Router) {
+	server.RegisterQuerylessGet[*adminSetMaxWethRplRatioContext, csapi.AdminTxData](
+		router, "set-max-weth-rpl-ratio", f, f.handler.logger.Logger, f.handler.serviceProvider,
+	)
+}
+
+// ===============
+// === Context ===
+// ===============
+
+type adminSetMaxWethRplRatioContext struct {
+	handler *AdminHandler
+	ratio   *big.Int
+	export  bool
+}
+
+// Set the highest ratio of WETH to RPL the WETH vault will accept deposits at
+func (c *adminSetMaxWethRplRatioContext) PrepareData(data *csapi.AdminTxData, walletStatus wallet.WalletStatus, opts *bind.TransactOpts) (types.ResponseStatus, error) {
+	return c.handler.prepareAdminTx(data, walletStatus, opts, c.export, adminRoleTimelockShort, func(csMgr *cscommon.ConstellationManager, opts *bind.TransactOpts) (*eth.TransactionInfo, error) {
+		return csMgr.Contracts().WethVault.SetMaxWethRplRatio(c.ratio, opts)
+	})
+}
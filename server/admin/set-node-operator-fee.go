@@ -0,0 +1,60 @@
+package csadmin
+
+import (
+	"errors"
+	"math/big"
+	"net/url"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/gorilla/mux"
+	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/server"
+	nmcserver "github.com/rocket-pool/node-manager-core/api/server"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/rocket-pool/node-manager-core/utils/input"
+	"github.com/rocket-pool/node-manager-core/wallet"
+)
+
+// ===============
+// === Factory ===
+// ===============
+
+type adminSetNodeOperatorFeeContextFactory struct {
+	handler *AdminHandler
+}
+
+func (f *adminSetNodeOperatorFeeContextFactory) Create(args url.Values) (*adminSetNodeOperatorFeeContext, error) {
+	c := &adminSetNodeOperatorFeeContext{
+		handler: f.handler,
+	}
+	inputErrs := []error{
+		nmcserver.ValidateArg("fee", args, input.ValidatePositiveOrZeroWeiAmount, &c.fee),
+		nmcserver.ValidateOptionalArg("export", args, input.ValidateBool, &c.export, nil),
+	}
+	return c, errors.Join(inputErrs...)
+}
+
+func (f *adminSetNodeOperatorFeeContextFactory) RegisterRoute(router *mux.Router) {
+	server.RegisterQuerylessGet[*adminSetNodeOperatorFeeContext, csapi.AdminTxData](
+		router, "set-node-operator-fee", f, f.handler.logger.Logger, f.handler.serviceProvider,
+	)
+}
+
+// ===============
+// === Context ===
+// ===============
+
+type adminSetNodeOperatorFeeContext struct {
+	handler *AdminHandler
+	fee     *big.Int
+	export  bool
+}
+
+// Set the share of the WETH vault's rewards that goes to node operators, where 1e18 is 100%
+func (c *adminSetNodeOperatorFeeContext) PrepareData(data *csapi.AdminTxData, walletStatus wallet.WalletStatus, opts *bind.TransactOpts) (types.ResponseStatus, error) {
+	return c.handler.prepareAdminTx(data, walletStatus, opts, c.export, adminRoleTimelockMed, func(csMgr *cscommon.ConstellationManager, opts *bind.TransactOpts) (*eth.TransactionInfo, error) {
+		return csMgr.Contracts().WethVault.SetNodeOperatorFee(c.fee, opts)
+	})
+}
@@ -0,0 +1,60 @@
+package csadmin
+
+import (
+	"errors"
+	"math/big"
+	"net/url"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/gorilla/mux"
+	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/server"
+	nmcserver "github.com/rocket-pool/node-manager-core/api/server"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/rocket-pool/node-manager-core/utils/input"
+	"github.com/rocket-pool/node-manager-core/wallet"
+)
+
+// ===============
+// === Factory ===
+// ===============
+
+type adminSetTargetStakeRatioContextFactory struct {
+	handler *AdminHandler
+}
+
+func (f *adminSetTargetStakeRatioContextFactory) Create(args url.Values) (*adminSetTargetStakeRatioContext, error) {
+	c := &adminSetTargetStakeRatioContext{
+		handler: f.handler,
+	}
+	inputErrs := []error{
+		nmcserver.ValidateArg("ratio", args, input.ValidatePositiveOrZeroWeiAmount, &c.ratio),
+		nmcserver.ValidateOptionalArg("export", args, input.ValidateBool, &c.export, nil),
+	}
+	return c, errors.Join(inputErrs...)
+}
+
+func (f *adminSetTargetStakeRatioContextFactory) RegisterRoute(router *mux.Router) {
+	server.RegisterQuerylessGet[*adminSetTargetStakeRatioContext, csapi.AdminTxData](
+		router, "set-target-stake-ratio", f, f.handler.logger.Logger, f.handler.serviceProvider,
+	)
+}
+
+// ===============
+// === Context ===
+// ===============
+
+type adminSetTargetStakeRatioContext struct {
+	handler *AdminHandler
+	ratio   *big.Int
+	export  bool
+}
+
+// Set the ratio of RPL to borrowed ETH the OperatorDistributor aims to stake for the supernode, where 1e18 is 100%
+func (c *adminSetTargetStakeRatioContext) PrepareData(data *csapi.AdminTxData, walletStatus wallet.WalletStatus, opts *bind.TransactOpts) (types.ResponseStatus, error) {
+	return c.handler.prepareAdminTx(data, walletStatus, opts, c.export, adminRoleAdmin, func(csMgr *cscommon.ConstellationManager, opts *bind.TransactOpts) (*eth.TransactionInfo, error) {
+		return csMgr.Contracts().OperatorDistributor.SetTargetStakeRatio(c.ratio, opts)
+	})
+}
@@ -0,0 +1,193 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gorilla/mux"
+	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/rocket-pool/node-manager-core/log"
+)
+
+// Routes that change state or build transactions, relative to the API router; calls to these are recorded in the audit log
+var auditedRoutes = []string{
+	"/admin/claim-eth",
+	"/admin/claim-token",
+	"/admin/set-all",
+	"/admin/set-lock-amount",
+	"/admin/set-max-validators",
+	"/admin/set-max-weth-rpl-ratio",
+	"/admin/set-min-weth-rpl-ratio",
+	"/admin/set-node-operator-fee",
+	"/admin/set-target-stake-ratio",
+	"/admin/set-treasury-fee",
+	"/minipool/create",
+	"/minipool/stake",
+	"/minipool/close",
+	"/minipool/exit",
+	"/minipool/upload-signed-exits",
+	"/node/register",
+	"/service/reload-settings",
+	"/service/restart-vc",
+	"/tx/cancel",
+	"/tx/speed-up",
+	"/tx/submit-signed",
+	"/wallet/create-validator-key",
+}
+
+// The parts of an audited route's response that are worth recording
+type auditedResponse struct {
+	Data  *auditedResponseData `json:"data"`
+	Error string               `json:"error"`
+}
+
+// The places transactions can show up in an audited route's response; the hashes are from routes that broadcast one
+type auditedResponseData struct {
+	TxHash  *common.Hash           `json:"txHash"`
+	NewHash *common.Hash           `json:"newHash"`
+	TxInfo  *eth.TransactionInfo   `json:"txInfo"`
+	TxInfos []*eth.TransactionInfo `json:"txInfos"`
+	Details []struct {
+		TxInfo *eth.TransactionInfo `json:"txInfo"`
+	} `json:"details"`
+}
+
+// Captures the response to an audited request so it can be recorded
+type auditResponseWriter struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (w *auditResponseWriter) WriteHeader(statusCode int) {
+	w.statusCode = statusCode
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *auditResponseWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+// Creates middleware that records calls to the audited routes in the audit log.
+// Failing to record an entry is logged but doesn't fail the request, since the response has already been sent by then.
+func createAuditMiddleware(sp cscommon.IConstellationServiceProvider, logger *slog.Logger, routePrefix string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, audited := getAuditedRoute(r, routePrefix)
+			if !audited {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// Capture the body while leaving it intact for the handler
+			var body []byte
+			if r.Body != nil {
+				var err error
+				body, err = io.ReadAll(r.Body)
+				if err != nil {
+					logger.Error("Error reading request body for the audit log", log.Err(err))
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				r.Body = io.NopCloser(bytes.NewReader(body))
+			}
+
+			// Run the request
+			recorder := &auditResponseWriter{
+				ResponseWriter: w,
+				statusCode:     http.StatusOK,
+			}
+			next.ServeHTTP(recorder, r)
+
+			// Record it
+			entry := csapi.AuditLogEntry{
+				Method:        r.Method,
+				Route:         route,
+				RemoteAddress: r.RemoteAddr,
+				UserAgent:     r.UserAgent(),
+				Arguments:     map[string]string{},
+				StatusCode:    recorder.statusCode,
+				Transactions:  []csapi.AuditLogTransaction{},
+			}
+			for name, values := range r.URL.Query() {
+				entry.Arguments[name] = strings.Join(values, ",")
+			}
+			if len(body) > 0 && json.Valid(body) {
+				entry.Body = body
+			}
+			addAuditedResponse(&entry, recorder.body.Bytes())
+			err := sp.GetAuditLog().Append(entry)
+			if err != nil {
+				logger.Error("Error recording request in the audit log", slog.String("route", route), log.Err(err))
+			}
+		})
+	}
+}
+
+// Get the route of the request relative to the API router, and whether it should be audited
+func getAuditedRoute(r *http.Request, routePrefix string) (string, bool) {
+	route := strings.TrimPrefix(r.URL.Path, routePrefix)
+	for _, auditedRoute := range auditedRoutes {
+		if route == auditedRoute {
+			return route, true
+		}
+	}
+	return route, false
+}
+
+// Add the error, any transactions, and the hash of any broadcast transaction from the response to an audit log entry
+func addAuditedResponse(entry *csapi.AuditLogEntry, body []byte) {
+	var response auditedResponse
+	err := json.Unmarshal(body, &response)
+	if err != nil {
+		// Type mismatches in fields that aren't transactions can be ignored, but anything else means the response isn't usable
+		var typeErr *json.UnmarshalTypeError
+		if !errors.As(err, &typeErr) {
+			if entry.Error == "" && entry.StatusCode != http.StatusOK {
+				entry.Error = strings.TrimSpace(string(body))
+			}
+			return
+		}
+	}
+	entry.Error = response.Error
+	if response.Data == nil {
+		return
+	}
+	if response.Data.TxHash != nil {
+		entry.TxHash = response.Data.TxHash
+	} else if response.Data.NewHash != nil {
+		entry.TxHash = response.Data.NewHash
+	}
+
+	txInfos := []*eth.TransactionInfo{}
+	if response.Data.TxInfo != nil {
+		txInfos = append(txInfos, response.Data.TxInfo)
+	}
+	txInfos = append(txInfos, response.Data.TxInfos...)
+	for _, details := range response.Data.Details {
+		if details.TxInfo != nil {
+			txInfos = append(txInfos, details.TxInfo)
+		}
+	}
+	for _, txInfo := range txInfos {
+		if txInfo == nil {
+			continue
+		}
+		entry.Transactions = append(entry.Transactions, csapi.AuditLogTransaction{
+			To:              txInfo.To,
+			Value:           txInfo.Value,
+			DataHash:        crypto.Keccak256Hash(txInfo.Data),
+			SimulationError: txInfo.SimulationResult.SimulationError,
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/rocket-pool/node-manager-core/log"
+)
+
+const (
+	// How often to send a comment down an idle event stream so proxies don't time it out
+	eventStreamKeepaliveInterval time.Duration = 30 * time.Second
+
+	// Query parameter that can be used instead of the Last-Event-ID header to resume a stream
+	lastEventIdParam string = "lastEventId"
+)
+
+// Registers the event stream route.
+// /events is a Server-Sent Events stream of the daemon's activity; clients can resume where they left off with the
+// Last-Event-ID header, as long as the events they missed are still in the broker's history.
+func registerEventsRoute(router *mux.Router, sp cscommon.IConstellationServiceProvider, logger *slog.Logger) {
+	router.HandleFunc("/events", func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			logger.Error("Event stream isn't supported by the response writer")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		// Get the last event the client saw
+		lastEventID := uint64(0)
+		lastEventIDString := r.Header.Get("Last-Event-ID")
+		if lastEventIDString == "" {
+			lastEventIDString = r.URL.Query().Get(lastEventIdParam)
+		}
+		if lastEventIDString != "" {
+			var err error
+			lastEventID, err = strconv.ParseUint(lastEventIDString, 10, 64)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = fmt.Fprintf(w, "invalid last event ID [%s]", lastEventIDString)
+				return
+			}
+		}
+
+		// Subscribe before writing anything so no events are missed
+		missed, events, unsubscribe := sp.GetEventBroker().Subscribe(lastEventID)
+		defer unsubscribe()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		// Catch the client up
+		for _, event := range missed {
+			err := writeEvent(w, event)
+			if err != nil {
+				logger.Debug("Event stream closed", log.Err(err))
+				return
+			}
+		}
+		flusher.Flush()
+
+		// Stream new events until the client leaves or the daemon shuts down
+		ticker := time.NewTicker(eventStreamKeepaliveInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-sp.GetBaseContext().Done():
+				return
+			case event, ok := <-events:
+				if !ok {
+					// The broker dropped this subscriber for falling behind; the client can reconnect and resume
+					logger.Warn("Event stream subscriber fell behind, closing the stream")
+					return
+				}
+				err := writeEvent(w, event)
+				if err != nil {
+					logger.Debug("Event stream closed", log.Err(err))
+					return
+				}
+				flusher.Flush()
+			case <-ticker.C:
+				_, err := fmt.Fprint(w, ": keepalive\n\n")
+				if err != nil {
+					logger.Debug("Event stream closed", log.Err(err))
+					return
+				}
+				flusher.Flush()
+			}
+		}
+	}).Methods(http.MethodGet)
+}
+
+// Write an event to the stream in the Server-Sent Events format
+func writeEvent(w http.ResponseWriter, event csapi.Event) error {
+	bytes, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("error serializing event %d: %w", event.ID, err)
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, bytes)
+	return err
+}
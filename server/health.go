@@ -0,0 +1,70 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/log"
+)
+
+const (
+	// Route names for the healthchecks, used to exempt them from authorization
+	healthRouteName string = "health"
+	readyRouteName  string = "ready"
+)
+
+// Registers the healthcheck routes.
+// /health responds with 200 as long as the task loop is running, and /ready responds with 200 once it's able to run all of its tasks;
+// both respond with 503 otherwise, and always include the full health details in the body.
+func registerHealthRoutes(router *mux.Router, sp cscommon.IConstellationServiceProvider, logger *slog.Logger) {
+	router.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		health := sp.GetHealthTracker().GetHealth()
+		writeHealthResponse(logger, w, health, health.Healthy)
+	}).Methods(http.MethodGet).Name(healthRouteName)
+
+	router.HandleFunc("/ready", func(w http.ResponseWriter, r *http.Request) {
+		health := sp.GetHealthTracker().GetHealth()
+		writeHealthResponse(logger, w, health, health.Ready)
+	}).Methods(http.MethodGet).Name(readyRouteName)
+}
+
+// True if the request is for one of the healthcheck routes
+func isHealthRoute(r *http.Request) bool {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return false
+	}
+	name := route.GetName()
+	return name == healthRouteName || name == readyRouteName
+}
+
+// Write the health details as an API response, with a 503 status code if the check failed
+func writeHealthResponse(logger *slog.Logger, w http.ResponseWriter, health csapi.ServiceHealthData, passed bool) {
+	response := types.ApiResponse[csapi.ServiceHealthData]{
+		Data: &health,
+	}
+	status := http.StatusOK
+	if !passed {
+		status = http.StatusServiceUnavailable
+		response.Error = strings.Join(health.Issues, "; ")
+	}
+
+	bytes, err := json.Marshal(response)
+	if err != nil {
+		logger.Error("Error serializing health response", log.Err(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, err = w.Write(bytes)
+	if err != nil {
+		logger.Error("Error writing health response", log.Err(err))
+	}
+}
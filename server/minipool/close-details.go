@@ -2,6 +2,7 @@ package csminipool
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"net/url"
 
@@ -12,7 +13,9 @@ import (
 	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
 	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
 	batch "github.com/rocket-pool/batch-query"
+	nmcserver "github.com/rocket-pool/node-manager-core/api/server"
 	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/utils/input"
 	"github.com/rocket-pool/node-manager-core/wallet"
 	"github.com/rocket-pool/rocketpool-go/v2/minipool"
 	"github.com/rocket-pool/rocketpool-go/v2/node"
@@ -34,9 +37,12 @@ func (f *minipoolCloseDetailsContextFactory) Create(args url.Values) (*MinipoolC
 		ServiceProvider:   f.handler.serviceProvider,
 		Logger:            f.handler.logger.Logger,
 		Context:           f.handler.ctx,
-		SnServiceProvider: f.handler.snServiceProvider,
+		SnServiceProvider: f.handler.serviceProvider.GetSmartNodeServiceProvider(),
 	}
-	return c, nil
+	inputErrs := []error{
+		nmcserver.ValidateOptionalArg("node-address", args, input.ValidateAddress, &c.NodeAddress, &c.HasNodeAddress),
+	}
+	return c, errors.Join(inputErrs...)
 }
 
 func (f *minipoolCloseDetailsContextFactory) RegisterRoute(router *mux.Router) {
@@ -56,10 +62,18 @@ type MinipoolCloseDetailsContext struct {
 	Context           context.Context
 	SnServiceProvider snservices.ISmartNodeServiceProvider
 
+	// Arguments
+	NodeAddress    common.Address
+	HasNodeAddress bool
+
 	snContext *snminipool.MinipoolCloseDetailsContext
 	snData    *snapi.MinipoolCloseDetailsData
 }
 
+func (c *MinipoolCloseDetailsContext) GetNodeAddressOverride() (common.Address, bool) {
+	return c.NodeAddress, c.HasNodeAddress
+}
+
 func (c *MinipoolCloseDetailsContext) Initialize(walletStatus wallet.WalletStatus) (types.ResponseStatus, error) {
 	// Create the SN context
 	c.snContext = &snminipool.MinipoolCloseDetailsContext{
@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gorilla/mux"
 	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
 	hdserver "github.com/nodeset-org/hyperdrive-daemon/module-utils/server"
 	hdservices "github.com/nodeset-org/hyperdrive-daemon/module-utils/services"
 	batch "github.com/rocket-pool/batch-query"
@@ -35,12 +36,13 @@ func (f *minipoolCloseContextFactory) Create(args url.Values) (*MinipoolCloseCon
 	}
 	inputErrs := []error{
 		server.ValidateArgBatch("addresses", args, minipoolDetailsBatchSize, input.ValidateAddress, &c.MinipoolAddresses),
+		server.ValidateOptionalArg("export", args, input.ValidateBool, &c.Export, nil),
 	}
 	return c, errors.Join(inputErrs...)
 }
 
 func (f *minipoolCloseContextFactory) RegisterRoute(router *mux.Router) {
-	hdserver.RegisterSingleStageRoute[*MinipoolCloseContext, types.BatchTxInfoData](
+	hdserver.RegisterSingleStageRoute[*MinipoolCloseContext, csapi.MinipoolCloseData](
 		router, "close", f, f.handler.logger.Logger, f.handler.serviceProvider,
 	)
 }
@@ -52,6 +54,7 @@ func (f *minipoolCloseContextFactory) RegisterRoute(router *mux.Router) {
 type MinipoolCloseContext struct {
 	Handler           *MinipoolHandler
 	MinipoolAddresses []common.Address
+	Export            bool
 
 	nodeAddress  common.Address
 	mps          []rpminipool.IMinipool
@@ -64,17 +67,17 @@ func (c *MinipoolCloseContext) Initialize(walletStatus wallet.WalletStatus) (typ
 	rpMgr := sp.GetRocketPoolManager()
 	ctx := c.Handler.ctx
 
-	// Requirements
-	err := sp.RequireRegisteredWithConstellation(ctx, walletStatus, false)
+	// Requirements; exported TXs are signed elsewhere, so they only need the node address instead of a ready wallet
+	err := sp.RequireRegisteredWithConstellation(ctx, walletStatus, c.Export)
 	if err != nil {
 		if errors.Is(err, hdservices.ErrNodeAddressNotSet) {
-			return types.ResponseStatus_AddressNotPresent, nil
+			return types.ResponseStatus_AddressNotPresent, err
 		}
 		if errors.Is(err, hdservices.ErrExecutionClientNotSynced) {
-			return types.ResponseStatus_ClientsNotSynced, nil
+			return types.ResponseStatus_ClientsNotSynced, err
 		}
 		if errors.Is(err, cscommon.ErrNotRegisteredWithConstellation) {
-			return types.ResponseStatus_InvalidChainState, nil
+			return types.ResponseStatus_InvalidChainState, err
 		}
 		return types.ResponseStatus_Error, err
 	}
@@ -84,7 +87,7 @@ func (c *MinipoolCloseContext) Initialize(walletStatus wallet.WalletStatus) (typ
 	if err != nil {
 		return types.ResponseStatus_Error, fmt.Errorf("error refreshing Rocket Pool contracts: %w", err)
 	}
-	rp := rpMgr.RocketPool
+	rp := rpMgr.RocketPool()
 
 	// Create minipool bindings
 	mpMgr, err := rpminipool.NewMinipoolManager(rp)
@@ -114,13 +117,13 @@ func (c *MinipoolCloseContext) GetState(mc *batch.MultiCaller) {
 		)
 
 		// Check if the node operator owns the minipool
-		c.csMgr.SuperNodeAccount.SubNodeOperatorHasMinipool(mc, &c.mpOwnerFlags[i], c.nodeAddress, mpCommon.Address)
+		c.csMgr.Contracts().SuperNodeAccount.SubNodeOperatorHasMinipool(mc, &c.mpOwnerFlags[i], c.nodeAddress, mpCommon.Address)
 	}
 }
 
-func (c *MinipoolCloseContext) PrepareData(data *types.BatchTxInfoData, opts *bind.TransactOpts) (types.ResponseStatus, error) {
+func (c *MinipoolCloseContext) PrepareData(data *csapi.MinipoolCloseData, opts *bind.TransactOpts) (types.ResponseStatus, error) {
 	// Validation
-	supernodeAddress := c.csMgr.SuperNodeAccount.Address
+	supernodeAddress := c.csMgr.Contracts().SuperNodeAccount.Address
 	for i, mp := range c.mps {
 		mpCommon := mp.Common()
 		if mpCommon.NodeAddress.Get() != supernodeAddress {
@@ -138,7 +141,10 @@ func (c *MinipoolCloseContext) PrepareData(data *types.BatchTxInfoData, opts *bi
 	}
 
 	// TX Generation
-	supernode := c.csMgr.SuperNodeAccount
+	if c.Export {
+		opts = cscommon.GetExportTransactOpts(c.nodeAddress)
+	}
+	supernode := c.csMgr.Contracts().SuperNodeAccount
 	for _, mp := range c.mps {
 		mpCommon := mp.Common()
 		txInfo, err := supernode.Close(c.nodeAddress, mpCommon.Address, opts)
@@ -147,5 +153,15 @@ func (c *MinipoolCloseContext) PrepareData(data *types.BatchTxInfoData, opts *bi
 		}
 		data.TxInfos = append(data.TxInfos, txInfo)
 	}
+
+	// Export the TXs for offline signing if requested
+	if c.Export {
+		sp := c.Handler.serviceProvider
+		var err error
+		data.UnsignedTxs, err = cscommon.CreateUnsignedTransactions(c.Handler.ctx, sp.GetEthClient(), c.nodeAddress, data.TxInfos)
+		if err != nil {
+			return types.ResponseStatus_Error, fmt.Errorf("error exporting close TXs: %w", err)
+		}
+	}
 	return types.ResponseStatus_Success, nil
 }
@@ -49,6 +49,7 @@ func (f *minipoolCreateContextFactory) Create(args url.Values) (*MinipoolCreateC
 		nmcserver.ValidateArg("salt", args, input.ValidateBigInt, &c.Salt),
 		nmcserver.ValidateOptionalArg("skipLiquidityCheck", args, input.ValidateBool, &c.SkipLiquidityCheck, nil),
 		nmcserver.ValidateOptionalArg("skipBalanceCheck", args, input.ValidateBool, &c.SkipBalanceCheck, nil),
+		nmcserver.ValidateOptionalArg("export", args, input.ValidateBool, &c.Export, nil),
 	}
 	return c, errors.Join(inputErrs...)
 }
@@ -74,6 +75,7 @@ type MinipoolCreateContext struct {
 	Salt                    *big.Int
 	SkipLiquidityCheck      bool
 	SkipBalanceCheck        bool
+	Export                  bool
 
 	// Services
 	nodeAddress        common.Address
@@ -105,13 +107,20 @@ func (c *MinipoolCreateContext) Initialize(walletStatus wallet.WalletStatus) (ty
 	c.bn = sp.GetBeaconClient()
 	c.wallet = sp.GetWallet()
 
-	// Requirements
-	err := sp.RequireWalletReady(walletStatus)
-	if err != nil {
-		return types.ResponseStatus_WalletNotReady, err
+	// Requirements; exported TXs are signed elsewhere, so they only need the node address instead of a ready wallet
+	if c.Export {
+		err := sp.RequireNodeAddress(walletStatus)
+		if err != nil {
+			return types.ResponseStatus_AddressNotPresent, err
+		}
+	} else {
+		err := sp.RequireWalletReady(walletStatus)
+		if err != nil {
+			return types.ResponseStatus_WalletNotReady, err
+		}
 	}
 
-	err = sp.RequireEthClientSynced(ctx)
+	err := sp.RequireEthClientSynced(ctx)
 	if err != nil {
 		if errors.Is(err, services.ErrExecutionClientNotSynced) {
 			return types.ResponseStatus_ClientsNotSynced, err
@@ -139,26 +148,26 @@ func (c *MinipoolCreateContext) Initialize(walletStatus wallet.WalletStatus) (ty
 	}
 
 	// Create the bindings
-	superNodeAddress := c.csMgr.SuperNodeAccount.Address
-	c.rpSuperNodeBinding, err = node.NewNode(c.rpMgr.RocketPool, superNodeAddress)
+	superNodeAddress := c.csMgr.Contracts().SuperNodeAccount.Address
+	c.rpSuperNodeBinding, err = node.NewNode(c.rpMgr.RocketPool(), superNodeAddress)
 	if err != nil {
 		return types.ResponseStatus_Error, fmt.Errorf("error creating node %s binding: %w", superNodeAddress.Hex(), err)
 	}
-	c.pdaoMgr, err = protocol.NewProtocolDaoManager(c.rpMgr.RocketPool)
+	c.pdaoMgr, err = protocol.NewProtocolDaoManager(c.rpMgr.RocketPool())
 	if err != nil {
 		return types.ResponseStatus_Error, fmt.Errorf("error creating protocol dao manager binding: %w", err)
 	}
-	c.odaoMgr, err = oracle.NewOracleDaoManager(c.rpMgr.RocketPool)
+	c.odaoMgr, err = oracle.NewOracleDaoManager(c.rpMgr.RocketPool())
 	if err != nil {
 		return types.ResponseStatus_Error, fmt.Errorf("error creating oracle dao manager binding: %w", err)
 	}
-	c.mpMgr, err = minipool.NewMinipoolManager(c.rpMgr.RocketPool)
+	c.mpMgr, err = minipool.NewMinipoolManager(c.rpMgr.RocketPool())
 	if err != nil {
 		return types.ResponseStatus_Error, fmt.Errorf("error creating minipool manager binding: %w", err)
 	}
 
 	// Adjust the salt
-	c.nodeAddress = walletStatus.Wallet.WalletAddress
+	c.nodeAddress = walletStatus.Address.NodeAddress
 	saltBytes := [32]byte{}
 	c.Salt.FillBytes(saltBytes[:])
 	saltWithNodeAddress := crypto.Keccak256(saltBytes[:], c.nodeAddress[:])
@@ -168,11 +177,11 @@ func (c *MinipoolCreateContext) Initialize(walletStatus wallet.WalletStatus) (ty
 
 func (c *MinipoolCreateContext) GetState(mc *batch.MultiCaller) {
 	c.rpSuperNodeBinding.GetExpectedMinipoolAddress(mc, &c.ExpectedMinipoolAddress, c.internalSalt)
-	c.csMgr.SuperNodeAccount.LockThreshold(mc, &c.lockThreshold)
-	c.csMgr.SuperNodeAccount.Bond(mc, &c.minipoolBondAmount)
-	c.csMgr.Whitelist.IsAddressInWhitelist(mc, &c.isWhitelisted, c.nodeAddress)
-	c.csMgr.SuperNodeAccount.GetMaxValidators(mc, &c.maxActiveValidatorsPerNode)
-	c.csMgr.Whitelist.GetActiveValidatorCountForOperator(mc, &c.activeValidatorCount, c.nodeAddress)
+	c.csMgr.Contracts().SuperNodeAccount.LockThreshold(mc, &c.lockThreshold)
+	c.csMgr.Contracts().SuperNodeAccount.Bond(mc, &c.minipoolBondAmount)
+	c.csMgr.Contracts().Whitelist.IsAddressInWhitelist(mc, &c.isWhitelisted, c.nodeAddress)
+	c.csMgr.Contracts().SuperNodeAccount.GetMaxValidators(mc, &c.maxActiveValidatorsPerNode)
+	c.csMgr.Contracts().Whitelist.GetActiveValidatorCountForOperator(mc, &c.activeValidatorCount, c.nodeAddress)
 	eth.AddQueryablesToMulticall(mc,
 		c.pdaoMgr.Settings.Node.IsDepositingEnabled,
 		c.odaoMgr.Settings.Minipool.ScrubPeriod,
@@ -183,7 +192,6 @@ func (c *MinipoolCreateContext) GetState(mc *batch.MultiCaller) {
 func (c *MinipoolCreateContext) PrepareData(data *csapi.MinipoolCreateData, opts *bind.TransactOpts) (types.ResponseStatus, error) {
 	sp := c.ServiceProvider
 	hd := sp.GetHyperdriveClient()
-	hdResources := sp.GetHyperdriveResources()
 	csResources := sp.GetResources()
 	qMgr := sp.GetQueryManager()
 
@@ -196,7 +204,7 @@ func (c *MinipoolCreateContext) PrepareData(data *csapi.MinipoolCreateData, opts
 	case hdapi.NodeSetRegistrationStatus_Unknown:
 		return types.ResponseStatus_Error, fmt.Errorf("node registration status is unknown: %s", regResponse.Data.ErrorMessage)
 	case hdapi.NodeSetRegistrationStatus_NoWallet:
-		// Shouldn't get hit because of the requirement in Initialize
+		// Exports don't require a ready wallet, but Hyperdrive still needs one to derive the validator key
 		return types.ResponseStatus_WalletNotReady, fmt.Errorf("node does not have a wallet loaded")
 	case hdapi.NodeSetRegistrationStatus_Unregistered:
 		data.NotRegisteredWithNodeSet = true
@@ -225,7 +233,7 @@ func (c *MinipoolCreateContext) PrepareData(data *csapi.MinipoolCreateData, opts
 	if !c.SkipLiquidityCheck {
 		var hasSufficientLiquidity bool
 		err = qMgr.Query(func(mc *batch.MultiCaller) error {
-			c.csMgr.SuperNodeAccount.HasSufficientLiquidity(mc, &hasSufficientLiquidity, c.minipoolBondAmount)
+			c.csMgr.Contracts().SuperNodeAccount.HasSufficientLiquidity(mc, &hasSufficientLiquidity, c.minipoolBondAmount)
 			return nil
 		}, nil)
 		if err != nil {
@@ -311,9 +319,9 @@ func (c *MinipoolCreateContext) PrepareData(data *csapi.MinipoolCreateData, opts
 		c.Logger,
 		validatorKey.PrivateKey,
 		withdrawalCredentials,
-		hdResources.GenesisForkVersion,
+		csResources.GenesisForkVersion,
 		prelaunchValueGwei.Uint64(),
-		hdResources.EthNetworkName,
+		csResources.EthNetworkName,
 	)
 	if err != nil {
 		return types.ResponseStatus_Error, fmt.Errorf("error creating deposit data for validator [%s]: %w", validatorKey.PublicKey.Hex(), err)
@@ -325,12 +333,12 @@ func (c *MinipoolCreateContext) PrepareData(data *csapi.MinipoolCreateData, opts
 
 	// Make the TX
 	newOpts := &bind.TransactOpts{
-		From:  opts.From,
+		From:  c.nodeAddress,
 		Value: prelaunchValueWei,
 	}
 	depositDataSignature := beacon.ValidatorSignature(depositData.Signature)
 	depositDataRoot := common.BytesToHash(depositData.DepositDataRoot)
-	data.TxInfo, err = c.csMgr.SuperNodeAccount.CreateMinipool(
+	data.TxInfo, err = c.csMgr.Contracts().SuperNodeAccount.CreateMinipool(
 		validatorKey.PublicKey,
 		depositDataSignature,
 		depositDataRoot,
@@ -342,5 +350,15 @@ func (c *MinipoolCreateContext) PrepareData(data *csapi.MinipoolCreateData, opts
 	if err != nil {
 		return types.ResponseStatus_Error, err
 	}
+	data.SimulationRevert = cscommon.GetSimulationRevert(c.Context, c.ec, c.nodeAddress, data.TxInfo)
+
+	// Export it for offline signing if requested
+	if c.Export {
+		unsignedTxs, err := cscommon.CreateUnsignedTransactions(c.Context, c.ec, c.nodeAddress, []*eth.TransactionInfo{data.TxInfo})
+		if err != nil {
+			return types.ResponseStatus_Error, fmt.Errorf("error exporting minipool creation TX: %w", err)
+		}
+		data.UnsignedTx = unsignedTxs[0]
+	}
 	return types.ResponseStatus_Success, nil
 }
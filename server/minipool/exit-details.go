@@ -41,6 +41,7 @@ func (f *minipoolExitDetailsContextFactory) Create(args url.Values) (*MinipoolEx
 	}
 	inputErrs := []error{
 		nmcserver.ValidateArg("verbose", args, input.ValidateBool, &c.Verbose),
+		nmcserver.ValidateOptionalArg("node-address", args, input.ValidateAddress, &c.NodeAddress, &c.HasNodeAddress),
 	}
 	return c, errors.Join(inputErrs...)
 }
@@ -62,7 +63,13 @@ type MinipoolExitDetailsContext struct {
 	Context         context.Context
 
 	// Arguments
-	Verbose bool
+	Verbose        bool
+	NodeAddress    common.Address
+	HasNodeAddress bool
+}
+
+func (c *MinipoolExitDetailsContext) GetNodeAddressOverride() (common.Address, bool) {
+	return c.NodeAddress, c.HasNodeAddress
 }
 
 func (c *MinipoolExitDetailsContext) Initialize(walletStatus wallet.WalletStatus) (types.ResponseStatus, error) {
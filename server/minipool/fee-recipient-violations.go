@@ -0,0 +1,83 @@
+package csminipool
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/gorilla/mux"
+	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/server"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/services"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/wallet"
+	"github.com/rocket-pool/rocketpool-go/v2/rocketpool"
+)
+
+// ===============
+// === Factory ===
+// ===============
+
+type minipoolFeeRecipientViolationsContextFactory struct {
+	handler *MinipoolHandler
+}
+
+func (f *minipoolFeeRecipientViolationsContextFactory) Create(args url.Values) (*minipoolFeeRecipientViolationsContext, error) {
+	c := &minipoolFeeRecipientViolationsContext{
+		handler: f.handler,
+	}
+	return c, nil
+}
+
+func (f *minipoolFeeRecipientViolationsContextFactory) RegisterRoute(router *mux.Router) {
+	server.RegisterQuerylessGet[*minipoolFeeRecipientViolationsContext, csapi.MinipoolFeeRecipientViolationsData](
+		router, "fee-recipient-violations", f, f.handler.logger.Logger, f.handler.serviceProvider,
+	)
+}
+
+// ===============
+// === Context ===
+// ===============
+
+type minipoolFeeRecipientViolationsContext struct {
+	handler *MinipoolHandler
+}
+
+func (c *minipoolFeeRecipientViolationsContext) PrepareData(data *csapi.MinipoolFeeRecipientViolationsData, walletStatus wallet.WalletStatus, opts *bind.TransactOpts) (types.ResponseStatus, error) {
+	sp := c.handler.serviceProvider
+	ctx := c.handler.ctx
+	rpMgr := sp.GetRocketPoolManager()
+	res := sp.GetResources()
+	monitor := sp.GetFeeRecipientMonitor()
+
+	// Requirements
+	err := sp.RequireEthClientSynced(ctx)
+	if err != nil {
+		if errors.Is(err, services.ErrExecutionClientNotSynced) {
+			return types.ResponseStatus_ClientsNotSynced, err
+		}
+		return types.ResponseStatus_Error, err
+	}
+
+	// Refresh RP
+	err = rpMgr.RefreshRocketPoolContracts()
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error refreshing Rocket Pool contracts: %w", err)
+	}
+
+	// Get the smoothing pool address
+	smoothingPool, err := rpMgr.RocketPool().GetContract(rocketpool.ContractName_RocketSmoothingPool)
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error getting smoothing pool contract: %w", err)
+	}
+
+	data.ConfiguredFeeRecipient = *res.FeeRecipient
+	data.SmoothingPoolAddress = smoothingPool.Address
+	data.ConfigMismatch = data.ConfiguredFeeRecipient != data.SmoothingPoolAddress
+	data.ExpectedGraffiti = cscommon.GetExpectedGraffiti(sp.GetConfig())
+	data.LastCheckedEpoch, data.HasScanned = monitor.GetLastCheckedEpoch()
+	data.Violations = monitor.GetViolations()
+	return types.ResponseStatus_Success, nil
+}
@@ -0,0 +1,93 @@
+package csminipool
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/gorilla/mux"
+	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/server"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/services"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/log"
+	"github.com/rocket-pool/node-manager-core/wallet"
+)
+
+// ===============
+// === Factory ===
+// ===============
+
+type minipoolFleetStatusContextFactory struct {
+	handler *MinipoolHandler
+}
+
+func (f *minipoolFleetStatusContextFactory) Create(args url.Values) (*minipoolFleetStatusContext, error) {
+	c := &minipoolFleetStatusContext{
+		handler: f.handler,
+	}
+	return c, nil
+}
+
+func (f *minipoolFleetStatusContextFactory) RegisterRoute(router *mux.Router) {
+	server.RegisterQuerylessGet[*minipoolFleetStatusContext, csapi.MinipoolFleetStatusData](
+		router, "fleet-status", f, f.handler.logger.Logger, f.handler.serviceProvider,
+	)
+}
+
+// ===============
+// === Context ===
+// ===============
+
+type minipoolFleetStatusContext struct {
+	handler *MinipoolHandler
+}
+
+func (c *minipoolFleetStatusContext) PrepareData(data *csapi.MinipoolFleetStatusData, walletStatus wallet.WalletStatus, opts *bind.TransactOpts) (types.ResponseStatus, error) {
+	sp := c.handler.serviceProvider
+	ctx := c.handler.ctx
+	logger := c.handler.logger
+
+	// Requirements
+	err := sp.RequireEthClientSynced(ctx)
+	if err != nil {
+		if errors.Is(err, services.ErrExecutionClientNotSynced) {
+			return types.ResponseStatus_ClientsNotSynced, err
+		}
+		return types.ResponseStatus_Error, err
+	}
+	nodes, err := cscommon.GetFleetNodes(sp.GetConfig(), walletStatus)
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error getting fleet nodes: %w", err)
+	}
+
+	// Get the status of each node; a failure on one node shouldn't stop the others from being reported
+	data.Nodes = make([]csapi.MinipoolFleetNodeStatus, len(nodes))
+	for i, node := range nodes {
+		nodeStatus := &data.Nodes[i]
+		nodeStatus.NodeAddress = node.Address
+		nodeStatus.IsDaemonNode = node.IsDaemonNode
+
+		mpContext := &MinipoolStatusContext{
+			ServiceProvider:   sp,
+			Logger:            logger.Logger,
+			Context:           ctx,
+			SnServiceProvider: c.handler.serviceProvider.GetSmartNodeServiceProvider(),
+			IsMonitoredNode:   !node.IsDaemonNode,
+		}
+		_, response, err := runMinipoolContextForNode[csapi.MinipoolStatusData](ctx, mpContext, sp, walletStatus, node.Address, opts)
+		if err != nil {
+			logger.Warn("Error getting minipool status for fleet node",
+				slog.String("node", node.Address.Hex()),
+				log.Err(err),
+			)
+			nodeStatus.Error = err.Error()
+			continue
+		}
+		nodeStatus.Status = response.Data
+	}
+	return types.ResponseStatus_Success, nil
+}
@@ -39,6 +39,7 @@ func (f *minipoolGetPubkeysContextFactory) Create(args url.Values) (*minipoolGet
 	}
 	inputErrs := []error{
 		nmcserver.ValidateArg("includeExited", args, input.ValidateBool, &c.includeExited),
+		nmcserver.ValidateOptionalArg("node-address", args, input.ValidateAddress, &c.nodeAddress, &c.hasNodeAddress),
 	}
 	return c, errors.Join(inputErrs...)
 }
@@ -54,8 +55,10 @@ func (f *minipoolGetPubkeysContextFactory) RegisterRoute(router *mux.Router) {
 // ===============
 
 type minipoolGetPubkeysContext struct {
-	handler       *MinipoolHandler
-	includeExited bool
+	handler        *MinipoolHandler
+	includeExited  bool
+	nodeAddress    common.Address
+	hasNodeAddress bool
 }
 
 func (c *minipoolGetPubkeysContext) PrepareData(data *csapi.MinipoolGetPubkeysData, walletStatus wallet.WalletStatus, opts *bind.TransactOpts) (types.ResponseStatus, error) {
@@ -66,12 +69,16 @@ func (c *minipoolGetPubkeysContext) PrepareData(data *csapi.MinipoolGetPubkeysDa
 	qMgr := sp.GetQueryManager()
 	bn := sp.GetBeaconClient()
 
-	// Requirements
-	err := sp.RequireWalletReady(walletStatus)
-	if err != nil {
-		return types.ResponseStatus_WalletNotReady, err
+	// Requirements - the wallet is only needed if another sub-node wasn't requested
+	subNodeAddress := c.nodeAddress
+	if !c.hasNodeAddress {
+		err := sp.RequireWalletReady(walletStatus)
+		if err != nil {
+			return types.ResponseStatus_WalletNotReady, err
+		}
+		subNodeAddress = walletStatus.Wallet.WalletAddress
 	}
-	err = sp.RequireEthClientSynced(ctx)
+	err := sp.RequireEthClientSynced(ctx)
 	if err != nil {
 		if errors.Is(err, services.ErrExecutionClientNotSynced) {
 			return types.ResponseStatus_ClientsNotSynced, err
@@ -99,15 +106,15 @@ func (c *minipoolGetPubkeysContext) PrepareData(data *csapi.MinipoolGetPubkeysDa
 	}
 
 	// Bindings
-	mpMgr, err := minipool.NewMinipoolManager(rpMgr.RocketPool)
+	mpMgr, err := minipool.NewMinipoolManager(rpMgr.RocketPool())
 	if err != nil {
 		return types.ResponseStatus_Error, fmt.Errorf("error creating minipool manager binding: %w", err)
 	}
 
-	// Get the list of validators for the node wallet
+	// Get the list of validators for the sub-node
 	var minipools []common.Address
 	err = qMgr.Query(func(mc *batch.MultiCaller) error {
-		csMgr.SuperNodeAccount.GetSubNodeMinipools(mc, &minipools, walletStatus.Wallet.WalletAddress)
+		csMgr.Contracts().SuperNodeAccount.GetSubNodeMinipools(mc, &minipools, subNodeAddress)
 		return nil
 	}, nil)
 	if err != nil {
@@ -7,23 +7,20 @@ import (
 	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
 	"github.com/rocket-pool/node-manager-core/api/server"
 	"github.com/rocket-pool/node-manager-core/log"
-	snservices "github.com/rocket-pool/smartnode/v2/rocketpool-daemon/common/services"
 )
 
 type MinipoolHandler struct {
-	logger            *log.Logger
-	ctx               context.Context
-	serviceProvider   cscommon.IConstellationServiceProvider
-	factories         []server.IContextFactory
-	snServiceProvider snservices.ISmartNodeServiceProvider
+	logger          *log.Logger
+	ctx             context.Context
+	serviceProvider cscommon.IConstellationServiceProvider
+	factories       []server.IContextFactory
 }
 
 func NewMinipoolHandler(logger *log.Logger, ctx context.Context, serviceProvider cscommon.IConstellationServiceProvider) *MinipoolHandler {
 	h := &MinipoolHandler{
-		logger:            logger,
-		ctx:               ctx,
-		serviceProvider:   serviceProvider,
-		snServiceProvider: serviceProvider.GetSmartNodeServiceProvider(),
+		logger:          logger,
+		ctx:             ctx,
+		serviceProvider: serviceProvider,
 	}
 	h.factories = []server.IContextFactory{
 		&minipoolCloseDetailsContextFactory{h},
@@ -31,6 +28,9 @@ func NewMinipoolHandler(logger *log.Logger, ctx context.Context, serviceProvider
 		&minipoolExitContextFactory{h},
 		&minipoolExitDetailsContextFactory{h},
 		&minipoolCreateContextFactory{h},
+		&minipoolFeeRecipientViolationsContextFactory{h},
+		&minipoolFleetStatusContextFactory{h},
+		&minipoolPerformanceContextFactory{h},
 		&minipoolStakeContextFactory{h},
 		&minipoolStatusContextFactory{h},
 		&minipoolUploadSignedExitsContextFactory{h},
@@ -47,6 +47,13 @@ type IMinipoolCallContext[DataType any] interface {
 	PrepareData(addresses []common.Address, mps []minipool.IMinipool, data *DataType, blockHeader *ethtypes.Header, opts *bind.TransactOpts) (types.ResponseStatus, error)
 }
 
+// Optional interface for minipool call contexts that can be run against a sub-node other than the daemon's own node.
+// Those runs are read-only, so the context won't be given a transactor.
+type IMinipoolNodeOverrideContext interface {
+	// Get the address of the sub-node to query; the bool will be false if the daemon's own node should be used
+	GetNodeAddressOverride() (common.Address, bool)
+}
+
 // Interface for minipool call context factories - these will be invoked during route handling to create the
 // unique context for the route
 type IMinipoolCallContextFactory[ContextType IMinipoolCallContext[DataType], DataType any] interface {
@@ -102,9 +109,6 @@ func RegisterMinipoolRoute[ContextType IMinipoolCallContext[DataType], DataType
 func runMinipoolRoute[DataType any](ctx context.Context, mpContext IMinipoolCallContext[DataType], serviceProvider cscommon.IConstellationServiceProvider) (types.ResponseStatus, *types.ApiResponse[DataType], error) {
 	// Get the services
 	hd := serviceProvider.GetHyperdriveClient()
-	csMgr := serviceProvider.GetConstellationManager()
-	rpMgr := serviceProvider.GetRocketPoolManager()
-	qMgr := serviceProvider.GetQueryManager()
 	signer := serviceProvider.GetSigner()
 
 	// Get the wallet status
@@ -120,12 +124,31 @@ func runMinipoolRoute[DataType any](ctx context.Context, mpContext IMinipoolCall
 		txOpts = signer.GetTransactor(walletStatus.Wallet.WalletAddress)
 	}
 
+	// Run against another sub-node in read-only mode if requested
+	if overrideContext, ok := mpContext.(IMinipoolNodeOverrideContext); ok {
+		subNodeAddress, hasOverride := overrideContext.GetNodeAddressOverride()
+		if hasOverride {
+			return runMinipoolContextForNode[DataType](ctx, mpContext, serviceProvider, walletStatus, subNodeAddress, nil)
+		}
+	}
+
 	// Common requirements
 	err = serviceProvider.RequireNodeAddress(walletStatus)
 	if err != nil {
 		return types.ResponseStatus_AddressNotPresent, nil, err
 	}
-	err = serviceProvider.RequireEthClientSynced(ctx)
+	return runMinipoolContextForNode[DataType](ctx, mpContext, serviceProvider, walletStatus, walletStatus.Address.NodeAddress, txOpts)
+}
+
+// Run a minipool call context against the minipools belonging to the given node, which doesn't have to be the daemon's own node
+func runMinipoolContextForNode[DataType any](ctx context.Context, mpContext IMinipoolCallContext[DataType], serviceProvider cscommon.IConstellationServiceProvider, walletStatus wallet.WalletStatus, subNodeAddress common.Address, txOpts *bind.TransactOpts) (types.ResponseStatus, *types.ApiResponse[DataType], error) {
+	// Get the services
+	csMgr := serviceProvider.GetConstellationManager()
+	rpMgr := serviceProvider.GetRocketPoolManager()
+	qMgr := serviceProvider.GetQueryManager()
+
+	// Common requirements
+	err := serviceProvider.RequireEthClientSynced(ctx)
 	if err != nil {
 		if errors.Is(err, services.ErrExecutionClientNotSynced) {
 			return types.ResponseStatus_ClientsNotSynced, nil, err
@@ -142,7 +165,7 @@ func runMinipoolRoute[DataType any](ctx context.Context, mpContext IMinipoolCall
 	if err != nil {
 		return types.ResponseStatus_Error, nil, fmt.Errorf("error refreshing Rocket Pool contracts: %w", err)
 	}
-	rp := rpMgr.RocketPool
+	rp := rpMgr.RocketPool()
 
 	// Get the latest block for consistency
 	latestBlockHeader, err := serviceProvider.GetEthClient().HeaderByNumber(ctx, nil)
@@ -154,7 +177,7 @@ func runMinipoolRoute[DataType any](ctx context.Context, mpContext IMinipoolCall
 	}
 
 	// Create the bindings
-	nodeAddress := csMgr.SuperNodeAccount.Address
+	nodeAddress := csMgr.Contracts().SuperNodeAccount.Address
 	node, err := node.NewNode(rp, nodeAddress)
 	if err != nil {
 		return types.ResponseStatus_Error, nil, fmt.Errorf("error creating node %s binding: %w", nodeAddress.Hex(), err)
@@ -169,7 +192,7 @@ func runMinipoolRoute[DataType any](ctx context.Context, mpContext IMinipoolCall
 	// Get the minipool addresses belonging to the node and the initial chain state
 	var addresses []common.Address
 	err = qMgr.Query(func(mc *batch.MultiCaller) error {
-		csMgr.SuperNodeAccount.GetSubNodeMinipools(mc, &addresses, walletStatus.Address.NodeAddress)
+		csMgr.Contracts().SuperNodeAccount.GetSubNodeMinipools(mc, &addresses, subNodeAddress)
 		mpContext.GetState(node, mc)
 		return nil
 	}, callOpts)
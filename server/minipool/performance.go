@@ -0,0 +1,321 @@
+package csminipool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/gorilla/mux"
+	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/services"
+	batch "github.com/rocket-pool/batch-query"
+	nmcserver "github.com/rocket-pool/node-manager-core/api/server"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/rocket-pool/node-manager-core/utils/input"
+	"github.com/rocket-pool/node-manager-core/wallet"
+	"github.com/rocket-pool/rocketpool-go/v2/minipool"
+	"github.com/rocket-pool/rocketpool-go/v2/node"
+)
+
+const (
+	// Number of epochs to report on if a start epoch isn't provided
+	defaultPerformanceEpochCount uint64 = 10
+
+	// Max number of epochs that can be reported on in one request, since every slot in the range (and the epoch after
+	// it) has to be fetched from the Beacon Node
+	maxPerformanceEpochCount uint64 = 20
+)
+
+// The location of a validator's attestation duty within a committee
+type attestationDuty struct {
+	position uint64
+	index    string
+}
+
+// A unique committee for a given slot
+type committeeKey struct {
+	slot           uint64
+	committeeIndex uint64
+}
+
+// ===============
+// === Factory ===
+// ===============
+
+type minipoolPerformanceContextFactory struct {
+	handler *MinipoolHandler
+}
+
+func (f *minipoolPerformanceContextFactory) Create(args url.Values) (*MinipoolPerformanceContext, error) {
+	c := &MinipoolPerformanceContext{
+		ServiceProvider: f.handler.serviceProvider,
+		Logger:          f.handler.logger.Logger,
+		Context:         f.handler.ctx,
+	}
+	inputErrs := []error{
+		nmcserver.ValidateOptionalArg("startEpoch", args, input.ValidateUint, &c.StartEpoch, &c.HasStartEpoch),
+		nmcserver.ValidateOptionalArg("endEpoch", args, input.ValidateUint, &c.EndEpoch, &c.HasEndEpoch),
+	}
+	return c, errors.Join(inputErrs...)
+}
+
+func (f *minipoolPerformanceContextFactory) RegisterRoute(router *mux.Router) {
+	RegisterMinipoolRoute[*MinipoolPerformanceContext, csapi.MinipoolPerformanceData](
+		router, "performance", f, f.handler.ctx, f.handler.logger.Logger, f.handler.serviceProvider,
+	)
+}
+
+// ===============
+// === Context ===
+// ===============
+
+type MinipoolPerformanceContext struct {
+	// Dependencies
+	ServiceProvider cscommon.IConstellationServiceProvider
+	Logger          *slog.Logger
+	Context         context.Context
+
+	// Arguments
+	StartEpoch    uint64
+	HasStartEpoch bool
+	EndEpoch      uint64
+	HasEndEpoch   bool
+}
+
+func (c *MinipoolPerformanceContext) Initialize(walletStatus wallet.WalletStatus) (types.ResponseStatus, error) {
+	err := c.ServiceProvider.RequireBeaconClientSynced(c.Context)
+	if err != nil {
+		if errors.Is(err, services.ErrBeaconNodeNotSynced) {
+			return types.ResponseStatus_ClientsNotSynced, err
+		}
+		return types.ResponseStatus_Error, err
+	}
+	return types.ResponseStatus_Success, nil
+}
+
+func (c *MinipoolPerformanceContext) GetState(node *node.Node, mc *batch.MultiCaller) {
+}
+
+func (c *MinipoolPerformanceContext) CheckState(node *node.Node, response *csapi.MinipoolPerformanceData) bool {
+	return true
+}
+
+func (c *MinipoolPerformanceContext) GetMinipoolDetails(mc *batch.MultiCaller, mp minipool.IMinipool, index int) {
+	mp.Common().Pubkey.AddToQuery(mc)
+}
+
+func (c *MinipoolPerformanceContext) PrepareData(addresses []common.Address, mps []minipool.IMinipool, data *csapi.MinipoolPerformanceData, blockHeader *ethtypes.Header, opts *bind.TransactOpts) (types.ResponseStatus, error) {
+	bn := c.ServiceProvider.GetBeaconClient()
+	beaconApi := c.ServiceProvider.GetBeaconApiClient()
+	beaconCfg, err := bn.GetEth2Config(c.Context)
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error getting Beacon config: %w", err)
+	}
+	beaconHead, err := bn.GetBeaconHead(c.Context)
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error getting Beacon head: %w", err)
+	}
+	data.CurrentEpoch = beaconHead.Epoch
+
+	// Get the epoch range; attestations for an epoch can be included until the end of the following one,
+	// so the last epoch in the range must be followed by a completed epoch
+	if beaconHead.Epoch < 2 {
+		return types.ResponseStatus_InvalidChainState, fmt.Errorf("the Beacon chain hasn't completed enough epochs to report on yet")
+	}
+	latestEpoch := beaconHead.Epoch - 2
+	data.EndEpoch = latestEpoch
+	if c.HasEndEpoch {
+		if c.EndEpoch > latestEpoch {
+			return types.ResponseStatus_InvalidArguments, fmt.Errorf("end epoch %d is too recent, the latest epoch that can be reported on is %d", c.EndEpoch, latestEpoch)
+		}
+		data.EndEpoch = c.EndEpoch
+	}
+	if c.HasStartEpoch {
+		data.StartEpoch = c.StartEpoch
+	} else if data.EndEpoch+1 > defaultPerformanceEpochCount {
+		data.StartEpoch = data.EndEpoch + 1 - defaultPerformanceEpochCount
+	}
+	if data.StartEpoch > data.EndEpoch {
+		return types.ResponseStatus_InvalidArguments, fmt.Errorf("start epoch %d is after end epoch %d", data.StartEpoch, data.EndEpoch)
+	}
+	if data.EndEpoch-data.StartEpoch+1 > maxPerformanceEpochCount {
+		return types.ResponseStatus_InvalidArguments, fmt.Errorf("epoch range is too large, at most %d epochs can be reported on at once", maxPerformanceEpochCount)
+	}
+
+	// Get the validator indices
+	details := make([]csapi.MinipoolPerformanceDetails, len(mps))
+	pubkeys := make([]beacon.ValidatorPubkey, len(mps))
+	for i, mp := range mps {
+		mpCommon := mp.Common()
+		details[i].Address = mpCommon.Address
+		details[i].Pubkey = mpCommon.Pubkey.Get()
+		pubkeys[i] = details[i].Pubkey
+	}
+	data.Details = details
+	statuses, err := bn.GetValidatorStatuses(c.Context, pubkeys, nil)
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error getting validator statuses: %w", err)
+	}
+	detailsByIndex := map[string]*csapi.MinipoolPerformanceDetails{}
+	indices := []string{}
+	for i := range details {
+		mpDetails := &details[i]
+		status, exists := statuses[mpDetails.Pubkey]
+		if !exists || status.Index == "" {
+			mpDetails.ValidatorNotSeenYet = true
+			continue
+		}
+		mpDetails.Index = status.Index
+		detailsByIndex[status.Index] = mpDetails
+		indices = append(indices, status.Index)
+	}
+	if len(indices) == 0 {
+		return types.ResponseStatus_Success, nil
+	}
+
+	// Get the attestation and proposal duties for each epoch
+	pendingAttestations := map[committeeKey][]attestationDuty{}
+	proposalSlots := map[uint64]string{}
+	for epoch := data.StartEpoch; epoch <= data.EndEpoch; epoch++ {
+		err = c.getAttestationDuties(epoch, detailsByIndex, pendingAttestations)
+		if err != nil {
+			return types.ResponseStatus_Error, err
+		}
+
+		proposerDuties, err := beaconApi.GetProposerDuties(c.Context, indices, epoch)
+		if err != nil {
+			return types.ResponseStatus_Error, err
+		}
+		for _, duty := range proposerDuties {
+			if mpDetails, exists := detailsByIndex[duty.ValidatorIndex]; exists {
+				mpDetails.ProposalsExpected++
+				proposalSlots[duty.Slot] = duty.ValidatorIndex
+			}
+		}
+	}
+
+	// Get the sync committee positions for each period in the range, since they only change between periods
+	syncPositions := map[uint64]map[string][]uint64{}
+	for epoch := data.StartEpoch; epoch <= data.EndEpoch; {
+		period := epoch / beaconCfg.EpochsPerSyncCommitteePeriod
+		positions, err := beaconApi.GetSyncCommitteePositions(c.Context, indices, epoch)
+		if err != nil {
+			return types.ResponseStatus_Error, fmt.Errorf("%w (Beacon Nodes only provide sync committees for the current and next periods, so the start epoch may be too old)", err)
+		}
+		syncPositions[period] = positions
+
+		nextPeriodStart := (period + 1) * beaconCfg.EpochsPerSyncCommitteePeriod
+		for ; epoch < nextPeriodStart && epoch <= data.EndEpoch; epoch++ {
+			for index := range positions {
+				detailsByIndex[index].SyncCommitteeEpochs++
+			}
+		}
+	}
+
+	// Scan the blocks in the range, plus the following epoch for late attestation inclusions
+	firstSlot := data.StartEpoch * beaconCfg.SlotsPerEpoch
+	lastProposalSlot := (data.EndEpoch+1)*beaconCfg.SlotsPerEpoch - 1
+	lastSlot := lastProposalSlot + beaconCfg.SlotsPerEpoch
+	for slot := firstSlot; slot <= lastSlot; slot++ {
+		block, exists, err := beaconApi.GetBlock(c.Context, slot)
+		if err != nil {
+			return types.ResponseStatus_Error, err
+		}
+		if !exists {
+			continue
+		}
+
+		if slot <= lastProposalSlot {
+			// Check the proposer
+			if index, isOurs := proposalSlots[slot]; isOurs && block.ProposerIndex == index {
+				detailsByIndex[index].ProposalsMade++
+			}
+
+			// Check the sync committee members; each block's sync aggregate is signed by the committee for its slot
+			if block.HasSyncAggregate {
+				period := slot / beaconCfg.SlotsPerEpoch / beaconCfg.EpochsPerSyncCommitteePeriod
+				for index, positions := range syncPositions[period] {
+					mpDetails := detailsByIndex[index]
+					for _, position := range positions {
+						mpDetails.SyncDutiesExpected++
+						if !block.SyncCommitteeBitAt(position) {
+							mpDetails.SyncDutiesMissed++
+						}
+					}
+				}
+			}
+		}
+
+		// Mark any included attestations as complete
+		for _, attestation := range block.Attestations {
+			key := committeeKey{
+				slot:           attestation.SlotIndex,
+				committeeIndex: attestation.CommitteeIndex,
+			}
+			duties, exists := pendingAttestations[key]
+			if !exists {
+				continue
+			}
+			remaining := []attestationDuty{}
+			for _, duty := range duties {
+				if !attestation.AggregationBits.BitAt(duty.position) {
+					remaining = append(remaining, duty)
+				}
+			}
+			if len(remaining) == 0 {
+				delete(pendingAttestations, key)
+			} else {
+				pendingAttestations[key] = remaining
+			}
+		}
+	}
+
+	// Anything still pending was never included
+	for _, duties := range pendingAttestations {
+		for _, duty := range duties {
+			detailsByIndex[duty.index].AttestationsMissed++
+		}
+	}
+	for _, mpDetails := range detailsByIndex {
+		if mpDetails.ProposalsMade < mpDetails.ProposalsExpected {
+			mpDetails.ProposalsMissed = mpDetails.ProposalsExpected - mpDetails.ProposalsMade
+		}
+	}
+	return types.ResponseStatus_Success, nil
+}
+
+// Get the attestation duties for the node's validators in the given epoch, adding them to the pending attestations map
+func (c *MinipoolPerformanceContext) getAttestationDuties(epoch uint64, detailsByIndex map[string]*csapi.MinipoolPerformanceDetails, pendingAttestations map[committeeKey][]attestationDuty) error {
+	bn := c.ServiceProvider.GetBeaconClient()
+	committees, err := bn.GetCommitteesForEpoch(c.Context, &epoch)
+	if err != nil {
+		return fmt.Errorf("error getting committees for epoch %d: %w", epoch, err)
+	}
+	defer committees.Release()
+
+	for i := 0; i < committees.Count(); i++ {
+		key := committeeKey{
+			slot:           committees.Slot(i),
+			committeeIndex: committees.Index(i),
+		}
+		for position, index := range committees.Validators(i) {
+			mpDetails, exists := detailsByIndex[index]
+			if !exists {
+				continue
+			}
+			mpDetails.AttestationsExpected++
+			pendingAttestations[key] = append(pendingAttestations[key], attestationDuty{
+				position: uint64(position),
+				index:    index,
+			})
+		}
+	}
+	return nil
+}
@@ -20,10 +20,12 @@ import (
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/gorilla/mux"
 	batch "github.com/rocket-pool/batch-query"
+	nmcserver "github.com/rocket-pool/node-manager-core/api/server"
 	"github.com/rocket-pool/node-manager-core/api/types"
 	"github.com/rocket-pool/node-manager-core/beacon"
 	"github.com/rocket-pool/node-manager-core/eth"
 	"github.com/rocket-pool/node-manager-core/node/validator"
+	"github.com/rocket-pool/node-manager-core/utils/input"
 	"github.com/rocket-pool/node-manager-core/wallet"
 
 	"github.com/rocket-pool/rocketpool-go/v2/dao/oracle"
@@ -47,7 +49,9 @@ func (f *minipoolStakeContextFactory) Create(args url.Values) (*MinipoolStakeCon
 		Logger:          f.handler.logger.Logger,
 		Context:         f.handler.ctx,
 	}
-	inputErrs := []error{}
+	inputErrs := []error{
+		nmcserver.ValidateOptionalArg("export", args, input.ValidateBool, &c.Export, nil),
+	}
 	return c, errors.Join(inputErrs...)
 }
 
@@ -67,6 +71,9 @@ type MinipoolStakeContext struct {
 	Logger          *slog.Logger
 	Context         context.Context
 
+	// Inputs
+	Export bool
+
 	// Services
 	nodeAddress common.Address
 	res         *csconfig.MergedResources
@@ -93,13 +100,20 @@ func (c *MinipoolStakeContext) Initialize(walletStatus wallet.WalletStatus) (typ
 	c.res = sp.GetResources()
 	c.wallet = sp.GetWallet()
 
-	// Requirements
-	err := sp.RequireWalletReady(walletStatus)
-	if err != nil {
-		return types.ResponseStatus_WalletNotReady, err
+	// Requirements; exported TXs are signed elsewhere, so they only need the node address instead of a ready wallet
+	if c.Export {
+		err := sp.RequireNodeAddress(walletStatus)
+		if err != nil {
+			return types.ResponseStatus_AddressNotPresent, err
+		}
+	} else {
+		err := sp.RequireWalletReady(walletStatus)
+		if err != nil {
+			return types.ResponseStatus_WalletNotReady, err
+		}
 	}
 
-	err = sp.RequireEthClientSynced(ctx)
+	err := sp.RequireEthClientSynced(ctx)
 	if err != nil {
 		if errors.Is(err, services.ErrExecutionClientNotSynced) {
 			return types.ResponseStatus_ClientsNotSynced, err
@@ -127,15 +141,15 @@ func (c *MinipoolStakeContext) Initialize(walletStatus wallet.WalletStatus) (typ
 	}
 
 	// Bindings
-	c.odaoMgr, err = oracle.NewOracleDaoManager(c.rpMgr.RocketPool)
+	c.odaoMgr, err = oracle.NewOracleDaoManager(c.rpMgr.RocketPool())
 	if err != nil {
 		return types.ResponseStatus_Error, fmt.Errorf("error creating oDAO manager binding: %w", err)
 	}
-	c.pdaoMgr, err = protocol.NewProtocolDaoManager(c.rpMgr.RocketPool)
+	c.pdaoMgr, err = protocol.NewProtocolDaoManager(c.rpMgr.RocketPool())
 	if err != nil {
 		return types.ResponseStatus_Error, fmt.Errorf("error creating pDAO manager binding: %w", err)
 	}
-	c.mpMgr, err = minipool.NewMinipoolManager(c.rpMgr.RocketPool)
+	c.mpMgr, err = minipool.NewMinipoolManager(c.rpMgr.RocketPool())
 	if err != nil {
 		return types.ResponseStatus_Error, fmt.Errorf("error creating minipool manager binding: %w", err)
 	}
@@ -145,7 +159,7 @@ func (c *MinipoolStakeContext) Initialize(walletStatus wallet.WalletStatus) (typ
 }
 
 func (c *MinipoolStakeContext) GetState(node *node.Node, mc *batch.MultiCaller) {
-	c.csMgr.Whitelist.IsAddressInWhitelist(mc, &c.isWhitelisted, c.nodeAddress)
+	c.csMgr.Contracts().Whitelist.IsAddressInWhitelist(mc, &c.isWhitelisted, c.nodeAddress)
 	eth.AddQueryablesToMulticall(mc,
 		c.odaoMgr.Settings.Minipool.ScrubPeriod,
 		c.pdaoMgr.Settings.Minipool.LaunchTimeout,
@@ -183,6 +197,9 @@ func (c *MinipoolStakeContext) PrepareData(addresses []common.Address, mps []min
 	c.stakeValueGwei = stakeValueGwei.Uint64()
 
 	// Process each minipool
+	if c.Export {
+		opts = cscommon.GetExportTransactOpts(c.nodeAddress)
+	}
 	for _, mp := range mps {
 		details, err := c.getMinipoolStakeDetails(mp, opts)
 		if err != nil {
@@ -196,6 +213,21 @@ func (c *MinipoolStakeContext) PrepareData(addresses []common.Address, mps []min
 			data.Details = append(data.Details, *details)
 		}
 	}
+
+	// Export the TXs for offline signing if requested
+	if c.Export {
+		txInfos := []*eth.TransactionInfo{}
+		for _, details := range data.Details {
+			if details.CanStake {
+				txInfos = append(txInfos, details.TxInfo)
+			}
+		}
+		var err error
+		data.UnsignedTxs, err = cscommon.CreateUnsignedTransactions(c.Context, c.ServiceProvider.GetEthClient(), c.nodeAddress, txInfos)
+		if err != nil {
+			return types.ResponseStatus_Error, fmt.Errorf("error exporting stake TXs: %w", err)
+		}
+	}
 	return types.ResponseStatus_Success, nil
 }
 
@@ -267,10 +299,13 @@ func (c *MinipoolStakeContext) getMinipoolStakeDetails(mp minipool.IMinipool, op
 	// Make the stake TX
 	signature := beacon.ValidatorSignature(depositData.Signature)
 	depositDataRoot := common.BytesToHash(depositData.DepositDataRoot)
-	txInfo, err := c.csMgr.SuperNodeAccount.Stake(signature, depositDataRoot, mpCommon.Address, opts)
+	txInfo, err := c.csMgr.Contracts().SuperNodeAccount.Stake(signature, depositDataRoot, mpCommon.Address, opts)
 	if err != nil {
 		return nil, fmt.Errorf("error creating stake transaction for minipool %s: %w", mpCommon.Address.Hex(), err)
 	}
 	mpDetails.TxInfo = txInfo
+	if opts != nil {
+		mpDetails.SimulationRevert = cscommon.GetSimulationRevert(c.Context, c.ServiceProvider.GetEthClient(), opts.From, txInfo)
+	}
 	return &mpDetails, nil
 }
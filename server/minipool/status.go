@@ -2,6 +2,7 @@ package csminipool
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"math/big"
@@ -14,7 +15,9 @@ import (
 	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
 	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
 	batch "github.com/rocket-pool/batch-query"
+	nmcserver "github.com/rocket-pool/node-manager-core/api/server"
 	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/utils/input"
 	"github.com/rocket-pool/node-manager-core/wallet"
 	"github.com/rocket-pool/rocketpool-go/v2/minipool"
 	"github.com/rocket-pool/rocketpool-go/v2/node"
@@ -36,9 +39,12 @@ func (f *minipoolStatusContextFactory) Create(args url.Values) (*MinipoolStatusC
 		ServiceProvider:   f.handler.serviceProvider,
 		Logger:            f.handler.logger.Logger,
 		Context:           f.handler.ctx,
-		SnServiceProvider: f.handler.snServiceProvider,
+		SnServiceProvider: f.handler.serviceProvider.GetSmartNodeServiceProvider(),
 	}
-	return c, nil
+	inputErrs := []error{
+		nmcserver.ValidateOptionalArg("node-address", args, input.ValidateAddress, &c.NodeAddress, &c.HasNodeAddress),
+	}
+	return c, errors.Join(inputErrs...)
 }
 
 func (f *minipoolStatusContextFactory) RegisterRoute(router *mux.Router) {
@@ -58,6 +64,13 @@ type MinipoolStatusContext struct {
 	Context           context.Context
 	SnServiceProvider snservices.ISmartNodeServiceProvider
 
+	// Arguments
+	NodeAddress    common.Address
+	HasNodeAddress bool
+
+	// True if the node being queried isn't the daemon's own node, so its NodeSet status isn't available
+	IsMonitoredNode bool
+
 	snContext *snminipool.MinipoolStatusContext
 	snData    *snapi.MinipoolStatusData
 
@@ -65,7 +78,15 @@ type MinipoolStatusContext struct {
 	maxValidators *big.Int
 }
 
+func (c *MinipoolStatusContext) GetNodeAddressOverride() (common.Address, bool) {
+	return c.NodeAddress, c.HasNodeAddress
+}
+
 func (c *MinipoolStatusContext) Initialize(walletStatus wallet.WalletStatus) (types.ResponseStatus, error) {
+	if c.HasNodeAddress && c.NodeAddress != walletStatus.Address.NodeAddress {
+		c.IsMonitoredNode = true
+	}
+
 	// Create the SN context
 	c.snContext = &snminipool.MinipoolStatusContext{
 		ServiceProvider: c.SnServiceProvider,
@@ -83,7 +104,7 @@ func (c *MinipoolStatusContext) GetState(node *node.Node, mc *batch.MultiCaller)
 	// Defer to the SN
 	c.snContext.GetState(node, mc)
 	csMgr := c.ServiceProvider.GetConstellationManager()
-	csMgr.SuperNodeAccount.GetMaxValidators(mc, &c.maxValidators)
+	csMgr.Contracts().SuperNodeAccount.GetMaxValidators(mc, &c.maxValidators)
 }
 
 func (c *MinipoolStatusContext) CheckState(node *node.Node, data *csapi.MinipoolStatusData) bool {
@@ -105,6 +126,17 @@ func (c *MinipoolStatusContext) PrepareData(addresses []common.Address, mps []mi
 	data.LatestDelegate = c.snData.LatestDelegate
 	csResources := c.ServiceProvider.GetResources()
 
+	// NodeSet only reports on the daemon's own node, so just list the minipools of monitored nodes
+	if c.IsMonitoredNode {
+		data.Minipools = make([]csapi.MinipoolDetails, len(c.snData.Minipools))
+		for i, mp := range c.snData.Minipools {
+			data.Minipools[i] = csapi.MinipoolDetails{
+				MinipoolDetails: &mp,
+			}
+		}
+		return types.ResponseStatus_Success, nil
+	}
+
 	// Get the signed exit status from NodeSet
 	hd := c.ServiceProvider.GetHyperdriveClient()
 	response, err := hd.NodeSet_Constellation.GetValidators(csResources.DeploymentName)
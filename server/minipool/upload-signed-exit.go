@@ -158,6 +158,15 @@ func (c *MinipoolUploadSignedExitsContext) PrepareData(data *types.SuccessData,
 		return types.ResponseStatus_Error, fmt.Errorf("your user account does not have the correct permissions to upload signed exit messages for Constellation")
 	}
 
+	for _, info := range c.Infos {
+		sp.GetEventBroker().Publish(csapi.Event{
+			Type:        csapi.EventType_ExitUploaded,
+			NodeAddress: walletStatus.Address.NodeAddress,
+			Minipool:    info.Address,
+			Description: info.Pubkey.HexWithPrefix(),
+		})
+	}
+
 	// Get the list of validators for the node now
 	validatorsResponse, err := hd.NodeSet_Constellation.GetValidators(csResources.DeploymentName)
 	if err != nil {
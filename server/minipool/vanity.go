@@ -74,7 +74,7 @@ func (c *MinipoolVanityContext) PrepareData(data *csapi.MinipoolVanityArtifactsD
 	if err != nil {
 		return types.ResponseStatus_Error, fmt.Errorf("error refreshing Rocket Pool contracts: %w", err)
 	}
-	rp := rpMgr.RocketPool
+	rp := rpMgr.RocketPool()
 
 	// Refresh constellation contracts
 	err = csMgr.LoadContracts()
@@ -131,7 +131,7 @@ func (c *MinipoolVanityContext) PrepareData(data *csapi.MinipoolVanityArtifactsD
 
 	// Update & return response
 	data.SubNodeAddress = nodeAddress
-	data.SuperNodeAddress = csMgr.SuperNodeAccount.Address
+	data.SuperNodeAddress = csMgr.Contracts().SuperNodeAccount.Address
 	data.MinipoolFactoryAddress = rocketMinipoolFactory.Address
 	data.InitHash = initHash
 	return types.ResponseStatus_Success, nil
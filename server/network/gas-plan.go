@@ -0,0 +1,153 @@
+package csnetwork
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/server"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/services"
+	batch "github.com/rocket-pool/batch-query"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/rocket-pool/node-manager-core/wallet"
+	"github.com/rocket-pool/rocketpool-go/v2/dao/oracle"
+	"github.com/rocket-pool/rocketpool-go/v2/dao/protocol"
+	"github.com/rocket-pool/rocketpool-go/v2/minipool"
+	rptypes "github.com/rocket-pool/rocketpool-go/v2/types"
+)
+
+// ===============
+// === Factory ===
+// ===============
+
+type networkGasPlanContextFactory struct {
+	handler *NetworkHandler
+}
+
+func (f *networkGasPlanContextFactory) Create(args url.Values) (*networkGasPlanContext, error) {
+	c := &networkGasPlanContext{
+		handler: f.handler,
+	}
+	return c, nil
+}
+
+func (f *networkGasPlanContextFactory) RegisterRoute(router *mux.Router) {
+	server.RegisterQuerylessGet[*networkGasPlanContext, csapi.NetworkGasPlanData](
+		router, "gas-plan", f, f.handler.logger.Logger, f.handler.serviceProvider,
+	)
+}
+
+// ===============
+// === Context ===
+// ===============
+
+type networkGasPlanContext struct {
+	handler *NetworkHandler
+}
+
+func (c *networkGasPlanContext) PrepareData(data *csapi.NetworkGasPlanData, walletStatus wallet.WalletStatus, opts *bind.TransactOpts) (types.ResponseStatus, error) {
+	sp := c.handler.serviceProvider
+	ctx := c.handler.ctx
+	cfg := sp.GetConfig()
+	csMgr := sp.GetConstellationManager()
+	rpMgr := sp.GetRocketPoolManager()
+	qMgr := sp.GetQueryManager()
+
+	// Requirements
+	err := sp.RequireNodeAddress(walletStatus)
+	if err != nil {
+		return types.ResponseStatus_AddressNotPresent, err
+	}
+	err = sp.RequireEthClientSynced(ctx)
+	if err != nil {
+		if errors.Is(err, services.ErrExecutionClientNotSynced) {
+			return types.ResponseStatus_ClientsNotSynced, err
+		}
+		return types.ResponseStatus_Error, err
+	}
+	err = rpMgr.RefreshRocketPoolContracts()
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error refreshing Rocket Pool contracts: %w", err)
+	}
+	err = csMgr.LoadContracts()
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error loading Constellation contracts: %w", err)
+	}
+
+	// Get the forecast
+	data.Enabled = cfg.GasStrategyEnabled.Value
+	data.Forecast, err = cscommon.GetGasForecast(ctx, sp.GetEthClient(), cfg)
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error getting gas forecast: %w", err)
+	}
+
+	// Get the node's minipools and the timing settings
+	pdaoMgr, err := protocol.NewProtocolDaoManager(rpMgr.RocketPool())
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error creating pDAO manager binding: %w", err)
+	}
+	odaoMgr, err := oracle.NewOracleDaoManager(rpMgr.RocketPool())
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error creating oDAO manager binding: %w", err)
+	}
+	mpMgr, err := minipool.NewMinipoolManager(rpMgr.RocketPool())
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error creating minipool manager binding: %w", err)
+	}
+	var addresses []common.Address
+	err = qMgr.Query(func(mc *batch.MultiCaller) error {
+		csMgr.Contracts().SuperNodeAccount.GetSubNodeMinipools(mc, &addresses, walletStatus.Address.NodeAddress)
+		return nil
+	}, nil,
+		odaoMgr.Settings.Minipool.ScrubPeriod,
+		pdaoMgr.Settings.Minipool.LaunchTimeout,
+	)
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error getting contract state: %w", err)
+	}
+	mps, err := mpMgr.CreateMinipoolsFromAddresses(addresses, false, nil)
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error creating minipool bindings: %w", err)
+	}
+	err = qMgr.BatchQuery(len(mps), minipoolDetailsBatchSize, func(mc *batch.MultiCaller, i int) error {
+		mpCommon := mps[i].Common()
+		eth.AddQueryablesToMulticall(mc,
+			mpCommon.Status,
+			mpCommon.StatusTime,
+		)
+		return nil
+	}, nil)
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error getting minipool details: %w", err)
+	}
+
+	// Plan the stake for each prelaunch minipool, skipping ones that have already been submitted
+	txTracker := sp.GetTransactionTracker()
+	scrubPeriod := odaoMgr.Settings.Minipool.ScrubPeriod.Formatted()
+	launchTimeout := pdaoMgr.Settings.Minipool.LaunchTimeout.Formatted()
+	now := time.Now()
+	data.Entries = []csapi.NetworkGasPlanEntry{}
+	for _, mp := range mps {
+		mpCommon := mp.Common()
+		if mpCommon.Status.Formatted() != rptypes.MinipoolStatus_Prelaunch {
+			continue
+		}
+		if _, isPending := txTracker.GetPendingTransactionForMinipool(mpCommon.Address); isPending {
+			continue
+		}
+		prelaunchTime := mpCommon.StatusTime.Formatted()
+		deadline := cscommon.GetStakeDueTime(prelaunchTime, launchTimeout)
+		entry := cscommon.PlanGasSubmission(data.Forecast, prelaunchTime.Add(scrubPeriod), deadline, now)
+		entry.Task = cscommon.GasPlanTask_StakeMinipool
+		entry.Minipool = mpCommon.Address
+		data.Entries = append(data.Entries, entry)
+	}
+	return types.ResponseStatus_Success, nil
+}
@@ -23,6 +23,10 @@ func NewNetworkHandler(logger *log.Logger, ctx context.Context, serviceProvider
 		serviceProvider: serviceProvider,
 	}
 	h.factories = []server.IContextFactory{
+		&networkGasPlanContextFactory{h},
+		&networkKeeperContextFactory{h},
+		&networkLiquidityContextFactory{h},
+		&networkProxyEventsContextFactory{h},
 		&networkStatsContextFactory{h},
 	}
 	return h
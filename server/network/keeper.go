@@ -0,0 +1,55 @@
+package csnetwork
+
+import (
+	"net/url"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/gorilla/mux"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/server"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/rocket-pool/node-manager-core/wallet"
+)
+
+// ===============
+// === Factory ===
+// ===============
+
+type networkKeeperContextFactory struct {
+	handler *NetworkHandler
+}
+
+func (f *networkKeeperContextFactory) Create(args url.Values) (*networkKeeperContext, error) {
+	c := &networkKeeperContext{
+		handler: f.handler,
+	}
+	return c, nil
+}
+
+func (f *networkKeeperContextFactory) RegisterRoute(router *mux.Router) {
+	server.RegisterQuerylessGet[*networkKeeperContext, csapi.NetworkKeeperData](
+		router, "keeper", f, f.handler.logger.Logger, f.handler.serviceProvider,
+	)
+}
+
+// ===============
+// === Context ===
+// ===============
+
+type networkKeeperContext struct {
+	handler *NetworkHandler
+}
+
+func (c *networkKeeperContext) PrepareData(data *csapi.NetworkKeeperData, walletStatus wallet.WalletStatus, opts *bind.TransactOpts) (types.ResponseStatus, error) {
+	sp := c.handler.serviceProvider
+	cfg := sp.GetConfig()
+	keeper := sp.GetKeeperTracker()
+
+	data.Enabled = cfg.KeeperEnabled.Value
+	data.DailyBudget = eth.EthToWei(cfg.KeeperDailyBudget.Value)
+	data.MinRewardMultiple = cfg.KeeperMinRewardMultiple.Value
+	data.RemainingBudget = keeper.GetRemainingBudget(data.DailyBudget)
+	data.Stats = keeper.GetStats()
+	return types.ResponseStatus_Success, nil
+}
@@ -0,0 +1,264 @@
+package csnetwork
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net/url"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/gorilla/mux"
+	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/server"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/services"
+	batch "github.com/rocket-pool/batch-query"
+	nmcserver "github.com/rocket-pool/node-manager-core/api/server"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/rocket-pool/node-manager-core/utils/input"
+	"github.com/rocket-pool/node-manager-core/wallet"
+	"github.com/rocket-pool/rocketpool-go/v2/minipool"
+	"github.com/rocket-pool/rocketpool-go/v2/node"
+)
+
+const (
+	// The most minipools the liquidity forecast will check for; beyond this, liquidity isn't the limiting factor
+	liquiditySearchLimit int = 100
+)
+
+// ===============
+// === Factory ===
+// ===============
+
+type networkLiquidityContextFactory struct {
+	handler *NetworkHandler
+}
+
+func (f *networkLiquidityContextFactory) Create(args url.Values) (*NetworkLiquidityContext, error) {
+	c := &NetworkLiquidityContext{
+		ServiceProvider: f.handler.serviceProvider,
+		Logger:          f.handler.logger.Logger,
+		Context:         f.handler.ctx,
+		EthDeposit:      big.NewInt(0),
+		RplDeposit:      big.NewInt(0),
+	}
+	inputErrs := []error{
+		nmcserver.ValidateOptionalArg("eth-deposit", args, input.ValidatePositiveOrZeroWeiAmount, &c.EthDeposit, nil),
+		nmcserver.ValidateOptionalArg("rpl-deposit", args, input.ValidatePositiveOrZeroWeiAmount, &c.RplDeposit, nil),
+	}
+	return c, errors.Join(inputErrs...)
+}
+
+func (f *networkLiquidityContextFactory) RegisterRoute(router *mux.Router) {
+	server.RegisterSingleStageRoute[*NetworkLiquidityContext, csapi.NetworkLiquidityData](
+		router, "liquidity", f, f.handler.logger.Logger, f.handler.serviceProvider,
+	)
+}
+
+// ===============
+// === Context ===
+// ===============
+
+type NetworkLiquidityContext struct {
+	// Dependencies
+	ServiceProvider cscommon.IConstellationServiceProvider
+	Logger          *slog.Logger
+	Context         context.Context
+
+	// Inputs
+	EthDeposit *big.Int
+	RplDeposit *big.Int
+
+	// Services
+	ec                 eth.IExecutionClient
+	rpMgr              *cscommon.RocketPoolManager
+	csMgr              *cscommon.ConstellationManager
+	rpSuperNodeBinding *node.Node
+	mpMgr              *minipool.MinipoolManager
+
+	// On-chain vars
+	bond                        *big.Int
+	rplPrice                    *big.Int
+	odRplBalance                *big.Int
+	wethVaultBalance            *big.Int
+	rplVaultBalance             *big.Int
+	wethVaultRequiredCollateral *big.Int
+	rplVaultRequiredCollateral  *big.Int
+}
+
+func (c *NetworkLiquidityContext) Initialize(walletStatus wallet.WalletStatus) (types.ResponseStatus, error) {
+	sp := c.ServiceProvider
+	ctx := c.Context
+	c.rpMgr = sp.GetRocketPoolManager()
+	c.csMgr = sp.GetConstellationManager()
+	c.ec = sp.GetEthClient()
+
+	// Requirements
+	err := sp.RequireEthClientSynced(ctx)
+	if err != nil {
+		if errors.Is(err, services.ErrExecutionClientNotSynced) {
+			return types.ResponseStatus_ClientsNotSynced, err
+		}
+		return types.ResponseStatus_Error, err
+	}
+
+	// Refresh RP
+	err = c.rpMgr.RefreshRocketPoolContracts()
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error refreshing Rocket Pool contracts: %w", err)
+	}
+
+	// Refresh constellation contracts
+	err = c.csMgr.LoadContracts()
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error loading Constellation contracts: %w", err)
+	}
+
+	// Create the bindings
+	superNodeAddress := c.csMgr.Contracts().SuperNodeAccount.Address
+	c.rpSuperNodeBinding, err = node.NewNode(c.rpMgr.RocketPool(), superNodeAddress)
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error creating node %s binding: %w", superNodeAddress.Hex(), err)
+	}
+	c.mpMgr, err = minipool.NewMinipoolManager(c.rpMgr.RocketPool())
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error getting minipool manager binding: %w", err)
+	}
+	return types.ResponseStatus_Success, nil
+}
+
+func (c *NetworkLiquidityContext) GetState(mc *batch.MultiCaller) {
+	eth.AddQueryablesToMulticall(mc,
+		c.rpSuperNodeBinding.RplStake,
+		c.rpSuperNodeBinding.EthMatched,
+		c.mpMgr.LaunchBalance,
+	)
+	rplVault := c.csMgr.Contracts().RplVault
+	c.csMgr.Contracts().SuperNodeAccount.Bond(mc, &c.bond)
+	c.csMgr.Contracts().PriceFetcher.GetRplPrice(mc, &c.rplPrice)
+	rplVault.Asset().BalanceOf(mc, &c.odRplBalance, c.csMgr.Contracts().OperatorDistributor.Address)
+	rplVault.Asset().BalanceOf(mc, &c.rplVaultBalance, rplVault.Address())
+	c.csMgr.Contracts().WethVault.GetWethBalance(mc, &c.wethVaultBalance)
+	c.csMgr.Contracts().WethVault.GetRequiredCollateralAfterDeposit(mc, &c.wethVaultRequiredCollateral, c.EthDeposit)
+	rplVault.GetRequiredCollateralAfterDeposit(mc, &c.rplVaultRequiredCollateral, c.RplDeposit)
+}
+
+func (c *NetworkLiquidityContext) PrepareData(data *csapi.NetworkLiquidityData, opts *bind.TransactOpts) (types.ResponseStatus, error) {
+	// Get the OD balance
+	odEthBalance, err := c.ec.BalanceAt(c.Context, c.csMgr.Contracts().OperatorDistributor.Address, nil)
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error getting Constellation's available ETH: %w", err)
+	}
+
+	// Populate the details
+	data.MinipoolBond = c.bond
+	data.BorrowedEthPerMinipool = new(big.Int).Sub(c.mpMgr.LaunchBalance.Get(), c.bond)
+	data.RplPrice = c.rplPrice
+	data.SuperNodeRplStake = c.rpSuperNodeBinding.RplStake.Get()
+	data.SuperNodeEthMatched = c.rpSuperNodeBinding.EthMatched.Get()
+	data.OperatorDistributorEthBalance = odEthBalance
+	data.OperatorDistributorRplBalance = c.odRplBalance
+	data.WethVaultBalance = c.wethVaultBalance
+	data.RplVaultBalance = c.rplVaultBalance
+	data.EthDeposit = c.EthDeposit
+	data.RplDeposit = c.RplDeposit
+
+	// Deposits into the vaults pass anything over the vault's required collateral along to the Operator Distributor
+	data.WethVaultSurplus = getVaultSurplus(c.wethVaultBalance, c.EthDeposit, c.wethVaultRequiredCollateral)
+	data.RplVaultSurplus = getVaultSurplus(c.rplVaultBalance, c.RplDeposit, c.rplVaultRequiredCollateral)
+
+	// Forecast with what the Operator Distributor has now
+	data.Current, err = c.getScenario(data, odEthBalance, c.odRplBalance)
+	if err != nil {
+		return types.ResponseStatus_Error, err
+	}
+
+	// Forecast with what the vaults would pass along on the next deposit
+	withDepositsEth := new(big.Int).Add(odEthBalance, data.WethVaultSurplus)
+	withDepositsRpl := new(big.Int).Add(c.odRplBalance, data.RplVaultSurplus)
+	data.WithVaultDeposits, err = c.getScenario(data, withDepositsEth, withDepositsRpl)
+	if err != nil {
+		return types.ResponseStatus_Error, err
+	}
+	return types.ResponseStatus_Success, nil
+}
+
+// Work out how many minipools can be funded with the given amounts of ETH and RPL.
+// Each minipool needs its bond in ETH, and enough RPL staked by the supernode to cover the ETH it borrows from Rocket Pool.
+func (c *NetworkLiquidityContext) getScenario(data *csapi.NetworkLiquidityData, availableEth *big.Int, availableRpl *big.Int) (csapi.NetworkLiquidityScenario, error) {
+	scenario := csapi.NetworkLiquidityScenario{
+		AvailableEth:        availableEth,
+		AvailableRpl:        availableRpl,
+		LimitedBy:           csapi.NetworkLiquidityLimit_None,
+		NextEthShortfall:    big.NewInt(0),
+		NextRplShortfall:    big.NewInt(0),
+		NextRplShortfallEth: big.NewInt(0),
+	}
+
+	// Find how many the ETH alone could fund
+	ethLimit := liquiditySearchLimit + 1
+	if data.MinipoolBond.Sign() > 0 {
+		ethCount := new(big.Int).Div(availableEth, data.MinipoolBond)
+		if ethCount.IsInt64() && ethCount.Int64() < int64(ethLimit) {
+			ethLimit = int(ethCount.Int64())
+		}
+	}
+	count := min(ethLimit, liquiditySearchLimit)
+
+	// Get the RPL shortfall for each number of minipools up to that, plus one more for the next minipool after it
+	shortfalls := make([]*big.Int, count+1)
+	err := c.ServiceProvider.GetQueryManager().Query(func(mc *batch.MultiCaller) error {
+		for i := range shortfalls {
+			borrowed := new(big.Int).Mul(data.BorrowedEthPerMinipool, big.NewInt(int64(i+1)))
+			ethStaked := new(big.Int).Add(data.SuperNodeEthMatched, borrowed)
+			c.csMgr.Contracts().OperatorDistributor.CalculateRplStakeShortfall(mc, &shortfalls[i], data.SuperNodeRplStake, ethStaked)
+		}
+		return nil
+	}, nil)
+	if err != nil {
+		return scenario, fmt.Errorf("error calculating RPL stake shortfalls: %w", err)
+	}
+
+	// Find the first count the RPL can't cover
+	scenario.MinipoolCount = count
+	for i, shortfall := range shortfalls[:count] {
+		if shortfall.Cmp(availableRpl) > 0 {
+			scenario.MinipoolCount = i
+			break
+		}
+	}
+
+	// Work out what's missing for the next one
+	next := scenario.MinipoolCount
+	nextEth := new(big.Int).Mul(data.MinipoolBond, big.NewInt(int64(next+1)))
+	if nextEth.Cmp(availableEth) > 0 {
+		scenario.NextEthShortfall.Sub(nextEth, availableEth)
+	}
+	if shortfalls[next].Cmp(availableRpl) > 0 {
+		scenario.NextRplShortfall.Sub(shortfalls[next], availableRpl)
+		scenario.NextRplShortfallEth.Mul(scenario.NextRplShortfall, data.RplPrice)
+		scenario.NextRplShortfallEth.Div(scenario.NextRplShortfallEth, eth.EthToWei(1))
+	}
+	switch {
+	case scenario.NextRplShortfall.Sign() > 0:
+		scenario.LimitedBy = csapi.NetworkLiquidityLimit_Rpl
+	case scenario.NextEthShortfall.Sign() > 0:
+		scenario.LimitedBy = csapi.NetworkLiquidityLimit_Eth
+	default:
+		scenario.AtSearchLimit = scenario.MinipoolCount == liquiditySearchLimit
+	}
+	return scenario, nil
+}
+
+// Get the amount a vault would pass along to the Operator Distributor after a deposit
+func getVaultSurplus(balance *big.Int, deposit *big.Int, requiredCollateral *big.Int) *big.Int {
+	surplus := new(big.Int).Add(balance, deposit)
+	surplus.Sub(surplus, requiredCollateral)
+	if surplus.Sign() < 0 {
+		return big.NewInt(0)
+	}
+	return surplus
+}
@@ -0,0 +1,62 @@
+package csnetwork
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/gorilla/mux"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/server"
+	nmcserver "github.com/rocket-pool/node-manager-core/api/server"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/utils/input"
+	"github.com/rocket-pool/node-manager-core/wallet"
+)
+
+// ===============
+// === Factory ===
+// ===============
+
+type networkProxyEventsContextFactory struct {
+	handler *NetworkHandler
+}
+
+func (f *networkProxyEventsContextFactory) Create(args url.Values) (*networkProxyEventsContext, error) {
+	c := &networkProxyEventsContext{
+		handler: f.handler,
+	}
+	inputErrs := []error{
+		nmcserver.ValidateOptionalArg("start-block", args, input.ValidateUint, &c.startBlock, nil),
+	}
+	return c, errors.Join(inputErrs...)
+}
+
+func (f *networkProxyEventsContextFactory) RegisterRoute(router *mux.Router) {
+	server.RegisterQuerylessGet[*networkProxyEventsContext, csapi.NetworkProxyEventsData](
+		router, "proxy-events", f, f.handler.logger.Logger, f.handler.serviceProvider,
+	)
+}
+
+// ===============
+// === Context ===
+// ===============
+
+type networkProxyEventsContext struct {
+	handler    *NetworkHandler
+	startBlock uint64
+}
+
+func (c *networkProxyEventsContext) PrepareData(data *csapi.NetworkProxyEventsData, walletStatus wallet.WalletStatus, opts *bind.TransactOpts) (types.ResponseStatus, error) {
+	sp := c.handler.serviceProvider
+
+	// Only read what's been indexed; the index events task is responsible for catching it up
+	indexer := sp.GetEventIndexer()
+	status := indexer.GetStatus()
+	data.StartBlock = max(c.startBlock, status.StartBlock)
+	data.IndexedBlock = status.IndexedBlock
+	data.LatestBlock = status.LatestBlock
+	data.IsCaughtUp = status.IsCaughtUp()
+	data.Upgrades, data.AdminChanges = indexer.GetProxyEvents(c.startBlock)
+	return types.ResponseStatus_Success, nil
+}
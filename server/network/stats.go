@@ -79,6 +79,9 @@ type NetworkStatsContext struct {
 	// On-chain vars
 	odRplBalance  *big.Int
 	maxValidators *big.Int
+
+	// Fleet
+	fleetNodes []cscommon.FleetNode
 }
 
 func (c *NetworkStatsContext) Initialize(walletStatus wallet.WalletStatus) (types.ResponseStatus, error) {
@@ -97,6 +100,12 @@ func (c *NetworkStatsContext) Initialize(walletStatus wallet.WalletStatus) (type
 		return types.ResponseStatus_Error, err
 	}
 
+	// Get the nodes to break the stats down for
+	c.fleetNodes, err = cscommon.GetFleetNodes(sp.GetConfig(), walletStatus)
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error getting fleet nodes: %w", err)
+	}
+
 	// Refresh RP
 	err = c.rpMgr.RefreshRocketPoolContracts()
 	if err != nil {
@@ -110,9 +119,9 @@ func (c *NetworkStatsContext) Initialize(walletStatus wallet.WalletStatus) (type
 	}
 
 	// Create the bindings
-	rp := c.rpMgr.RocketPool
-	superNodeAddress := c.csMgr.SuperNodeAccount.Address
-	c.rpSuperNodeBinding, err = node.NewNode(c.rpMgr.RocketPool, superNodeAddress)
+	rp := c.rpMgr.RocketPool()
+	superNodeAddress := c.csMgr.Contracts().SuperNodeAccount.Address
+	c.rpSuperNodeBinding, err = node.NewNode(c.rpMgr.RocketPool(), superNodeAddress)
 	if err != nil {
 		return types.ResponseStatus_Error, fmt.Errorf("error creating node %s binding: %w", superNodeAddress.Hex(), err)
 	}
@@ -145,15 +154,15 @@ func (c *NetworkStatsContext) GetState(mc *batch.MultiCaller) {
 		c.rpSuperNodeBinding.RplStake,
 		c.rpSuperNodeBinding.MinipoolCount,
 	)
-	c.rpl.BalanceOf(mc, &c.odRplBalance, c.csMgr.OperatorDistributor.Address)
-	c.csMgr.SuperNodeAccount.GetMaxValidators(mc, &c.maxValidators)
+	c.rpl.BalanceOf(mc, &c.odRplBalance, c.csMgr.Contracts().OperatorDistributor.Address)
+	c.csMgr.Contracts().SuperNodeAccount.GetMaxValidators(mc, &c.maxValidators)
 }
 
 func (c *NetworkStatsContext) PrepareData(data *csapi.NetworkStatsData, opts *bind.TransactOpts) (types.ResponseStatus, error) {
 	qMgr := c.ServiceProvider.GetQueryManager()
 
 	// Populate initial fields
-	data.SuperNodeAddress = c.csMgr.SuperNodeAccount.Address
+	data.SuperNodeAddress = c.csMgr.Contracts().SuperNodeAccount.Address
 	data.SuperNodeRplStake = c.rpSuperNodeBinding.RplStake.Get()
 	data.ConstellationRplBalance = c.odRplBalance
 	data.RocketPoolEthBalance = c.depositPool.Balance.Get()
@@ -164,7 +173,7 @@ func (c *NetworkStatsContext) PrepareData(data *csapi.NetworkStatsData, opts *bi
 	data.ValidatorLimit = int(c.maxValidators.Uint64())
 
 	// Get the OD balance
-	odEthBalance, err := c.ec.BalanceAt(c.Context, c.csMgr.OperatorDistributor.Address, nil)
+	odEthBalance, err := c.ec.BalanceAt(c.Context, c.csMgr.Contracts().OperatorDistributor.Address, nil)
 	if err != nil {
 		return types.ResponseStatus_Error, fmt.Errorf("error getting Constellation's available ETH: %w", err)
 	}
@@ -191,7 +200,7 @@ func (c *NetworkStatsContext) PrepareData(data *csapi.NetworkStatsData, opts *bi
 		)
 
 		// Make the CS binding
-		c.csMgr.SuperNodeAccount.GetMinipoolData(mc, &csDetails[i], mpCommon.Address)
+		c.csMgr.Contracts().SuperNodeAccount.GetMinipoolData(mc, &csDetails[i], mpCommon.Address)
 		return nil
 	}, nil)
 	if err != nil {
@@ -205,23 +214,43 @@ func (c *NetworkStatsContext) PrepareData(data *csapi.NetworkStatsData, opts *bi
 	}
 	data.SubnodeCount = len(nodes)
 
+	// Set up the per-node stats for the fleet
+	data.FleetNodes = make([]csapi.NetworkFleetNodeStats, len(c.fleetNodes))
+	fleetStats := map[common.Address]*csapi.NetworkFleetNodeStats{}
+	for i, fleetNode := range c.fleetNodes {
+		data.FleetNodes[i].NodeAddress = fleetNode.Address
+		data.FleetNodes[i].IsDaemonNode = fleetNode.IsDaemonNode
+		fleetStats[fleetNode.Address] = &data.FleetNodes[i]
+	}
+
 	// Get the minipool status counts
-	for _, mp := range mps {
+	for i, mp := range mps {
 		mpCommon := mp.Common()
+		nodeStats, isFleetNode := fleetStats[csDetails[i].NodeAddress]
+		if !isFleetNode {
+			// Use a throwaway for minipools outside of the fleet
+			nodeStats = &csapi.NetworkFleetNodeStats{}
+		}
 		if mpCommon.IsFinalised.Get() {
 			data.FinalizedMinipoolCount++
+			nodeStats.FinalizedMinipoolCount++
 			continue
 		}
+		nodeStats.ActiveMinipoolCount++
 
 		switch mpCommon.Status.Formatted() {
 		case rptypes.MinipoolStatus_Initialized:
 			data.InitializedMinipoolCount++
+			nodeStats.InitializedMinipoolCount++
 		case rptypes.MinipoolStatus_Prelaunch:
 			data.PrelaunchMinipoolCount++
+			nodeStats.PrelaunchMinipoolCount++
 		case rptypes.MinipoolStatus_Staking:
 			data.StakingMinipoolCount++
+			nodeStats.StakingMinipoolCount++
 		case rptypes.MinipoolStatus_Dissolved:
 			data.DissolvedMinipoolCount++
+			nodeStats.DissolvedMinipoolCount++
 		}
 	}
 	data.ActiveMinipoolCount = len(mps) - data.FinalizedMinipoolCount
@@ -0,0 +1,270 @@
+package csnode
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	hdapi "github.com/nodeset-org/hyperdrive-daemon/shared/types/api"
+
+	batch "github.com/rocket-pool/batch-query"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/rocket-pool/node-manager-core/wallet"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/gorilla/mux"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/server"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/services"
+)
+
+// ===============
+// === Factory ===
+// ===============
+
+type nodeDiagnoseContextFactory struct {
+	handler *NodeHandler
+}
+
+func (f *nodeDiagnoseContextFactory) Create(args url.Values) (*nodeDiagnoseContext, error) {
+	c := &nodeDiagnoseContext{
+		handler: f.handler,
+	}
+	inputErrs := []error{}
+	return c, errors.Join(inputErrs...)
+}
+
+func (f *nodeDiagnoseContextFactory) RegisterRoute(router *mux.Router) {
+	server.RegisterQuerylessGet[*nodeDiagnoseContext, csapi.NodeDiagnoseData](
+		router, "diagnose", f, f.handler.logger.Logger, f.handler.serviceProvider,
+	)
+}
+
+// ===============
+// === Context ===
+// ===============
+
+type nodeDiagnoseContext struct {
+	handler *NodeHandler
+}
+
+func (c *nodeDiagnoseContext) PrepareData(data *csapi.NodeDiagnoseData, walletStatus wallet.WalletStatus, opts *bind.TransactOpts) (types.ResponseStatus, error) {
+	sp := c.handler.serviceProvider
+	hd := sp.GetHyperdriveClient()
+	csMgr := sp.GetConstellationManager()
+	csResources := sp.GetResources()
+	ctx := c.handler.ctx
+
+	// Requirements
+	err := sp.RequireEthClientSynced(ctx)
+	if err != nil {
+		if errors.Is(err, services.ErrExecutionClientNotSynced) {
+			return types.ResponseStatus_ClientsNotSynced, err
+		}
+		return types.ResponseStatus_Error, err
+	}
+
+	// Load the Constellation contracts
+	err = csMgr.LoadContracts()
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error loading Constellation contracts: %w", err)
+	}
+
+	// Wallet; a missing wallet is reported as a failed check so the operator still gets the rest of the checklist.
+	// The node address is enough for the on-chain checks, so they're only skipped if there isn't one.
+	hasAddress := walletStatus.Address.HasAddress
+	nodeAddress := walletStatus.Address.NodeAddress
+	walletCheck := csapi.NodeDiagnosticCheck{
+		ID:    csapi.NodeDiagnosticCheck_Wallet,
+		Title: "Node wallet is loaded",
+	}
+	switch {
+	case wallet.IsWalletReady(walletStatus):
+		passCheck(&walletCheck, fmt.Sprintf("Hyperdrive has the wallet for %s loaded.", nodeAddress.Hex()))
+	case hasAddress:
+		failCheck(&walletCheck, fmt.Sprintf("Hyperdrive has %s as the node address, but its wallet isn't loaded.", nodeAddress.Hex()), "Recover the node wallet in Hyperdrive, or stop masquerading as another node.")
+	default:
+		failCheck(&walletCheck, "Hyperdrive doesn't have a node wallet loaded.", "Initialize or recover the node wallet in Hyperdrive.")
+	}
+	data.Checks = append(data.Checks, walletCheck)
+
+	// Get the on-chain state
+	data.WalletAddress = nodeAddress
+	var isWhitelisted bool
+	var bond *big.Int
+	err = sp.GetQueryManager().Query(func(mc *batch.MultiCaller) error {
+		if hasAddress {
+			csMgr.Contracts().Whitelist.IsAddressInWhitelist(mc, &isWhitelisted, nodeAddress)
+			csMgr.Contracts().Whitelist.GetActiveValidatorCountForOperator(mc, &data.ActiveValidatorCount, nodeAddress)
+		}
+		csMgr.Contracts().SuperNodeAccount.GetMaxValidators(mc, &data.MaxValidators)
+		csMgr.Contracts().SuperNodeAccount.LockThreshold(mc, &data.LockThreshold)
+		csMgr.Contracts().SuperNodeAccount.Bond(mc, &bond)
+		return nil
+	}, nil)
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error getting contract state: %w", err)
+	}
+	var hasSufficientLiquidity bool
+	err = sp.GetQueryManager().Query(func(mc *batch.MultiCaller) error {
+		csMgr.Contracts().SuperNodeAccount.HasSufficientLiquidity(mc, &hasSufficientLiquidity, bond)
+		return nil
+	}, nil)
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error checking for sufficient liquidity: %w", err)
+	}
+	if hasAddress {
+		data.NodeBalance, err = sp.GetEthClient().BalanceAt(ctx, nodeAddress, nil)
+		if err != nil {
+			return types.ResponseStatus_Error, fmt.Errorf("error getting node balance: %w", err)
+		}
+	}
+
+	// NodeSet registration; problems talking to NodeSet are reported as failed checks rather than errors so the rest of the checklist still comes through
+	isNodeSetRegistered := false
+	regCheck := csapi.NodeDiagnosticCheck{
+		ID:    csapi.NodeDiagnosticCheck_NodeSetRegistration,
+		Title: "Node is registered with NodeSet",
+	}
+	regResponse, err := hd.NodeSet.GetRegistrationStatus()
+	if err != nil {
+		failCheck(&regCheck, fmt.Sprintf("Error getting the registration status: %s", err.Error()), "Make sure the Hyperdrive daemon is running and can reach the NodeSet service.")
+	} else {
+		switch regResponse.Data.Status {
+		case hdapi.NodeSetRegistrationStatus_Registered:
+			isNodeSetRegistered = true
+			passCheck(&regCheck, "The node is registered with your NodeSet account.")
+		case hdapi.NodeSetRegistrationStatus_Unregistered:
+			failCheck(&regCheck, "The node isn't registered with a NodeSet account.", "Register the node with your NodeSet account using Hyperdrive's NodeSet registration command, then confirm it in the NodeSet portal.")
+		case hdapi.NodeSetRegistrationStatus_NoWallet:
+			failCheck(&regCheck, "Hyperdrive doesn't have a node wallet loaded.", "Initialize or recover the node wallet in Hyperdrive.")
+		default:
+			failCheck(&regCheck, fmt.Sprintf("The registration status couldn't be determined: %s", regResponse.Data.ErrorMessage), "Make sure the node can reach the NodeSet service and that its clock is accurate, then try again.")
+		}
+	}
+	data.Checks = append(data.Checks, regCheck)
+
+	// The address NodeSet has registered for Constellation
+	addressCheck := csapi.NodeDiagnosticCheck{
+		ID:    csapi.NodeDiagnosticCheck_RegisteredAddress,
+		Title: "NodeSet has this node registered for Constellation",
+	}
+	if !isNodeSetRegistered {
+		skipCheck(&addressCheck, "The node isn't registered with NodeSet.")
+	} else {
+		addressResponse, err := hd.NodeSet_Constellation.GetRegisteredAddress(csResources.DeploymentName)
+		switch {
+		case err != nil:
+			failCheck(&addressCheck, fmt.Sprintf("Error getting the registered address from NodeSet: %s", err.Error()), "Make sure the Hyperdrive daemon is running and can reach the NodeSet service.")
+		case addressResponse.Data.InvalidPermissions:
+			failCheck(&addressCheck, "Your NodeSet account doesn't have permission to use Constellation.", "Contact NodeSet to get your account approved for Constellation.")
+		case addressResponse.Data.NotRegisteredWithNodeSet:
+			failCheck(&addressCheck, "NodeSet doesn't recognize this node.", "Register the node with your NodeSet account using Hyperdrive's NodeSet registration command.")
+		case addressResponse.Data.NotRegisteredWithConstellation:
+			failCheck(&addressCheck, "Your NodeSet account doesn't have a node registered for Constellation yet.", "Register this node with Constellation using the node register command.")
+		default:
+			data.RegisteredAddress = addressResponse.Data.RegisteredAddress
+			if data.RegisteredAddress == nodeAddress {
+				passCheck(&addressCheck, fmt.Sprintf("NodeSet has %s registered for Constellation.", nodeAddress.Hex()))
+			} else {
+				failCheck(&addressCheck,
+					fmt.Sprintf("NodeSet has %s registered for Constellation, but this node's wallet is %s.", data.RegisteredAddress.Hex(), nodeAddress.Hex()),
+					"Only one node per NodeSet account can be used with Constellation. Use the registered node, or restore its wallet on this machine.",
+				)
+			}
+		}
+	}
+	data.Checks = append(data.Checks, addressCheck)
+
+	// Whitelist
+	whitelistCheck := csapi.NodeDiagnosticCheck{
+		ID:    csapi.NodeDiagnosticCheck_Whitelist,
+		Title: "Node is on the Constellation whitelist",
+	}
+	if !hasAddress {
+		skipCheck(&whitelistCheck, "The node doesn't have an address.")
+	} else if isWhitelisted {
+		passCheck(&whitelistCheck, "The node is on the Constellation whitelist.")
+	} else {
+		failCheck(&whitelistCheck, "The node isn't on the Constellation whitelist.", "Register this node with Constellation using the node register command once the checks above pass.")
+	}
+	data.Checks = append(data.Checks, whitelistCheck)
+
+	// Validator limit
+	limitCheck := csapi.NodeDiagnosticCheck{
+		ID:    csapi.NodeDiagnosticCheck_ValidatorLimit,
+		Title: "Node has room for another validator",
+	}
+	if !hasAddress {
+		skipCheck(&limitCheck, "The node doesn't have an address.")
+	} else if data.ActiveValidatorCount.Cmp(data.MaxValidators) < 0 {
+		passCheck(&limitCheck, fmt.Sprintf("The node has %s active validators out of a maximum of %s.", data.ActiveValidatorCount.String(), data.MaxValidators.String()))
+	} else {
+		failCheck(&limitCheck,
+			fmt.Sprintf("The node has %s active validators, which is the maximum of %s.", data.ActiveValidatorCount.String(), data.MaxValidators.String()),
+			"Wait for the Constellation administrators to raise the validator limit, or exit and close an existing minipool.",
+		)
+	}
+	data.Checks = append(data.Checks, limitCheck)
+
+	// Balance
+	balanceCheck := csapi.NodeDiagnosticCheck{
+		ID:    csapi.NodeDiagnosticCheck_Balance,
+		Title: "Node has enough ETH for the minipool lockup",
+	}
+	if !hasAddress {
+		skipCheck(&balanceCheck, "The node doesn't have an address.")
+	} else if data.NodeBalance.Cmp(data.LockThreshold) >= 0 {
+		passCheck(&balanceCheck, fmt.Sprintf("The node has %.6f ETH, and the lockup is %.6f ETH.", eth.WeiToEth(data.NodeBalance), eth.WeiToEth(data.LockThreshold)))
+	} else {
+		shortfall := new(big.Int).Sub(data.LockThreshold, data.NodeBalance)
+		failCheck(&balanceCheck,
+			fmt.Sprintf("The node has %.6f ETH, but the lockup is %.6f ETH.", eth.WeiToEth(data.NodeBalance), eth.WeiToEth(data.LockThreshold)),
+			fmt.Sprintf("Send at least %.6f more ETH to %s, plus enough to cover gas.", eth.WeiToEth(shortfall), nodeAddress.Hex()),
+		)
+	}
+	data.Checks = append(data.Checks, balanceCheck)
+
+	// Liquidity
+	liquidityCheck := csapi.NodeDiagnosticCheck{
+		ID:    csapi.NodeDiagnosticCheck_Liquidity,
+		Title: "Constellation has enough liquidity for a new minipool",
+	}
+	if hasSufficientLiquidity {
+		passCheck(&liquidityCheck, "The Constellation vaults have enough ETH and RPL to fund a new minipool.")
+	} else {
+		failCheck(&liquidityCheck, "The Constellation vaults don't have enough ETH and RPL to fund a new minipool right now.", "Nothing needs to be done on the node; wait for more deposits into the Constellation vaults and try again later.")
+	}
+	data.Checks = append(data.Checks, liquidityCheck)
+
+	// Summarize
+	data.Passed = true
+	for _, check := range data.Checks {
+		if check.Status != csapi.NodeDiagnosticStatus_Pass {
+			data.Passed = false
+			break
+		}
+	}
+	return types.ResponseStatus_Success, nil
+}
+
+// Mark a diagnostic check as passed
+func passCheck(check *csapi.NodeDiagnosticCheck, details string) {
+	check.Status = csapi.NodeDiagnosticStatus_Pass
+	check.Details = details
+}
+
+// Mark a diagnostic check as failed, with a hint on how to fix it
+func failCheck(check *csapi.NodeDiagnosticCheck, details string, remediation string) {
+	check.Status = csapi.NodeDiagnosticStatus_Fail
+	check.Details = details
+	check.Remediation = remediation
+}
+
+// Mark a diagnostic check as skipped
+func skipCheck(check *csapi.NodeDiagnosticCheck, reason string) {
+	check.Status = csapi.NodeDiagnosticStatus_Skipped
+	check.Details = reason
+}
@@ -73,7 +73,7 @@ func (c *nodeGetRegistrationStatusContext) PrepareData(data *csapi.NodeGetRegist
 
 	// Get the registration status
 	err = sp.GetQueryManager().Query(func(mc *batch.MultiCaller) error {
-		csMgr.Whitelist.IsAddressInWhitelist(mc, &data.Registered, walletStatus.Address.NodeAddress)
+		csMgr.Contracts().Whitelist.IsAddressInWhitelist(mc, &data.Registered, walletStatus.Address.NodeAddress)
 		return nil
 	}, nil)
 	if err != nil {
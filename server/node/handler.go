@@ -23,7 +23,10 @@ func NewNodeHandler(logger *log.Logger, ctx context.Context, serviceProvider csc
 		serviceProvider: serviceProvider,
 	}
 	h.factories = []server.IContextFactory{
+		&nodeDiagnoseContextFactory{h},
 		&nodeGetRegistrationStatusContextFactory{h},
+		&nodeMinipoolEventsContextFactory{h},
+		&nodePositionContextFactory{h},
 		&nodeRegisterContextFactory{h},
 	}
 	return h
@@ -0,0 +1,69 @@
+package csnode
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/gorilla/mux"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/server"
+	nmcserver "github.com/rocket-pool/node-manager-core/api/server"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/utils/input"
+	"github.com/rocket-pool/node-manager-core/wallet"
+)
+
+// ===============
+// === Factory ===
+// ===============
+
+type nodeMinipoolEventsContextFactory struct {
+	handler *NodeHandler
+}
+
+func (f *nodeMinipoolEventsContextFactory) Create(args url.Values) (*nodeMinipoolEventsContext, error) {
+	c := &nodeMinipoolEventsContext{
+		handler: f.handler,
+	}
+	inputErrs := []error{
+		nmcserver.ValidateOptionalArg("start-block", args, input.ValidateUint, &c.startBlock, nil),
+	}
+	return c, errors.Join(inputErrs...)
+}
+
+func (f *nodeMinipoolEventsContextFactory) RegisterRoute(router *mux.Router) {
+	server.RegisterQuerylessGet[*nodeMinipoolEventsContext, csapi.NodeMinipoolEventsData](
+		router, "minipool-events", f, f.handler.logger.Logger, f.handler.serviceProvider,
+	)
+}
+
+// ===============
+// === Context ===
+// ===============
+
+type nodeMinipoolEventsContext struct {
+	handler    *NodeHandler
+	startBlock uint64
+}
+
+func (c *nodeMinipoolEventsContext) PrepareData(data *csapi.NodeMinipoolEventsData, walletStatus wallet.WalletStatus, opts *bind.TransactOpts) (types.ResponseStatus, error) {
+	sp := c.handler.serviceProvider
+
+	// Requirements
+	err := sp.RequireNodeAddress(walletStatus)
+	if err != nil {
+		return types.ResponseStatus_AddressNotPresent, err
+	}
+
+	// Only read what's been indexed; the index events task is responsible for catching it up
+	indexer := sp.GetEventIndexer()
+	status := indexer.GetStatus()
+	data.NodeAddress = walletStatus.Address.NodeAddress
+	data.StartBlock = max(c.startBlock, status.StartBlock)
+	data.IndexedBlock = status.IndexedBlock
+	data.LatestBlock = status.LatestBlock
+	data.IsCaughtUp = status.IsCaughtUp()
+	data.Created, data.Destroyed = indexer.GetMinipoolEvents(data.NodeAddress, c.startBlock)
+	return types.ResponseStatus_Success, nil
+}
@@ -0,0 +1,541 @@
+package csnode
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	"github.com/nodeset-org/hyperdrive-constellation/common/contracts/constellation"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/server"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/services"
+	batch "github.com/rocket-pool/batch-query"
+	nmcserver "github.com/rocket-pool/node-manager-core/api/server"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/rocket-pool/node-manager-core/log"
+	"github.com/rocket-pool/node-manager-core/utils/input"
+	"github.com/rocket-pool/node-manager-core/wallet"
+	"github.com/rocket-pool/rocketpool-go/v2/core"
+	"github.com/rocket-pool/rocketpool-go/v2/minipool"
+	"github.com/rocket-pool/rocketpool-go/v2/rewards"
+	"github.com/rocket-pool/rocketpool-go/v2/rocketpool"
+	rptypes "github.com/rocket-pool/rocketpool-go/v2/types"
+)
+
+const (
+	minipoolDetailsBatchSize int = 100
+
+	// Number of days of Beacon balance history to estimate APR from if one isn't provided
+	defaultPositionAprDays uint64 = 7
+
+	// Max number of days of Beacon balance history that can be used, since the balances are sampled once per day
+	maxPositionAprDays uint64 = 30
+
+	// The balance of a fully-deposited validator, in gwei
+	validatorFullBalanceGwei int64 = 32e9
+)
+
+// ===============
+// === Factory ===
+// ===============
+
+type nodePositionContextFactory struct {
+	handler *NodeHandler
+}
+
+func (f *nodePositionContextFactory) Create(args url.Values) (*nodePositionContext, error) {
+	c := &nodePositionContext{
+		handler: f.handler,
+		aprDays: defaultPositionAprDays,
+	}
+	inputErrs := []error{
+		nmcserver.ValidateOptionalArg("apr-days", args, input.ValidateUint, &c.aprDays, nil),
+		nmcserver.ValidateOptionalArg("export", args, input.ValidateBool, &c.export, nil),
+	}
+	return c, errors.Join(inputErrs...)
+}
+
+func (f *nodePositionContextFactory) RegisterRoute(router *mux.Router) {
+	server.RegisterSingleStageRoute[*nodePositionContext, csapi.NodePositionData](
+		router, "position", f, f.handler.logger.Logger, f.handler.serviceProvider,
+	)
+}
+
+// ===============
+// === Context ===
+// ===============
+
+type nodePositionContext struct {
+	handler *NodeHandler
+	aprDays uint64
+	export  bool
+
+	// Services
+	rpMgr               *cscommon.RocketPoolManager
+	csMgr               *cscommon.ConstellationManager
+	mpMgr               *minipool.MinipoolManager
+	rewardsPool         *rewards.RewardsPool
+	rewardsPoolContract *core.Contract
+	distributor         *rewards.MerkleDistributorMainnet
+	nodeAddress         common.Address
+
+	// On-chain vars
+	minipoolAddresses []common.Address
+	priorEthStream    *big.Int
+	priorRplStream    *big.Int
+}
+
+func (c *nodePositionContext) Initialize(walletStatus wallet.WalletStatus) (types.ResponseStatus, error) {
+	sp := c.handler.serviceProvider
+	ctx := c.handler.ctx
+	c.rpMgr = sp.GetRocketPoolManager()
+	c.csMgr = sp.GetConstellationManager()
+
+	if c.aprDays == 0 || c.aprDays > maxPositionAprDays {
+		return types.ResponseStatus_InvalidArguments, fmt.Errorf("apr-days must be between 1 and %d", maxPositionAprDays)
+	}
+
+	// Requirements
+	err := sp.RequireNodeAddress(walletStatus)
+	if err != nil {
+		return types.ResponseStatus_AddressNotPresent, err
+	}
+	err = sp.RequireEthClientSynced(ctx)
+	if err != nil {
+		if errors.Is(err, services.ErrExecutionClientNotSynced) {
+			return types.ResponseStatus_ClientsNotSynced, err
+		}
+		return types.ResponseStatus_Error, err
+	}
+	err = sp.RequireBeaconClientSynced(ctx)
+	if err != nil {
+		if errors.Is(err, services.ErrBeaconNodeNotSynced) {
+			return types.ResponseStatus_ClientsNotSynced, err
+		}
+		return types.ResponseStatus_Error, err
+	}
+	c.nodeAddress = walletStatus.Address.NodeAddress
+
+	// Refresh RP
+	err = c.rpMgr.RefreshRocketPoolContracts()
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error refreshing Rocket Pool contracts: %w", err)
+	}
+
+	// Refresh constellation contracts
+	err = c.csMgr.LoadContracts()
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error loading Constellation contracts: %w", err)
+	}
+
+	// Create the bindings
+	rp := c.rpMgr.RocketPool()
+	c.mpMgr, err = minipool.NewMinipoolManager(rp)
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error getting minipool manager binding: %w", err)
+	}
+	c.rewardsPool, err = rewards.NewRewardsPool(rp)
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error getting rewards pool binding: %w", err)
+	}
+	c.rewardsPoolContract, err = rp.GetContract(rocketpool.ContractName_RocketRewardsPool)
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error getting rewards pool contract: %w", err)
+	}
+	c.distributor, err = rewards.NewMerkleDistributorMainnet(rp)
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error getting merkle distributor binding: %w", err)
+	}
+	return types.ResponseStatus_Success, nil
+}
+
+func (c *nodePositionContext) GetState(mc *batch.MultiCaller) {
+	eth.AddQueryablesToMulticall(mc,
+		c.rewardsPool.RewardIndex,
+	)
+	c.csMgr.Contracts().SuperNodeAccount.GetSubNodeMinipools(mc, &c.minipoolAddresses, c.nodeAddress)
+	c.csMgr.Contracts().MerkleClaimStreamer.GetPriorEthStreamAmount(mc, &c.priorEthStream)
+	c.csMgr.Contracts().MerkleClaimStreamer.GetPriorRplStreamAmount(mc, &c.priorRplStream)
+}
+
+func (c *nodePositionContext) PrepareData(data *csapi.NodePositionData, opts *bind.TransactOpts) (types.ResponseStatus, error) {
+	sp := c.handler.serviceProvider
+	qMgr := sp.GetQueryManager()
+	superNodeAddress := c.csMgr.Contracts().SuperNodeAccount.Address
+	data.NodeAddress = c.nodeAddress
+	data.SuperNodeAddress = superNodeAddress
+	data.StreamingEthRewards = c.priorEthStream
+	data.StreamingRplRewards = c.priorRplStream
+	data.AprDays = c.aprDays
+	data.Minipools = []csapi.NodePositionMinipool{}
+	data.RewardIntervals = []csapi.NodePositionRewardInterval{}
+
+	// Get the minipool details
+	mps, err := c.mpMgr.CreateMinipoolsFromAddresses(c.minipoolAddresses, false, nil)
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error creating minipool bindings: %w", err)
+	}
+	balances, err := c.rpMgr.RocketPool().BalanceBatcher.GetEthBalances(c.minipoolAddresses, nil)
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error getting minipool balances: %w", err)
+	}
+	csDetails := make([]constellation.MinipoolData, len(mps))
+	lockups := make([]*big.Int, len(mps))
+	err = qMgr.BatchQuery(len(mps), minipoolDetailsBatchSize, func(mc *batch.MultiCaller, i int) error {
+		mpCommon := mps[i].Common()
+		eth.AddQueryablesToMulticall(mc,
+			mpCommon.Pubkey,
+			mpCommon.Status,
+			mpCommon.IsFinalised,
+			mpCommon.NodeDepositBalance,
+			mpCommon.NodeRefundBalance,
+		)
+		c.csMgr.Contracts().SuperNodeAccount.GetMinipoolData(mc, &csDetails[i], mpCommon.Address)
+		c.csMgr.Contracts().SuperNodeAccount.LockedEth(mc, &lockups[i], mpCommon.Address)
+		return nil
+	}, nil)
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error getting minipool details: %w", err)
+	}
+
+	// Anything in a staking minipool's balance beyond its refund is skimmed rewards that can be distributed
+	data.Minipools = make([]csapi.NodePositionMinipool, len(mps))
+	for i, mp := range mps {
+		mpCommon := mp.Common()
+		position := &data.Minipools[i]
+		position.Address = mpCommon.Address
+		position.Pubkey = mpCommon.Pubkey.Get()
+		position.Status = mpCommon.Status.Formatted()
+		position.IsFinalised = mpCommon.IsFinalised.Get()
+		position.Lockup = lockups[i]
+		position.BondShare = mpCommon.NodeDepositBalance.Get()
+		position.NodeFee = csDetails[i].NodeFee
+		position.TreasuryFee = csDetails[i].EthTreasuryFee
+		position.DistributableSkim = big.NewInt(0)
+		position.NodeShareOfSkim = big.NewInt(0)
+		position.AccruedOperatorFees = big.NewInt(0)
+		position.BeaconBalance = big.NewInt(0)
+		if position.Status == rptypes.MinipoolStatus_Staking && !position.IsFinalised {
+			skim := new(big.Int).Sub(balances[i], mpCommon.NodeRefundBalance.Get())
+			if skim.Sign() > 0 {
+				position.DistributableSkim = skim
+			}
+		}
+	}
+
+	// Get the node's share of each skim, which the operator's fee is taken from
+	err = qMgr.BatchQuery(len(mps), minipoolDetailsBatchSize, func(mc *batch.MultiCaller, i int) error {
+		position := &data.Minipools[i]
+		if position.DistributableSkim.Sign() > 0 {
+			mps[i].Common().CalculateNodeShare(mc, &position.NodeShareOfSkim, position.DistributableSkim)
+		}
+		return nil
+	}, nil)
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error calculating node shares: %w", err)
+	}
+
+	// Sum everything up
+	data.TotalLockup = big.NewInt(0)
+	data.TotalBondShare = big.NewInt(0)
+	data.TotalDistributableSkim = big.NewInt(0)
+	data.TotalAccruedOperatorFees = big.NewInt(0)
+	for i := range data.Minipools {
+		position := &data.Minipools[i]
+		if position.NodeFee != nil {
+			position.AccruedOperatorFees.Mul(position.NodeShareOfSkim, position.NodeFee)
+			position.AccruedOperatorFees.Div(position.AccruedOperatorFees, eth.EthToWei(1))
+		}
+		data.TotalLockup.Add(data.TotalLockup, position.Lockup)
+		data.TotalBondShare.Add(data.TotalBondShare, position.BondShare)
+		data.TotalDistributableSkim.Add(data.TotalDistributableSkim, position.DistributableSkim)
+		data.TotalAccruedOperatorFees.Add(data.TotalAccruedOperatorFees, position.AccruedOperatorFees)
+	}
+
+	// Check which of the completed rewards intervals since Constellation was deployed the supernode has claimed
+	intervalCount := c.rewardsPool.RewardIndex.Formatted()
+	data.FirstRewardInterval, err = c.getFirstRewardInterval(intervalCount)
+	if err != nil {
+		return types.ResponseStatus_Error, err
+	}
+	data.RewardIntervals = make([]csapi.NodePositionRewardInterval, intervalCount-data.FirstRewardInterval)
+	err = qMgr.BatchQuery(len(data.RewardIntervals), minipoolDetailsBatchSize, func(mc *batch.MultiCaller, i int) error {
+		interval := &data.RewardIntervals[i]
+		interval.Index = data.FirstRewardInterval + uint64(i)
+		c.distributor.HasNodeClaimedRewards(new(big.Int).SetUint64(interval.Index), superNodeAddress, &interval.Claimed, mc)
+		return nil
+	}, nil)
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error getting rewards claim status: %w", err)
+	}
+
+	// Add the amounts of the claims the event index has found so far
+	indexer := sp.GetEventIndexer()
+	data.RewardClaimsIndexed = indexer.GetStatus().IsCaughtUp()
+	claims := map[uint64]csapi.RewardClaimEvent{}
+	for _, claim := range indexer.GetRewardClaims() {
+		claims[claim.Interval] = claim
+	}
+	data.TotalClaimedEth = big.NewInt(0)
+	data.TotalClaimedRpl = big.NewInt(0)
+	for i := range data.RewardIntervals {
+		interval := &data.RewardIntervals[i]
+		if !interval.Claimed {
+			data.UnclaimedRewardIntervals++
+			continue
+		}
+		data.ClaimedRewardIntervals++
+		claim, exists := claims[interval.Index]
+		if !exists {
+			continue
+		}
+		interval.ClaimedEth = claim.AmountEth
+		interval.ClaimedRpl = claim.AmountRpl
+		interval.ClaimTx = claim.TxHash
+		data.TotalClaimedEth.Add(data.TotalClaimedEth, claim.AmountEth)
+		data.TotalClaimedRpl.Add(data.TotalClaimedRpl, claim.AmountRpl)
+	}
+
+	// Estimate the APR from the Beacon balances; if that fails, the rest of the position is still worth returning
+	err = c.estimateApr(data)
+	if err != nil {
+		c.handler.logger.Warn("Error estimating APR", log.Err(err))
+		data.AprAvailable = false
+		data.AprError = err.Error()
+		for i := range data.Minipools {
+			data.Minipools[i].AprAvailable = false
+			data.Minipools[i].Apr = 0
+		}
+	}
+
+	// Export it for accounting if requested
+	if c.export {
+		data.Csv, err = createPositionCsv(data)
+		if err != nil {
+			return types.ResponseStatus_Error, fmt.Errorf("error exporting position: %w", err)
+		}
+	}
+	return types.ResponseStatus_Success, nil
+}
+
+// Get the first rewards interval that ended after Constellation was deployed; the supernode can't have earned anything
+// in the ones before it. Returns the interval count if none have ended since then.
+func (c *nodePositionContext) getFirstRewardInterval(intervalCount uint64) (uint64, error) {
+	deploymentBlock := c.handler.serviceProvider.GetResources().DeploymentBlock
+	if deploymentBlock == 0 || intervalCount == 0 {
+		return 0, nil
+	}
+
+	// Get the block each interval's rewards snapshot was executed in
+	executionBlocks := make([]*big.Int, intervalCount)
+	err := c.handler.serviceProvider.GetQueryManager().BatchQuery(int(intervalCount), minipoolDetailsBatchSize, func(mc *batch.MultiCaller, i int) error {
+		core.AddCall(mc, c.rewardsPoolContract, &executionBlocks[i], "getClaimIntervalExecutionBlock", big.NewInt(int64(i)))
+		return nil
+	}, nil)
+	if err != nil {
+		return 0, fmt.Errorf("error getting rewards interval execution blocks: %w", err)
+	}
+	for i, block := range executionBlocks {
+		if block.Uint64() >= deploymentBlock {
+			return uint64(i), nil
+		}
+	}
+	return intervalCount, nil
+}
+
+// Estimate the consensus layer APR of each validator by sampling its Beacon balance once a day over the requested window.
+// Withdrawal sweeps skim everything over 32 ETH, so a sample that drops back towards 32 ETH is treated as a sweep rather
+// than a penalty; rewards earned between the previous sample and the sweep itself are missed, so this slightly underestimates.
+func (c *nodePositionContext) estimateApr(data *csapi.NodePositionData) error {
+	bn := c.handler.serviceProvider.GetBeaconClient()
+	ctx := c.handler.ctx
+	beaconCfg, err := bn.GetEth2Config(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting Beacon config: %w", err)
+	}
+	beaconHead, err := bn.GetBeaconHead(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting Beacon head: %w", err)
+	}
+	if beaconCfg.SecondsPerEpoch == 0 {
+		return fmt.Errorf("the Beacon config has no epoch length")
+	}
+	epochsPerDay := uint64(24*60*60) / beaconCfg.SecondsPerEpoch
+	epochsPerYear := float64(epochsPerDay) * 365
+
+	// Get the sample range, clamped to the start of the chain
+	data.AprEndEpoch = beaconHead.FinalizedEpoch
+	window := c.aprDays * epochsPerDay
+	if data.AprEndEpoch > window {
+		data.AprStartEpoch = data.AprEndEpoch - window
+	}
+	if len(data.Minipools) == 0 || data.AprEndEpoch == data.AprStartEpoch {
+		return nil
+	}
+	pubkeys := make([]beacon.ValidatorPubkey, len(data.Minipools))
+	for i, position := range data.Minipools {
+		pubkeys[i] = position.Pubkey
+	}
+
+	// Sample each validator's balance
+	previous := make([]int64, len(data.Minipools))
+	earned := make([]int64, len(data.Minipools))
+	firstActive := make([]uint64, len(data.Minipools))
+	lastActive := make([]uint64, len(data.Minipools))
+	seen := make([]bool, len(data.Minipools))
+	for epoch := data.AprStartEpoch; ; epoch += epochsPerDay {
+		epoch = min(epoch, data.AprEndEpoch)
+		statuses, err := bn.GetValidatorStatuses(ctx, pubkeys, &beacon.ValidatorStatusOptions{
+			Epoch: &epoch,
+		})
+		if err != nil {
+			return fmt.Errorf("error getting validator statuses for epoch %d: %w", epoch, err)
+		}
+		for i, pubkey := range pubkeys {
+			status, exists := statuses[pubkey]
+			if !exists || !status.Exists || status.ActivationEpoch > epoch || status.ExitEpoch <= epoch {
+				continue
+			}
+			balance := int64(status.Balance)
+			if epoch == data.AprEndEpoch {
+				data.Minipools[i].BeaconBalance = new(big.Int).Mul(big.NewInt(balance), big.NewInt(1e9))
+			}
+			if !seen[i] {
+				seen[i] = true
+				firstActive[i] = epoch
+				lastActive[i] = epoch
+				previous[i] = balance
+				continue
+			}
+			previousExcess := previous[i] - validatorFullBalanceGwei
+			if balance < previous[i] && previousExcess > 0 && balance-validatorFullBalanceGwei < previousExcess/2 {
+				earned[i] += balance - validatorFullBalanceGwei
+			} else {
+				earned[i] += balance - previous[i]
+			}
+			previous[i] = balance
+			lastActive[i] = epoch
+		}
+		if epoch == data.AprEndEpoch {
+			break
+		}
+	}
+
+	// Annualize the earnings relative to a full validator balance
+	var totalEarned float64
+	var totalWeight float64
+	for i := range data.Minipools {
+		activeEpochs := lastActive[i] - firstActive[i]
+		if !seen[i] || activeEpochs == 0 {
+			continue
+		}
+		weight := float64(validatorFullBalanceGwei) * float64(activeEpochs)
+		data.Minipools[i].AprAvailable = true
+		data.Minipools[i].Apr = float64(earned[i]) / weight * epochsPerYear
+		totalEarned += float64(earned[i])
+		totalWeight += weight
+	}
+	if totalWeight > 0 {
+		data.AprAvailable = true
+		data.Apr = totalEarned / totalWeight * epochsPerYear
+	}
+	return nil
+}
+
+// Create a CSV of the node's position, with one row per minipool and a row for the totals.
+// Amounts are in ETH, and fees and APR are fractions.
+func createPositionCsv(data *csapi.NodePositionData) (string, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	records := [][]string{
+		{
+			"Minipool",
+			"Pubkey",
+			"Status",
+			"Finalised",
+			"Lockup",
+			"Bond Share",
+			"Operator Fee",
+			"Treasury Fee",
+			"Distributable Skim",
+			"Node Share of Skim",
+			"Accrued Operator Fees",
+			"Beacon Balance",
+			"APR",
+		},
+	}
+	for _, position := range data.Minipools {
+		records = append(records, []string{
+			position.Address.Hex(),
+			position.Pubkey.HexWithPrefix(),
+			string(position.Status),
+			strconv.FormatBool(position.IsFinalised),
+			formatWeiAsEth(position.Lockup),
+			formatWeiAsEth(position.BondShare),
+			formatWeiAsEth(position.NodeFee),
+			formatWeiAsEth(position.TreasuryFee),
+			formatWeiAsEth(position.DistributableSkim),
+			formatWeiAsEth(position.NodeShareOfSkim),
+			formatWeiAsEth(position.AccruedOperatorFees),
+			formatWeiAsEth(position.BeaconBalance),
+			formatApr(position.AprAvailable, position.Apr),
+		})
+	}
+	records = append(records, []string{
+		"Total",
+		"",
+		"",
+		"",
+		formatWeiAsEth(data.TotalLockup),
+		formatWeiAsEth(data.TotalBondShare),
+		"",
+		"",
+		formatWeiAsEth(data.TotalDistributableSkim),
+		"",
+		formatWeiAsEth(data.TotalAccruedOperatorFees),
+		"",
+		formatApr(data.AprAvailable, data.Apr),
+	})
+	err := writer.WriteAll(records)
+	if err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
+// Format a wei amount as an exact decimal ETH string, so the export doesn't lose precision
+func formatWeiAsEth(amount *big.Int) string {
+	if amount == nil {
+		return ""
+	}
+	sign := ""
+	abs := new(big.Int).Abs(amount)
+	if amount.Sign() < 0 {
+		sign = "-"
+	}
+	whole, fraction := new(big.Int).QuoRem(abs, eth.EthToWei(1), new(big.Int))
+	fractionString := strings.TrimRight(fmt.Sprintf("%018s", fraction.String()), "0")
+	if fractionString == "" {
+		return sign + whole.String()
+	}
+	return fmt.Sprintf("%s%s.%s", sign, whole.String(), fractionString)
+}
+
+// Format an APR estimate for the export, leaving it blank if there wasn't enough history to estimate it
+func formatApr(available bool, apr float64) string {
+	if !available {
+		return ""
+	}
+	return strconv.FormatFloat(apr, 'f', 6, 64)
+}
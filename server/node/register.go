@@ -6,9 +6,13 @@ import (
 	"log/slog"
 	"net/url"
 
+	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
 	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	nmcserver "github.com/rocket-pool/node-manager-core/api/server"
 	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/eth"
 	"github.com/rocket-pool/node-manager-core/utils"
+	"github.com/rocket-pool/node-manager-core/utils/input"
 	"github.com/rocket-pool/node-manager-core/wallet"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -29,7 +33,9 @@ func (f *nodeRegisterContextFactory) Create(args url.Values) (*nodeRegisterConte
 	c := &nodeRegisterContext{
 		handler: f.handler,
 	}
-	inputErrs := []error{}
+	inputErrs := []error{
+		nmcserver.ValidateOptionalArg("export", args, input.ValidateBool, &c.export, nil),
+	}
 	return c, errors.Join(inputErrs...)
 }
 
@@ -45,6 +51,7 @@ func (f *nodeRegisterContextFactory) RegisterRoute(router *mux.Router) {
 
 type nodeRegisterContext struct {
 	handler *NodeHandler
+	export  bool
 }
 
 func (c *nodeRegisterContext) PrepareData(data *csapi.NodeRegisterData, walletStatus wallet.WalletStatus, opts *bind.TransactOpts) (types.ResponseStatus, error) {
@@ -54,12 +61,22 @@ func (c *nodeRegisterContext) PrepareData(data *csapi.NodeRegisterData, walletSt
 	ctx := c.handler.ctx
 	csResources := sp.GetResources()
 
-	// Requirements
-	err := sp.RequireWalletReady(walletStatus)
-	if err != nil {
-		return types.ResponseStatus_WalletNotReady, err
+	// Requirements; exported TXs are signed elsewhere, so they only need the node address instead of a ready wallet
+	operatorAddress := walletStatus.Wallet.WalletAddress
+	if c.export {
+		err := sp.RequireNodeAddress(walletStatus)
+		if err != nil {
+			return types.ResponseStatus_AddressNotPresent, err
+		}
+		operatorAddress = walletStatus.Address.NodeAddress
+		opts = cscommon.GetExportTransactOpts(operatorAddress)
+	} else {
+		err := sp.RequireWalletReady(walletStatus)
+		if err != nil {
+			return types.ResponseStatus_WalletNotReady, err
+		}
 	}
-	err = sp.RequireEthClientSynced(ctx)
+	err := sp.RequireEthClientSynced(ctx)
 	if err != nil {
 		if errors.Is(err, services.ErrExecutionClientNotSynced) {
 			return types.ResponseStatus_ClientsNotSynced, err
@@ -103,9 +120,18 @@ func (c *nodeRegisterContext) PrepareData(data *csapi.NodeRegisterData, walletSt
 	)
 
 	// Get the registration TX
-	data.TxInfo, err = csMgr.Whitelist.AddOperator(walletStatus.Wallet.WalletAddress, sigResponse.Data.Signature, opts)
+	data.TxInfo, err = csMgr.Contracts().Whitelist.AddOperator(operatorAddress, sigResponse.Data.Signature, opts)
 	if err != nil {
 		return types.ResponseStatus_Error, fmt.Errorf("error creating registration TX: %w", err)
 	}
+
+	// Export it for offline signing if requested
+	if c.export {
+		unsignedTxs, err := cscommon.CreateUnsignedTransactions(ctx, sp.GetEthClient(), operatorAddress, []*eth.TransactionInfo{data.TxInfo})
+		if err != nil {
+			return types.ResponseStatus_Error, fmt.Errorf("error exporting registration TX: %w", err)
+		}
+		data.UnsignedTx = unsignedTxs[0]
+	}
 	return types.ResponseStatus_Success, nil
 }
@@ -6,10 +6,12 @@ import (
 	"sync"
 
 	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	csadmin "github.com/nodeset-org/hyperdrive-constellation/server/admin"
 	csminipool "github.com/nodeset-org/hyperdrive-constellation/server/minipool"
 	csnetwork "github.com/nodeset-org/hyperdrive-constellation/server/network"
 	csnode "github.com/nodeset-org/hyperdrive-constellation/server/node"
 	csservice "github.com/nodeset-org/hyperdrive-constellation/server/service"
+	cstx "github.com/nodeset-org/hyperdrive-constellation/server/tx"
 	cswallet "github.com/nodeset-org/hyperdrive-constellation/server/wallet"
 	csconfig "github.com/nodeset-org/hyperdrive-constellation/shared/config"
 	"github.com/nodeset-org/hyperdrive-daemon/shared/auth"
@@ -67,8 +69,12 @@ func createServer(sp cscommon.IConstellationServiceProvider, ip string, port uin
 		csnetwork.NewNetworkHandler(apiLogger, ctx, sp),
 		csnode.NewNodeHandler(apiLogger, ctx, sp),
 		csservice.NewServiceHandler(apiLogger, ctx, sp),
+		cstx.NewTxHandler(apiLogger, ctx, sp),
 		cswallet.NewWalletHandler(apiLogger, ctx, sp),
 	}
+	if sp.GetConfig().AdminRoutesEnabled.Value {
+		handlers = append(handlers, csadmin.NewAdminHandler(apiLogger, ctx, sp))
+	}
 
 	// Create the API server
 	server, err := server.NewNetworkSocketApiServer(apiLogger.Logger, ip, port, handlers, csconfig.DaemonBaseRoute, csconfig.ApiVersion)
@@ -76,9 +82,25 @@ func createServer(sp cscommon.IConstellationServiceProvider, ip string, port uin
 		return nil, err
 	}
 
-	// Add the authorization middleware
+	// Add the healthchecks
+	registerHealthRoutes(server.GetApiRouter(), sp, apiLogger.Logger)
+
+	// Add the event stream
+	registerEventsRoute(server.GetApiRouter(), sp, apiLogger.Logger)
+
+	// Add the authorization middleware, letting the healthchecks through so Docker and orchestrators can use them without credentials
 	server.GetApiRouter().Use(func(next http.Handler) http.Handler {
-		return authMgr.GetRequestHandler(apiLogger.Logger, next)
+		authHandler := authMgr.GetRequestHandler(apiLogger.Logger, next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isHealthRoute(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			authHandler.ServeHTTP(w, r)
+		})
 	})
+
+	// Record state-changing calls in the audit log, once they've been authorized
+	server.GetApiRouter().Use(createAuditMiddleware(sp, apiLogger.Logger, "/"+csconfig.ApiClientRoute))
 	return server, nil
 }
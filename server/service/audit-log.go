@@ -0,0 +1,68 @@
+package csservice
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/gorilla/mux"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/server"
+	nmcserver "github.com/rocket-pool/node-manager-core/api/server"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/utils/input"
+	"github.com/rocket-pool/node-manager-core/wallet"
+)
+
+// ===============
+// === Factory ===
+// ===============
+
+type serviceAuditLogContextFactory struct {
+	handler *ServiceHandler
+}
+
+func (f *serviceAuditLogContextFactory) Create(args url.Values) (*serviceAuditLogContext, error) {
+	c := &serviceAuditLogContext{
+		handler: f.handler,
+	}
+	inputErrs := []error{
+		nmcserver.ValidateOptionalArg("start", args, input.ValidateTime, &c.start, nil),
+		nmcserver.ValidateOptionalArg("end", args, input.ValidateTime, &c.end, nil),
+	}
+	return c, errors.Join(inputErrs...)
+}
+
+func (f *serviceAuditLogContextFactory) RegisterRoute(router *mux.Router) {
+	server.RegisterQuerylessGet[*serviceAuditLogContext, csapi.ServiceAuditLogData](
+		router, "audit-log", f, f.handler.logger.Logger, f.handler.serviceProvider,
+	)
+}
+
+// ===============
+// === Context ===
+// ===============
+
+type serviceAuditLogContext struct {
+	handler *ServiceHandler
+	start   time.Time
+	end     time.Time
+}
+
+func (c *serviceAuditLogContext) PrepareData(data *csapi.ServiceAuditLogData, walletStatus wallet.WalletStatus, opts *bind.TransactOpts) (types.ResponseStatus, error) {
+	if !c.start.IsZero() && !c.end.IsZero() && c.end.Before(c.start) {
+		return types.ResponseStatus_InvalidArguments, fmt.Errorf("end time %s is before start time %s", c.end.Format(time.RFC3339), c.start.Format(time.RFC3339))
+	}
+
+	entries, verification, err := c.handler.serviceProvider.GetAuditLog().GetEntries(c.start, c.end)
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error reading audit log: %w", err)
+	}
+	data.Entries = entries
+	data.TotalEntries = verification.TotalEntries
+	data.ChainIntact = verification.Intact
+	data.InvalidSequence = verification.InvalidSequence
+	return types.ResponseStatus_Success, nil
+}
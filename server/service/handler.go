@@ -23,8 +23,12 @@ func NewServiceHandler(logger *log.Logger, ctx context.Context, serviceProvider
 		serviceProvider: serviceProvider,
 	}
 	h.factories = []server.IContextFactory{
+		&serviceAuditLogContextFactory{h},
 		&serviceGetNetworkSettingsContextFactory{h},
 		&serviceGetResourcesContextFactory{h},
+		&serviceMaintenanceWindowContextFactory{h},
+		&serviceReloadSettingsContextFactory{h},
+		&serviceRestartVcContextFactory{h},
 		&serviceVersionContextFactory{h},
 	}
 	return h
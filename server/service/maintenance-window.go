@@ -0,0 +1,246 @@
+package csservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/server"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/services"
+	batch "github.com/rocket-pool/batch-query"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/rocket-pool/node-manager-core/wallet"
+	"github.com/rocket-pool/rocketpool-go/v2/minipool"
+)
+
+const (
+	// Batch size for minipool pubkey queries
+	minipoolPubkeyBatchSize int = 200
+
+	// The minimum amount of duty-free time remaining for a VC restart to be considered safe
+	restartSafetyBuffer time.Duration = 2 * time.Minute
+)
+
+// ===============
+// === Factory ===
+// ===============
+
+type serviceMaintenanceWindowContextFactory struct {
+	handler *ServiceHandler
+}
+
+func (f *serviceMaintenanceWindowContextFactory) Create(args url.Values) (*serviceMaintenanceWindowContext, error) {
+	c := &serviceMaintenanceWindowContext{
+		handler: f.handler,
+	}
+	return c, nil
+}
+
+func (f *serviceMaintenanceWindowContextFactory) RegisterRoute(router *mux.Router) {
+	server.RegisterQuerylessGet[*serviceMaintenanceWindowContext, csapi.ServiceMaintenanceWindowData](
+		router, "maintenance-window", f, f.handler.logger.Logger, f.handler.serviceProvider,
+	)
+}
+
+// ===============
+// === Context ===
+// ===============
+
+type serviceMaintenanceWindowContext struct {
+	handler *ServiceHandler
+}
+
+func (c *serviceMaintenanceWindowContext) PrepareData(data *csapi.ServiceMaintenanceWindowData, walletStatus wallet.WalletStatus, opts *bind.TransactOpts) (types.ResponseStatus, error) {
+	return getMaintenanceWindow(c.handler.ctx, c.handler.serviceProvider, walletStatus, data)
+}
+
+// Get the upcoming duties for the node's validators and find the longest window without any of them.
+// Beacon nodes only publish proposer duties up to one epoch ahead, so the current and next epochs are checked.
+// Attestations are ignored since they're due every epoch; a restart will always miss a few of them.
+func getMaintenanceWindow(ctx context.Context, sp cscommon.IConstellationServiceProvider, walletStatus wallet.WalletStatus, data *csapi.ServiceMaintenanceWindowData) (types.ResponseStatus, error) {
+	csMgr := sp.GetConstellationManager()
+	rpMgr := sp.GetRocketPoolManager()
+	qMgr := sp.GetQueryManager()
+	bn := sp.GetBeaconClient()
+
+	// Requirements
+	err := sp.RequireNodeAddress(walletStatus)
+	if err != nil {
+		return types.ResponseStatus_AddressNotPresent, err
+	}
+	err = sp.RequireEthClientSynced(ctx)
+	if err != nil {
+		if errors.Is(err, services.ErrExecutionClientNotSynced) {
+			return types.ResponseStatus_ClientsNotSynced, err
+		}
+		return types.ResponseStatus_Error, err
+	}
+	err = sp.RequireBeaconClientSynced(ctx)
+	if err != nil {
+		if errors.Is(err, services.ErrBeaconNodeNotSynced) {
+			return types.ResponseStatus_ClientsNotSynced, err
+		}
+		return types.ResponseStatus_Error, err
+	}
+	err = rpMgr.RefreshRocketPoolContracts()
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error refreshing Rocket Pool contracts: %w", err)
+	}
+	err = csMgr.LoadContracts()
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error loading Constellation contracts: %w", err)
+	}
+
+	// Get the node's minipool pubkeys
+	var addresses []common.Address
+	err = qMgr.Query(func(mc *batch.MultiCaller) error {
+		csMgr.Contracts().SuperNodeAccount.GetSubNodeMinipools(mc, &addresses, walletStatus.Address.NodeAddress)
+		return nil
+	}, nil)
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error getting minipools for node: %w", err)
+	}
+	mpMgr, err := minipool.NewMinipoolManager(rpMgr.RocketPool())
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error creating minipool manager binding: %w", err)
+	}
+	mps, err := mpMgr.CreateMinipoolsFromAddresses(addresses, false, nil)
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error creating minipool bindings: %w", err)
+	}
+	err = qMgr.BatchQuery(len(mps), minipoolPubkeyBatchSize, func(mc *batch.MultiCaller, i int) error {
+		mps[i].Common().Pubkey.AddToQuery(mc)
+		return nil
+	}, nil)
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error querying minipool pubkeys: %w", err)
+	}
+
+	// Get the Beacon details
+	beaconCfg, err := bn.GetEth2Config(ctx)
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error getting Beacon config: %w", err)
+	}
+	head, err := bn.GetBeaconHead(ctx)
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error getting Beacon head: %w", err)
+	}
+	data.CurrentEpoch = head.Epoch
+	data.LastKnownEpoch = head.Epoch + 1
+	epochDuration := time.Duration(beaconCfg.SlotsPerEpoch*beaconCfg.SecondsPerSlot) * time.Second
+	genesis := time.Unix(int64(beaconCfg.GenesisTime), 0)
+	getEpochStart := func(epoch uint64) time.Time {
+		return genesis.Add(time.Duration(epoch) * epochDuration)
+	}
+
+	// Map the validator indices to their minipools
+	pubkeys := make([]beacon.ValidatorPubkey, len(mps))
+	for i, mp := range mps {
+		pubkeys[i] = mp.Common().Pubkey.Get()
+	}
+	statuses, err := bn.GetValidatorStatuses(ctx, pubkeys, nil)
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error getting validator statuses: %w", err)
+	}
+	validators := map[string]csapi.MinipoolValidatorInfo{}
+	indices := []string{}
+	for _, mp := range mps {
+		mpCommon := mp.Common()
+		pubkey := mpCommon.Pubkey.Get()
+		status, exists := statuses[pubkey]
+		if !exists || status.Index == "" {
+			continue
+		}
+		validators[status.Index] = csapi.MinipoolValidatorInfo{
+			Address: mpCommon.Address,
+			Pubkey:  pubkey,
+			Index:   status.Index,
+		}
+		indices = append(indices, status.Index)
+	}
+
+	// Get the duties for each epoch
+	data.Duties = []csapi.ServiceUpcomingDuty{}
+	busyEpochs := map[uint64]bool{}
+	if len(indices) > 0 {
+		for epoch := data.CurrentEpoch; epoch <= data.LastKnownEpoch; epoch++ {
+			proposerDuties, err := bn.GetValidatorProposerDuties(ctx, indices, epoch)
+			if err != nil {
+				return types.ResponseStatus_Error, fmt.Errorf("error getting proposer duties for epoch %d: %w", epoch, err)
+			}
+			syncDuties, err := bn.GetValidatorSyncDuties(ctx, indices, epoch)
+			if err != nil {
+				return types.ResponseStatus_Error, fmt.Errorf("error getting sync committee duties for epoch %d: %w", epoch, err)
+			}
+			for _, index := range indices {
+				info := validators[index]
+				duty := csapi.ServiceUpcomingDuty{
+					Epoch:      epoch,
+					EpochStart: getEpochStart(epoch),
+					Address:    info.Address,
+					Pubkey:     info.Pubkey,
+					Index:      index,
+				}
+				if count := proposerDuties[index]; count > 0 {
+					duty.Type = csapi.ServiceDutyType_Proposal
+					duty.Count = count
+					data.Duties = append(data.Duties, duty)
+					busyEpochs[epoch] = true
+				}
+				if syncDuties[index] {
+					duty.Type = csapi.ServiceDutyType_SyncCommittee
+					duty.Count = 1
+					data.Duties = append(data.Duties, duty)
+					busyEpochs[epoch] = true
+				}
+			}
+		}
+	}
+	data.NoActiveDuties = len(busyEpochs) == 0
+
+	// Find the longest run of duty-free epochs
+	now := time.Now()
+	var bestStart, bestEnd time.Time
+	var runStart time.Time
+	inRun := false
+	for epoch := data.CurrentEpoch; epoch <= data.LastKnownEpoch+1; epoch++ {
+		isFree := epoch <= data.LastKnownEpoch && !busyEpochs[epoch]
+		if isFree && !inRun {
+			runStart = getEpochStart(epoch)
+			if runStart.Before(now) {
+				runStart = now
+			}
+			inRun = true
+		} else if !isFree && inRun {
+			runEnd := getEpochStart(epoch)
+			if runEnd.Sub(runStart) > bestEnd.Sub(bestStart) {
+				bestStart = runStart
+				bestEnd = runEnd
+				data.WindowIsOpenEnded = (epoch == data.LastKnownEpoch+1)
+			}
+			inRun = false
+		}
+	}
+	if bestEnd.After(bestStart) {
+		data.HasWindow = true
+		data.WindowStart = bestStart
+		data.WindowEnd = bestEnd
+		data.WindowDuration = bestEnd.Sub(bestStart)
+	}
+
+	// A restart is safe right now if there's enough duty-free time left before the next duty
+	nextBusyEpoch := data.CurrentEpoch
+	for nextBusyEpoch <= data.LastKnownEpoch && !busyEpochs[nextBusyEpoch] {
+		nextBusyEpoch++
+	}
+	data.SafeToRestartNow = getEpochStart(nextBusyEpoch).Sub(now) >= restartSafetyBuffer
+	return types.ResponseStatus_Success, nil
+}
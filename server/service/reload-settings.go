@@ -0,0 +1,63 @@
+package csservice
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/gorilla/mux"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/server"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/services"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/wallet"
+)
+
+// ===============
+// === Factory ===
+// ===============
+
+type serviceReloadSettingsContextFactory struct {
+	handler *ServiceHandler
+}
+
+func (f *serviceReloadSettingsContextFactory) Create(body csapi.ServiceReloadSettingsBody) (*serviceReloadSettingsContext, error) {
+	c := &serviceReloadSettingsContext{
+		handler: f.handler,
+	}
+	return c, nil
+}
+
+func (f *serviceReloadSettingsContextFactory) RegisterRoute(router *mux.Router) {
+	server.RegisterQuerylessPost[*serviceReloadSettingsContext, csapi.ServiceReloadSettingsBody, csapi.ServiceReloadSettingsData](
+		router, "reload-settings", f, f.handler.logger.Logger, f.handler.serviceProvider,
+	)
+}
+
+// ===============
+// === Context ===
+// ===============
+
+type serviceReloadSettingsContext struct {
+	handler *ServiceHandler
+}
+
+func (c *serviceReloadSettingsContext) PrepareData(data *csapi.ServiceReloadSettingsData, walletStatus wallet.WalletStatus, opts *bind.TransactOpts) (types.ResponseStatus, error) {
+	sp := c.handler.serviceProvider
+	ctx := c.handler.ctx
+
+	// Requirements
+	err := sp.RequireEthClientSynced(ctx)
+	if err != nil {
+		if errors.Is(err, services.ErrExecutionClientNotSynced) {
+			return types.ResponseStatus_ClientsNotSynced, err
+		}
+		return types.ResponseStatus_Error, err
+	}
+
+	// Reload the settings
+	*data, err = sp.GetSettingsReloader().Reload()
+	if err != nil {
+		return types.ResponseStatus_Error, err
+	}
+	return types.ResponseStatus_Success, nil
+}
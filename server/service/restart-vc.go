@@ -0,0 +1,71 @@
+package csservice
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/gorilla/mux"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/server"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/wallet"
+)
+
+// ===============
+// === Factory ===
+// ===============
+
+type serviceRestartVcContextFactory struct {
+	handler *ServiceHandler
+}
+
+func (f *serviceRestartVcContextFactory) Create(body csapi.ServiceRestartVcBody) (*serviceRestartVcContext, error) {
+	c := &serviceRestartVcContext{
+		handler: f.handler,
+		force:   body.Force,
+	}
+	return c, nil
+}
+
+func (f *serviceRestartVcContextFactory) RegisterRoute(router *mux.Router) {
+	server.RegisterQuerylessPost[*serviceRestartVcContext, csapi.ServiceRestartVcBody, csapi.ServiceRestartVcData](
+		router, "restart-vc", f, f.handler.logger.Logger, f.handler.serviceProvider,
+	)
+}
+
+// ===============
+// === Context ===
+// ===============
+
+type serviceRestartVcContext struct {
+	handler *ServiceHandler
+	force   bool
+}
+
+func (c *serviceRestartVcContext) PrepareData(data *csapi.ServiceRestartVcData, walletStatus wallet.WalletStatus, opts *bind.TransactOpts) (types.ResponseStatus, error) {
+	sp := c.handler.serviceProvider
+	hd := sp.GetHyperdriveClient()
+	cfg := sp.GetConfig()
+
+	// Make sure the restart won't overlap any scheduled duties unless it's forced
+	if !c.force {
+		window := csapi.ServiceMaintenanceWindowData{}
+		status, err := getMaintenanceWindow(c.handler.ctx, sp, walletStatus, &window)
+		if err != nil {
+			return status, err
+		}
+		data.Duties = window.Duties
+		if !window.SafeToRestartNow {
+			data.BlockedByDuty = true
+			return types.ResponseStatus_Success, nil
+		}
+	}
+
+	// Have Hyperdrive restart the VC
+	_, err := hd.Service.RestartContainer(cfg.VcContainerName())
+	if err != nil {
+		return types.ResponseStatus_Error, fmt.Errorf("error restarting validator client: %w", err)
+	}
+	data.Restarted = true
+	return types.ResponseStatus_Success, nil
+}
@@ -0,0 +1,45 @@
+package cstx
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/gorilla/mux"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/server"
+	apitypes "github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/wallet"
+)
+
+// ===============
+// === Factory ===
+// ===============
+
+type txCancelContextFactory struct {
+	handler *TxHandler
+}
+
+func (f *txCancelContextFactory) Create(body csapi.TxReplaceBody) (*txCancelContext, error) {
+	c := &txCancelContext{
+		handler: f.handler,
+		nonce:   body.Nonce,
+	}
+	return c, nil
+}
+
+func (f *txCancelContextFactory) RegisterRoute(router *mux.Router) {
+	server.RegisterQuerylessPost[*txCancelContext, csapi.TxReplaceBody, csapi.TxReplaceData](
+		router, "cancel", f, f.handler.logger.Logger, f.handler.serviceProvider,
+	)
+}
+
+// ===============
+// === Context ===
+// ===============
+
+type txCancelContext struct {
+	handler *TxHandler
+	nonce   uint64
+}
+
+func (c *txCancelContext) PrepareData(data *csapi.TxReplaceData, walletStatus wallet.WalletStatus, opts *bind.TransactOpts) (apitypes.ResponseStatus, error) {
+	return replaceTransaction(c.handler, walletStatus, opts, c.nonce, true, data)
+}
@@ -0,0 +1,40 @@
+package cstx
+
+import (
+	"context"
+
+	"github.com/gorilla/mux"
+	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	"github.com/rocket-pool/node-manager-core/api/server"
+	"github.com/rocket-pool/node-manager-core/log"
+)
+
+type TxHandler struct {
+	logger          *log.Logger
+	ctx             context.Context
+	serviceProvider cscommon.IConstellationServiceProvider
+	factories       []server.IContextFactory
+}
+
+func NewTxHandler(logger *log.Logger, ctx context.Context, serviceProvider cscommon.IConstellationServiceProvider) *TxHandler {
+	h := &TxHandler{
+		logger:          logger,
+		ctx:             ctx,
+		serviceProvider: serviceProvider,
+	}
+	h.factories = []server.IContextFactory{
+		&txCancelContextFactory{h},
+		&txPendingContextFactory{h},
+		&txSpeedUpContextFactory{h},
+		&txStatusContextFactory{h},
+		&txSubmitSignedContextFactory{h},
+	}
+	return h
+}
+
+func (h *TxHandler) RegisterRoutes(router *mux.Router) {
+	subrouter := router.PathPrefix("/tx").Subrouter()
+	for _, factory := range h.factories {
+		factory.RegisterRoute(subrouter)
+	}
+}
@@ -0,0 +1,47 @@
+package cstx
+
+import (
+	"net/url"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/gorilla/mux"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/server"
+	apitypes "github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/wallet"
+)
+
+// ===============
+// === Factory ===
+// ===============
+
+type txPendingContextFactory struct {
+	handler *TxHandler
+}
+
+func (f *txPendingContextFactory) Create(args url.Values) (*txPendingContext, error) {
+	c := &txPendingContext{
+		handler: f.handler,
+	}
+	return c, nil
+}
+
+func (f *txPendingContextFactory) RegisterRoute(router *mux.Router) {
+	server.RegisterQuerylessGet[*txPendingContext, csapi.TxPendingData](
+		router, "pending", f, f.handler.logger.Logger, f.handler.serviceProvider,
+	)
+}
+
+// ===============
+// === Context ===
+// ===============
+
+type txPendingContext struct {
+	handler *TxHandler
+}
+
+func (c *txPendingContext) PrepareData(data *csapi.TxPendingData, walletStatus wallet.WalletStatus, opts *bind.TransactOpts) (apitypes.ResponseStatus, error) {
+	txTracker := c.handler.serviceProvider.GetTransactionTracker()
+	data.Transactions = txTracker.GetPendingTransactions()
+	return apitypes.ResponseStatus_Success, nil
+}
@@ -0,0 +1,112 @@
+package cstx
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/gorilla/mux"
+	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/server"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/services"
+	apitypes "github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/wallet"
+)
+
+// ===============
+// === Factory ===
+// ===============
+
+type txSpeedUpContextFactory struct {
+	handler *TxHandler
+}
+
+func (f *txSpeedUpContextFactory) Create(body csapi.TxReplaceBody) (*txSpeedUpContext, error) {
+	c := &txSpeedUpContext{
+		handler: f.handler,
+		nonce:   body.Nonce,
+	}
+	return c, nil
+}
+
+func (f *txSpeedUpContextFactory) RegisterRoute(router *mux.Router) {
+	server.RegisterQuerylessPost[*txSpeedUpContext, csapi.TxReplaceBody, csapi.TxReplaceData](
+		router, "speed-up", f, f.handler.logger.Logger, f.handler.serviceProvider,
+	)
+}
+
+// ===============
+// === Context ===
+// ===============
+
+type txSpeedUpContext struct {
+	handler *TxHandler
+	nonce   uint64
+}
+
+func (c *txSpeedUpContext) PrepareData(data *csapi.TxReplaceData, walletStatus wallet.WalletStatus, opts *bind.TransactOpts) (apitypes.ResponseStatus, error) {
+	return replaceTransaction(c.handler, walletStatus, opts, c.nonce, false, data)
+}
+
+// Replace a pending transaction that's being tracked by the daemon, either with a faster copy of itself or with a cancellation
+func replaceTransaction(handler *TxHandler, walletStatus wallet.WalletStatus, opts *bind.TransactOpts, nonce uint64, cancel bool, data *csapi.TxReplaceData) (apitypes.ResponseStatus, error) {
+	sp := handler.serviceProvider
+	ctx := handler.ctx
+	txTracker := sp.GetTransactionTracker()
+
+	// Requirements
+	err := sp.RequireWalletReady(walletStatus)
+	if err != nil {
+		return apitypes.ResponseStatus_WalletNotReady, err
+	}
+	err = sp.RequireEthClientSynced(ctx)
+	if err != nil {
+		if errors.Is(err, services.ErrExecutionClientNotSynced) {
+			return apitypes.ResponseStatus_ClientsNotSynced, err
+		}
+		return apitypes.ResponseStatus_Error, err
+	}
+
+	// Find the original TX
+	var original *csapi.TrackedTransaction
+	for _, tx := range txTracker.GetPendingTransactions() {
+		if tx.From == opts.From && tx.Nonce == nonce {
+			original = &tx
+			break
+		}
+	}
+	if original == nil {
+		return apitypes.ResponseStatus_InvalidArguments, fmt.Errorf("no pending transaction from %s with nonce %d is being tracked", opts.From.Hex(), nonce)
+	}
+
+	// Replace it
+	opts.Context = ctx
+	replacement, err := txTracker.ReplaceTransaction(ctx, opts, nonce, cancel)
+	if err != nil {
+		if errors.Is(err, cscommon.ErrTransactionNotTracked) {
+			return apitypes.ResponseStatus_InvalidArguments, err
+		}
+		return apitypes.ResponseStatus_Error, err
+	}
+	handler.logger.Info("Replaced pending transaction",
+		slog.String("description", replacement.Description),
+		slog.String("oldHash", original.Hash.Hex()),
+		slog.String("newHash", replacement.Hash.Hex()),
+		slog.Bool("cancel", cancel),
+	)
+	sp.GetEventBroker().Publish(csapi.Event{
+		Type:        csapi.EventType_TxSubmitted,
+		NodeAddress: replacement.From,
+		Minipool:    replacement.Minipool,
+		TxHash:      replacement.Hash,
+		Description: replacement.Description,
+	})
+	data.OriginalHash = original.Hash
+	data.NewHash = replacement.Hash
+	data.Nonce = replacement.Nonce
+	data.MaxFeePerGas = replacement.MaxFeePerGas
+	data.MaxPriorityFeePerGas = replacement.MaxPriorityFeePerGas
+	return apitypes.ResponseStatus_Success, nil
+}
@@ -0,0 +1,113 @@
+package cstx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/gorilla/mux"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/server"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/services"
+	nmcserver "github.com/rocket-pool/node-manager-core/api/server"
+	apitypes "github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/rocket-pool/node-manager-core/utils/input"
+	"github.com/rocket-pool/node-manager-core/wallet"
+)
+
+// ===============
+// === Factory ===
+// ===============
+
+type txStatusContextFactory struct {
+	handler *TxHandler
+}
+
+func (f *txStatusContextFactory) Create(args url.Values) (*txStatusContext, error) {
+	c := &txStatusContext{
+		handler: f.handler,
+	}
+	inputErrs := []error{
+		nmcserver.ValidateArg("hash", args, input.ValidateHash, &c.hash),
+	}
+	return c, errors.Join(inputErrs...)
+}
+
+func (f *txStatusContextFactory) RegisterRoute(router *mux.Router) {
+	server.RegisterQuerylessGet[*txStatusContext, csapi.TxStatusData](
+		router, "status", f, f.handler.logger.Logger, f.handler.serviceProvider,
+	)
+}
+
+// ===============
+// === Context ===
+// ===============
+
+type txStatusContext struct {
+	handler *TxHandler
+	hash    common.Hash
+}
+
+func (c *txStatusContext) PrepareData(data *csapi.TxStatusData, walletStatus wallet.WalletStatus, opts *bind.TransactOpts) (apitypes.ResponseStatus, error) {
+	sp := c.handler.serviceProvider
+	ctx := c.handler.ctx
+
+	// Requirements
+	err := sp.RequireEthClientSynced(ctx)
+	if err != nil {
+		if errors.Is(err, services.ErrExecutionClientNotSynced) {
+			return apitypes.ResponseStatus_ClientsNotSynced, err
+		}
+		return apitypes.ResponseStatus_Error, err
+	}
+
+	err = getTxStatus(ctx, sp.GetEthClient(), c.hash, data)
+	if err != nil {
+		return apitypes.ResponseStatus_Error, err
+	}
+	return apitypes.ResponseStatus_Success, nil
+}
+
+// Populate the status of a transaction, including its receipt details if it's been included in a block
+func getTxStatus(ctx context.Context, ec eth.IExecutionClient, hash common.Hash, data *csapi.TxStatusData) error {
+	data.TxHash = hash
+	tx, isPending, err := ec.TransactionByHash(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error getting transaction %s: %w", hash.Hex(), err)
+	}
+	data.Found = true
+	data.Pending = isPending
+	data.Nonce = tx.Nonce()
+	data.From, err = types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return fmt.Errorf("error getting sender of transaction %s: %w", hash.Hex(), err)
+	}
+	if isPending {
+		return nil
+	}
+
+	receipt, err := ec.TransactionReceipt(ctx, hash)
+	if err != nil {
+		return fmt.Errorf("error getting receipt for transaction %s: %w", hash.Hex(), err)
+	}
+	setReceiptDetails(receipt, data)
+	return nil
+}
+
+// Populate the status of a transaction using its receipt
+func setReceiptDetails(receipt *types.Receipt, data *csapi.TxStatusData) {
+	data.Pending = false
+	data.Included = true
+	data.Succeeded = (receipt.Status == types.ReceiptStatusSuccessful)
+	data.BlockNumber = receipt.BlockNumber.Uint64()
+	data.GasUsed = receipt.GasUsed
+}
@@ -0,0 +1,124 @@
+package cstx
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/gorilla/mux"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/server"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/services"
+	apitypes "github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/log"
+	"github.com/rocket-pool/node-manager-core/wallet"
+)
+
+const (
+	// The description given to signed transactions submitted through the API
+	signedTxDescription string = "Signed transaction"
+)
+
+// ===============
+// === Factory ===
+// ===============
+
+type txSubmitSignedContextFactory struct {
+	handler *TxHandler
+}
+
+func (f *txSubmitSignedContextFactory) Create(body csapi.TxSubmitSignedBody) (*txSubmitSignedContext, error) {
+	c := &txSubmitSignedContext{
+		handler: f.handler,
+		body:    body,
+	}
+	return c, nil
+}
+
+func (f *txSubmitSignedContextFactory) RegisterRoute(router *mux.Router) {
+	server.RegisterQuerylessPost[*txSubmitSignedContext, csapi.TxSubmitSignedBody, csapi.TxStatusData](
+		router, "submit-signed", f, f.handler.logger.Logger, f.handler.serviceProvider,
+	)
+}
+
+// ===============
+// === Context ===
+// ===============
+
+type txSubmitSignedContext struct {
+	handler *TxHandler
+	body    csapi.TxSubmitSignedBody
+}
+
+func (c *txSubmitSignedContext) PrepareData(data *csapi.TxStatusData, walletStatus wallet.WalletStatus, opts *bind.TransactOpts) (apitypes.ResponseStatus, error) {
+	sp := c.handler.serviceProvider
+	ctx := c.handler.ctx
+	ec := sp.GetEthClient()
+	logger := c.handler.logger
+
+	// Requirements
+	err := sp.RequireEthClientSynced(ctx)
+	if err != nil {
+		if errors.Is(err, services.ErrExecutionClientNotSynced) {
+			return apitypes.ResponseStatus_ClientsNotSynced, err
+		}
+		return apitypes.ResponseStatus_Error, err
+	}
+
+	// Decode the TX
+	tx := new(types.Transaction)
+	err = tx.UnmarshalBinary(c.body.SignedTx)
+	if err != nil {
+		return apitypes.ResponseStatus_InvalidArguments, fmt.Errorf("error decoding signed transaction: %w", err)
+	}
+	chainID, err := ec.ChainID(ctx)
+	if err != nil {
+		return apitypes.ResponseStatus_Error, fmt.Errorf("error getting chain ID: %w", err)
+	}
+	if tx.ChainId().Cmp(chainID) != 0 {
+		return apitypes.ResponseStatus_InvalidArguments, fmt.Errorf("transaction is for chain %s but the execution client is on chain %s", tx.ChainId().String(), chainID.String())
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(chainID), tx)
+	if err != nil {
+		return apitypes.ResponseStatus_InvalidArguments, fmt.Errorf("error recovering transaction sender: %w", err)
+	}
+
+	// Submit it
+	err = ec.SendTransaction(ctx, tx)
+	if err != nil {
+		return apitypes.ResponseStatus_Error, fmt.Errorf("error submitting transaction: %w", err)
+	}
+	logger.Info("Submitted signed transaction",
+		slog.String("hash", tx.Hash().Hex()),
+		slog.String("from", from.Hex()),
+		slog.Uint64("nonce", tx.Nonce()),
+	)
+	sp.GetEventBroker().Publish(csapi.Event{
+		Type:        csapi.EventType_TxSubmitted,
+		NodeAddress: from,
+		TxHash:      tx.Hash(),
+		Description: signedTxDescription,
+	})
+	data.TxHash = tx.Hash()
+	data.From = from
+	data.Nonce = tx.Nonce()
+	data.Found = true
+	data.Pending = true
+
+	// Track it so it shows up with the other pending TXs and can be sped up or cancelled; clients poll tx/status for
+	// its inclusion rather than holding the request open. Contract creations can't be replaced, so they aren't tracked.
+	if tx.To() == nil {
+		logger.Warn("Signed transaction is a contract creation, so it won't be tracked", slog.String("hash", tx.Hash().Hex()))
+		return apitypes.ResponseStatus_Success, nil
+	}
+	err = sp.GetTransactionTracker().TrackTransaction(tx, from, signedTxDescription, common.Address{}, time.Time{})
+	if err != nil {
+		// It's already been submitted, so the caller still needs the hash
+		logger.Warn("Error tracking signed transaction", slog.String("hash", tx.Hash().Hex()), log.Err(err))
+	}
+	return apitypes.ResponseStatus_Success, nil
+}
@@ -0,0 +1,28 @@
+package csapi
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/node-manager-core/eth"
+)
+
+// A transaction for one of the Constellation admin functions
+type AdminTxData struct {
+	Contract         common.Address       `json:"contract"`
+	Role             string               `json:"role"`
+	MissingRole      bool                 `json:"missingRole"`
+	TxInfo           *eth.TransactionInfo `json:"txInfo"`
+	UnsignedTx       *UnsignedTransaction `json:"unsignedTx,omitempty"`
+	SimulationRevert *RevertInfo          `json:"simulationRevert,omitempty"`
+}
+
+// Whether an address holds one of the Constellation access control roles
+type AdminRoleStatus struct {
+	Role     string         `json:"role"`
+	Contract common.Address `json:"contract"`
+	HasRole  bool           `json:"hasRole"`
+}
+
+type AdminRolesData struct {
+	Address common.Address    `json:"address"`
+	Roles   []AdminRoleStatus `json:"roles"`
+}
@@ -0,0 +1,48 @@
+package csapi
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	rptypes "github.com/rocket-pool/rocketpool-go/v2/types"
+)
+
+// The kind of activity an event describes
+type EventType string
+
+const (
+	// The task loop created a new network snapshot
+	EventType_SnapshotCreated EventType = "snapshotCreated"
+
+	// One of the node's minipools changed status
+	EventType_MinipoolStatusChanged EventType = "minipoolStatusChanged"
+
+	// The daemon submitted a transaction, or replaced a pending one
+	EventType_TxSubmitted EventType = "txSubmitted"
+
+	// A transaction submitted by the daemon was included in a block
+	EventType_TxMined EventType = "txMined"
+
+	// A signed exit message was uploaded to the NodeSet server
+	EventType_ExitUploaded EventType = "exitUploaded"
+
+	// One of the daemon's tasks failed
+	EventType_TaskError EventType = "taskError"
+)
+
+// An event from the daemon's activity stream; only the fields relevant to the event type are set
+type Event struct {
+	ID             uint64                 `json:"id"`
+	Type           EventType              `json:"type"`
+	Time           time.Time              `json:"time"`
+	Block          uint64                 `json:"block"`
+	NodeAddress    common.Address         `json:"nodeAddress"`
+	Minipool       common.Address         `json:"minipool"`
+	PreviousStatus rptypes.MinipoolStatus `json:"previousStatus"`
+	Status         rptypes.MinipoolStatus `json:"status"`
+	TxHash         common.Hash            `json:"txHash"`
+	Description    string                 `json:"description"`
+	Succeeded      bool                   `json:"succeeded"`
+	Task           string                 `json:"task"`
+	Error          string                 `json:"error"`
+}
@@ -7,11 +7,17 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/node-manager-core/beacon"
 
+	"github.com/rocket-pool/node-manager-core/api/types"
 	"github.com/rocket-pool/node-manager-core/eth"
 	rptypes "github.com/rocket-pool/rocketpool-go/v2/types"
 	snapi "github.com/rocket-pool/smartnode/v2/shared/types/api"
 )
 
+type MinipoolCloseData struct {
+	types.BatchTxInfoData
+	UnsignedTxs []*UnsignedTransaction `json:"unsignedTxs,omitempty"`
+}
+
 type MinipoolCloseDetailsData struct {
 	Details []snapi.MinipoolCloseDetails `json:"details"`
 }
@@ -85,6 +91,8 @@ type MinipoolCreateData struct {
 	Index                           uint64                 `json:"index"`
 	ScrubPeriod                     time.Duration          `json:"scrubPeriod"`
 	TxInfo                          *eth.TransactionInfo   `json:"txInfo"`
+	SimulationRevert                *RevertInfo            `json:"simulationRevert,omitempty"`
+	UnsignedTx                      *UnsignedTransaction   `json:"unsignedTx,omitempty"`
 }
 
 type MinipoolStakeDetails struct {
@@ -95,11 +103,13 @@ type MinipoolStakeDetails struct {
 	Address            common.Address         `json:"address"`
 	Pubkey             beacon.ValidatorPubkey `json:"pubkey"`
 	TxInfo             *eth.TransactionInfo   `json:"txInfo"`
+	SimulationRevert   *RevertInfo            `json:"simulationRevert,omitempty"`
 }
 
 type MinipoolStakeData struct {
 	NotWhitelistedWithConstellation bool                   `json:"notWhitelistedWithConstellation"`
 	Details                         []MinipoolStakeDetails `json:"details"`
+	UnsignedTxs                     []*UnsignedTransaction `json:"unsignedTxs,omitempty"`
 }
 
 type MinipoolUploadSignedExitBody struct {
@@ -115,3 +125,60 @@ type MinipoolVanityArtifactsData struct {
 type MinipoolGetPubkeysData struct {
 	Infos []MinipoolValidatorInfo `json:"infos"`
 }
+
+type MinipoolFeeRecipientViolation struct {
+	Slot                 uint64                 `json:"slot"`
+	Epoch                uint64                 `json:"epoch"`
+	ExecutionBlockNumber uint64                 `json:"executionBlockNumber"`
+	Address              common.Address         `json:"address"`
+	Pubkey               beacon.ValidatorPubkey `json:"pubkey"`
+	Index                string                 `json:"index"`
+	WrongFeeRecipient    bool                   `json:"wrongFeeRecipient"`
+	FeeRecipient         common.Address         `json:"feeRecipient"`
+	ExpectedFeeRecipient common.Address         `json:"expectedFeeRecipient"`
+	WrongGraffiti        bool                   `json:"wrongGraffiti"`
+	Graffiti             string                 `json:"graffiti"`
+	ExpectedGraffiti     string                 `json:"expectedGraffiti"`
+}
+type MinipoolFeeRecipientViolationsData struct {
+	ConfiguredFeeRecipient common.Address                  `json:"configuredFeeRecipient"`
+	SmoothingPoolAddress   common.Address                  `json:"smoothingPoolAddress"`
+	ConfigMismatch         bool                            `json:"configMismatch"`
+	ExpectedGraffiti       string                          `json:"expectedGraffiti"`
+	HasScanned             bool                            `json:"hasScanned"`
+	LastCheckedEpoch       uint64                          `json:"lastCheckedEpoch"`
+	Violations             []MinipoolFeeRecipientViolation `json:"violations"`
+}
+
+type MinipoolPerformanceDetails struct {
+	Address              common.Address         `json:"address"`
+	Pubkey               beacon.ValidatorPubkey `json:"pubkey"`
+	Index                string                 `json:"index"`
+	ValidatorNotSeenYet  bool                   `json:"validatorNotSeenYet"`
+	AttestationsExpected uint64                 `json:"attestationsExpected"`
+	AttestationsMissed   uint64                 `json:"attestationsMissed"`
+	ProposalsExpected    uint64                 `json:"proposalsExpected"`
+	ProposalsMade        uint64                 `json:"proposalsMade"`
+	ProposalsMissed      uint64                 `json:"proposalsMissed"`
+	SyncCommitteeEpochs  uint64                 `json:"syncCommitteeEpochs"`
+	SyncDutiesExpected   uint64                 `json:"syncDutiesExpected"`
+	SyncDutiesMissed     uint64                 `json:"syncDutiesMissed"`
+}
+type MinipoolPerformanceData struct {
+	StartEpoch   uint64                       `json:"startEpoch"`
+	EndEpoch     uint64                       `json:"endEpoch"`
+	CurrentEpoch uint64                       `json:"currentEpoch"`
+	Details      []MinipoolPerformanceDetails `json:"details"`
+}
+
+// The minipool status of a single node in the fleet
+type MinipoolFleetNodeStatus struct {
+	NodeAddress  common.Address      `json:"nodeAddress"`
+	IsDaemonNode bool                `json:"isDaemonNode"`
+	Error        string              `json:"error,omitempty"`
+	Status       *MinipoolStatusData `json:"status,omitempty"`
+}
+
+type MinipoolFleetStatusData struct {
+	Nodes []MinipoolFleetNodeStatus `json:"nodes"`
+}
@@ -2,26 +2,203 @@ package csapi
 
 import (
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 )
 
 type NetworkStatsData struct {
-	SubnodeCount                 int            `json:"subnodeCount"`
-	ActiveMinipoolCount          int            `json:"activeMinipoolCount"`
-	InitializedMinipoolCount     int            `json:"initializedMinipoolCount"`
-	PrelaunchMinipoolCount       int            `json:"prelaunchMinipoolCount"`
-	StakingMinipoolCount         int            `json:"stakingMinipoolCount"`
-	DissolvedMinipoolCount       int            `json:"dissolvedMinipoolCount"`
-	FinalizedMinipoolCount       int            `json:"finalizedMinipoolCount"`
-	SuperNodeAddress             common.Address `json:"superNodeAddress"`
-	SuperNodeRplStake            *big.Int       `json:"superNodeRplStake"`
-	ConstellationEthBalance      *big.Int       `json:"constellationEthBalance"`
-	ConstellationRplBalance      *big.Int       `json:"constellationRplBalance"`
-	RocketPoolEthBalance         *big.Int       `json:"rocketPoolEthBalance"`
-	MinipoolQueueLength          int            `json:"minipoolQueueLength"`
-	MinipoolQueueCapacity        *big.Int       `json:"minipoolQueueCapacity"`
-	RplPrice                     *big.Int       `json:"rplPrice"`
-	RocketPoolEthUtilizationRate *big.Int       `json:"rocketPoolEthUtilizationRate"`
-	ValidatorLimit               int            `json:"validatorLimit"`
+	SubnodeCount                 int                     `json:"subnodeCount"`
+	ActiveMinipoolCount          int                     `json:"activeMinipoolCount"`
+	InitializedMinipoolCount     int                     `json:"initializedMinipoolCount"`
+	PrelaunchMinipoolCount       int                     `json:"prelaunchMinipoolCount"`
+	StakingMinipoolCount         int                     `json:"stakingMinipoolCount"`
+	DissolvedMinipoolCount       int                     `json:"dissolvedMinipoolCount"`
+	FinalizedMinipoolCount       int                     `json:"finalizedMinipoolCount"`
+	SuperNodeAddress             common.Address          `json:"superNodeAddress"`
+	SuperNodeRplStake            *big.Int                `json:"superNodeRplStake"`
+	ConstellationEthBalance      *big.Int                `json:"constellationEthBalance"`
+	ConstellationRplBalance      *big.Int                `json:"constellationRplBalance"`
+	RocketPoolEthBalance         *big.Int                `json:"rocketPoolEthBalance"`
+	MinipoolQueueLength          int                     `json:"minipoolQueueLength"`
+	MinipoolQueueCapacity        *big.Int                `json:"minipoolQueueCapacity"`
+	RplPrice                     *big.Int                `json:"rplPrice"`
+	RocketPoolEthUtilizationRate *big.Int                `json:"rocketPoolEthUtilizationRate"`
+	ValidatorLimit               int                     `json:"validatorLimit"`
+	FleetNodes                   []NetworkFleetNodeStats `json:"fleetNodes"`
+}
+
+// Minipool counts for a single node in the fleet
+type NetworkFleetNodeStats struct {
+	NodeAddress              common.Address `json:"nodeAddress"`
+	IsDaemonNode             bool           `json:"isDaemonNode"`
+	ActiveMinipoolCount      int            `json:"activeMinipoolCount"`
+	InitializedMinipoolCount int            `json:"initializedMinipoolCount"`
+	PrelaunchMinipoolCount   int            `json:"prelaunchMinipoolCount"`
+	StakingMinipoolCount     int            `json:"stakingMinipoolCount"`
+	DissolvedMinipoolCount   int            `json:"dissolvedMinipoolCount"`
+	FinalizedMinipoolCount   int            `json:"finalizedMinipoolCount"`
+}
+
+// A forecast of gas prices based on the fee history of recent blocks
+type NetworkGasForecast struct {
+	LatestBlock               uint64        `json:"latestBlock"`
+	SampledBlocks             uint64        `json:"sampledBlocks"`
+	AverageBlockTime          time.Duration `json:"averageBlockTime"`
+	CurrentBaseFee            *big.Int      `json:"currentBaseFee"`
+	MedianBaseFee             *big.Int      `json:"medianBaseFee"`
+	TargetBaseFee             *big.Int      `json:"targetBaseFee"`
+	CheapBlockRatio           float64       `json:"cheapBlockRatio"`
+	ExpectedWaitForCheapBlock time.Duration `json:"expectedWaitForCheapBlock"`
+	SuggestedPriorityFee      *big.Int      `json:"suggestedPriorityFee"`
+}
+
+// The plan for submitting an automatic transaction
+type NetworkGasPlanEntry struct {
+	Task                 string         `json:"task"`
+	Minipool             common.Address `json:"minipool"`
+	EarliestSubmission   time.Time      `json:"earliestSubmission"`
+	Deadline             time.Time      `json:"deadline"`
+	SubmitNow            bool           `json:"submitNow"`
+	PlannedSubmission    time.Time      `json:"plannedSubmission"`
+	Reason               string         `json:"reason"`
+	MaxFeePerGas         *big.Int       `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *big.Int       `json:"maxPriorityFeePerGas"`
+}
+
+type NetworkGasPlanData struct {
+	Enabled  bool                  `json:"enabled"`
+	Forecast NetworkGasForecast    `json:"forecast"`
+	Entries  []NetworkGasPlanEntry `json:"entries"`
+}
+
+// The resource that limits how many more minipools Constellation can fund
+type NetworkLiquidityLimit string
+
+const (
+	// There's enough of both ETH and RPL to fund more minipools than were checked
+	NetworkLiquidityLimit_None NetworkLiquidityLimit = "none"
+
+	// The Operator Distributor doesn't have enough ETH for the bond of another minipool
+	NetworkLiquidityLimit_Eth NetworkLiquidityLimit = "eth"
+
+	// The Operator Distributor doesn't have enough RPL to cover the stake another minipool would need
+	NetworkLiquidityLimit_Rpl NetworkLiquidityLimit = "rpl"
+)
+
+// How many minipools Constellation could fund with a given amount of ETH and RPL
+type NetworkLiquidityScenario struct {
+	AvailableEth        *big.Int              `json:"availableEth"`
+	AvailableRpl        *big.Int              `json:"availableRpl"`
+	MinipoolCount       int                   `json:"minipoolCount"`
+	AtSearchLimit       bool                  `json:"atSearchLimit"`
+	LimitedBy           NetworkLiquidityLimit `json:"limitedBy"`
+	NextEthShortfall    *big.Int              `json:"nextEthShortfall"`
+	NextRplShortfall    *big.Int              `json:"nextRplShortfall"`
+	NextRplShortfallEth *big.Int              `json:"nextRplShortfallEth"`
+}
+
+type NetworkLiquidityData struct {
+	MinipoolBond                  *big.Int                 `json:"minipoolBond"`
+	BorrowedEthPerMinipool        *big.Int                 `json:"borrowedEthPerMinipool"`
+	RplPrice                      *big.Int                 `json:"rplPrice"`
+	SuperNodeRplStake             *big.Int                 `json:"superNodeRplStake"`
+	SuperNodeEthMatched           *big.Int                 `json:"superNodeEthMatched"`
+	OperatorDistributorEthBalance *big.Int                 `json:"operatorDistributorEthBalance"`
+	OperatorDistributorRplBalance *big.Int                 `json:"operatorDistributorRplBalance"`
+	WethVaultBalance              *big.Int                 `json:"wethVaultBalance"`
+	RplVaultBalance               *big.Int                 `json:"rplVaultBalance"`
+	EthDeposit                    *big.Int                 `json:"ethDeposit"`
+	RplDeposit                    *big.Int                 `json:"rplDeposit"`
+	WethVaultSurplus              *big.Int                 `json:"wethVaultSurplus"`
+	RplVaultSurplus               *big.Int                 `json:"rplVaultSurplus"`
+	Current                       NetworkLiquidityScenario `json:"current"`
+	WithVaultDeposits             NetworkLiquidityScenario `json:"withVaultDeposits"`
+}
+
+// An Upgraded event from one of the Constellation proxies
+type ProxyUpgradeEvent struct {
+	Contract       string         `json:"contract"`
+	Proxy          common.Address `json:"proxy"`
+	Implementation common.Address `json:"implementation"`
+	Block          uint64         `json:"block"`
+	TxHash         common.Hash    `json:"txHash"`
+	LogIndex       uint           `json:"logIndex"`
+}
+
+// An AdminChanged event from one of the Constellation proxies
+type ProxyAdminChangeEvent struct {
+	Contract      string         `json:"contract"`
+	Proxy         common.Address `json:"proxy"`
+	PreviousAdmin common.Address `json:"previousAdmin"`
+	NewAdmin      common.Address `json:"newAdmin"`
+	Block         uint64         `json:"block"`
+	TxHash        common.Hash    `json:"txHash"`
+	LogIndex      uint           `json:"logIndex"`
+}
+
+type NetworkProxyEventsData struct {
+	StartBlock   uint64                  `json:"startBlock"`
+	IndexedBlock uint64                  `json:"indexedBlock"`
+	LatestBlock  uint64                  `json:"latestBlock"`
+	IsCaughtUp   bool                    `json:"isCaughtUp"`
+	Upgrades     []ProxyUpgradeEvent     `json:"upgrades"`
+	AdminChanges []ProxyAdminChangeEvent `json:"adminChanges"`
+}
+
+// Why the keeper didn't process a minipool on its last check
+type KeeperSkipReason string
+
+const (
+	// Nothing was skipped
+	KeeperSkipReason_None KeeperSkipReason = ""
+
+	// The keeper's transaction from an earlier check is still pending
+	KeeperSkipReason_TxPending KeeperSkipReason = "txPending"
+
+	// The Operator Distributor doesn't have any minipools to process
+	KeeperSkipReason_NoMinipools KeeperSkipReason = "noMinipools"
+
+	// Processing the next minipool failed simulation
+	KeeperSkipReason_SimulationReverted KeeperSkipReason = "simulationReverted"
+
+	// The rewards processing would distribute aren't worth the gas it would cost
+	KeeperSkipReason_Unprofitable KeeperSkipReason = "unprofitable"
+
+	// Processing would take the keeper over its daily budget
+	KeeperSkipReason_OverBudget KeeperSkipReason = "overBudget"
+)
+
+// A minipool that was processed by the keeper
+type KeeperProcessedMinipool struct {
+	Minipool             common.Address `json:"minipool"`
+	TxHash               common.Hash    `json:"txHash"`
+	Time                 time.Time      `json:"time"`
+	DistributableRewards *big.Int       `json:"distributableRewards"`
+	EstimatedCost        *big.Int       `json:"estimatedCost"`
+	ActualCost           *big.Int       `json:"actualCost,omitempty"`
+}
+
+// What the minipool processing keeper has done since it was enabled
+type KeeperStats struct {
+	BudgetDay                time.Time                 `json:"budgetDay"`
+	SpentToday               *big.Int                  `json:"spentToday"`
+	TotalSpent               *big.Int                  `json:"totalSpent"`
+	TotalDistributed         *big.Int                  `json:"totalDistributed"`
+	ProcessedCount           uint64                    `json:"processedCount"`
+	SkippedUnprofitableCount uint64                    `json:"skippedUnprofitableCount"`
+	SkippedOverBudgetCount   uint64                    `json:"skippedOverBudgetCount"`
+	SkippedRevertedCount     uint64                    `json:"skippedRevertedCount"`
+	LastCheckTime            time.Time                 `json:"lastCheckTime"`
+	LastSkipReason           KeeperSkipReason          `json:"lastSkipReason"`
+	LastSkipMessage          string                    `json:"lastSkipMessage"`
+	RecentlyProcessed        []KeeperProcessedMinipool `json:"recentlyProcessed"`
+}
+
+type NetworkKeeperData struct {
+	Enabled           bool        `json:"enabled"`
+	DailyBudget       *big.Int    `json:"dailyBudget"`
+	MinRewardMultiple float64     `json:"minRewardMultiple"`
+	RemainingBudget   *big.Int    `json:"remainingBudget"`
+	Stats             KeeperStats `json:"stats"`
 }
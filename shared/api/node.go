@@ -1,6 +1,13 @@
 package csapi
 
-import "github.com/rocket-pool/node-manager-core/eth"
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/rocket-pool/node-manager-core/eth"
+	rptypes "github.com/rocket-pool/rocketpool-go/v2/types"
+)
 
 type NodeGetRegistrationStatusData struct {
 	Registered bool `json:"registered"`
@@ -8,8 +15,138 @@ type NodeGetRegistrationStatusData struct {
 
 type NodeRegisterData struct {
 	TxInfo                   *eth.TransactionInfo `json:"txInfo"`
+	UnsignedTx               *UnsignedTransaction `json:"unsignedTx,omitempty"`
 	NotAuthorized            bool                 `json:"notAuthorized"`
 	NotRegisteredWithNodeSet bool                 `json:"notRegisteredWithNodeSet"`
 	InvalidPermissions       bool                 `json:"invalidPermissions"`
 	IncorrectNodeAddress     bool                 `json:"incorrectNodeAddress"`
 }
+
+// The outcome of a diagnostic check
+type NodeDiagnosticStatus string
+
+const (
+	// The check passed
+	NodeDiagnosticStatus_Pass NodeDiagnosticStatus = "pass"
+
+	// The check failed, and the node won't be able to create minipools until it's resolved
+	NodeDiagnosticStatus_Fail NodeDiagnosticStatus = "fail"
+
+	// The check couldn't be run because an earlier check failed
+	NodeDiagnosticStatus_Skipped NodeDiagnosticStatus = "skipped"
+)
+
+// Identifiers for each of the diagnostic checks
+const (
+	NodeDiagnosticCheck_Wallet              string = "wallet"
+	NodeDiagnosticCheck_NodeSetRegistration string = "nodesetRegistration"
+	NodeDiagnosticCheck_RegisteredAddress   string = "registeredAddress"
+	NodeDiagnosticCheck_Whitelist           string = "whitelist"
+	NodeDiagnosticCheck_ValidatorLimit      string = "validatorLimit"
+	NodeDiagnosticCheck_Balance             string = "balance"
+	NodeDiagnosticCheck_Liquidity           string = "liquidity"
+)
+
+// A single item in the node's diagnostic checklist
+type NodeDiagnosticCheck struct {
+	ID          string               `json:"id"`
+	Title       string               `json:"title"`
+	Status      NodeDiagnosticStatus `json:"status"`
+	Details     string               `json:"details"`
+	Remediation string               `json:"remediation"`
+}
+
+type NodeDiagnoseData struct {
+	WalletAddress        common.Address        `json:"walletAddress"`
+	RegisteredAddress    common.Address        `json:"registeredAddress"`
+	ActiveValidatorCount *big.Int              `json:"activeValidatorCount"`
+	MaxValidators        *big.Int              `json:"maxValidators"`
+	NodeBalance          *big.Int              `json:"nodeBalance"`
+	LockThreshold        *big.Int              `json:"lockThreshold"`
+	Passed               bool                  `json:"passed"`
+	Checks               []NodeDiagnosticCheck `json:"checks"`
+}
+
+// The operator's stake in, and earnings from, one of their minipools
+type NodePositionMinipool struct {
+	Address             common.Address         `json:"address"`
+	Pubkey              beacon.ValidatorPubkey `json:"pubkey"`
+	Status              rptypes.MinipoolStatus `json:"status"`
+	IsFinalised         bool                   `json:"isFinalised"`
+	Lockup              *big.Int               `json:"lockup"`
+	BondShare           *big.Int               `json:"bondShare"`
+	NodeFee             *big.Int               `json:"nodeFee"`
+	TreasuryFee         *big.Int               `json:"treasuryFee"`
+	DistributableSkim   *big.Int               `json:"distributableSkim"`
+	NodeShareOfSkim     *big.Int               `json:"nodeShareOfSkim"`
+	AccruedOperatorFees *big.Int               `json:"accruedOperatorFees"`
+	BeaconBalance       *big.Int               `json:"beaconBalance"`
+	AprAvailable        bool                   `json:"aprAvailable"`
+	Apr                 float64                `json:"apr"`
+}
+
+// Whether the supernode has claimed its Rocket Pool merkle rewards for an interval, and how much it got if so.
+// The amounts come from the event index, so they're nil for claims it hasn't reached yet; unclaimed amounts are only
+// in the interval's rewards tree, so they aren't reported.
+type NodePositionRewardInterval struct {
+	Index      uint64      `json:"index"`
+	Claimed    bool        `json:"claimed"`
+	ClaimedEth *big.Int    `json:"claimedEth"`
+	ClaimedRpl *big.Int    `json:"claimedRpl"`
+	ClaimTx    common.Hash `json:"claimTx"`
+}
+
+type NodePositionData struct {
+	NodeAddress              common.Address               `json:"nodeAddress"`
+	SuperNodeAddress         common.Address               `json:"superNodeAddress"`
+	TotalLockup              *big.Int                     `json:"totalLockup"`
+	TotalBondShare           *big.Int                     `json:"totalBondShare"`
+	TotalDistributableSkim   *big.Int                     `json:"totalDistributableSkim"`
+	TotalAccruedOperatorFees *big.Int                     `json:"totalAccruedOperatorFees"`
+	AprDays                  uint64                       `json:"aprDays"`
+	AprStartEpoch            uint64                       `json:"aprStartEpoch"`
+	AprEndEpoch              uint64                       `json:"aprEndEpoch"`
+	AprAvailable             bool                         `json:"aprAvailable"`
+	Apr                      float64                      `json:"apr"`
+	AprError                 string                       `json:"aprError,omitempty"`
+	Minipools                []NodePositionMinipool       `json:"minipools"`
+	FirstRewardInterval      uint64                       `json:"firstRewardInterval"`
+	RewardIntervals          []NodePositionRewardInterval `json:"rewardIntervals"`
+	ClaimedRewardIntervals   uint64                       `json:"claimedRewardIntervals"`
+	UnclaimedRewardIntervals uint64                       `json:"unclaimedRewardIntervals"`
+	TotalClaimedEth          *big.Int                     `json:"totalClaimedEth"`
+	TotalClaimedRpl          *big.Int                     `json:"totalClaimedRpl"`
+	RewardClaimsIndexed      bool                         `json:"rewardClaimsIndexed"`
+	StreamingEthRewards      *big.Int                     `json:"streamingEthRewards"`
+	StreamingRplRewards      *big.Int                     `json:"streamingRplRewards"`
+	Csv                      string                       `json:"csv,omitempty"`
+}
+
+// The supernode's share of a Rocket Pool rewards interval, from a RewardsClaimed event of the merkle distributor
+type RewardClaimEvent struct {
+	Interval  uint64      `json:"interval"`
+	AmountEth *big.Int    `json:"amountEth"`
+	AmountRpl *big.Int    `json:"amountRpl"`
+	Block     uint64      `json:"block"`
+	TxHash    common.Hash `json:"txHash"`
+	LogIndex  uint        `json:"logIndex"`
+}
+
+// A MinipoolCreated or MinipoolDestroyed event from the SuperNodeAccount
+type MinipoolContractEvent struct {
+	Minipool common.Address `json:"minipool"`
+	Operator common.Address `json:"operator"`
+	Block    uint64         `json:"block"`
+	TxHash   common.Hash    `json:"txHash"`
+	LogIndex uint           `json:"logIndex"`
+}
+
+type NodeMinipoolEventsData struct {
+	NodeAddress  common.Address          `json:"nodeAddress"`
+	StartBlock   uint64                  `json:"startBlock"`
+	IndexedBlock uint64                  `json:"indexedBlock"`
+	LatestBlock  uint64                  `json:"latestBlock"`
+	IsCaughtUp   bool                    `json:"isCaughtUp"`
+	Created      []MinipoolContractEvent `json:"created"`
+	Destroyed    []MinipoolContractEvent `json:"destroyed"`
+}
@@ -0,0 +1,60 @@
+package csapi
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// The kind of event a notification was sent for
+type NotificationEvent string
+
+const (
+	// A minipool stake transaction was included in a block
+	NotificationEvent_StakeSucceeded NotificationEvent = "stakeSucceeded"
+
+	// A minipool stake transaction couldn't be submitted, or it reverted
+	NotificationEvent_StakeFailed NotificationEvent = "stakeFailed"
+
+	// Signed exit messages couldn't be uploaded to the NodeSet server
+	NotificationEvent_ExitUploadFailed NotificationEvent = "exitUploadFailed"
+
+	// NodeSet reported that the user account has a different node registered for Constellation
+	NotificationEvent_IncorrectNodeAddress NotificationEvent = "incorrectNodeAddress"
+
+	// NodeSet reported that the user account doesn't have permission to use Constellation
+	NotificationEvent_InvalidPermissions NotificationEvent = "invalidPermissions"
+
+	// The Execution Client or Beacon Node has been out of sync for longer than the configured threshold
+	NotificationEvent_ClientDesync NotificationEvent = "clientDesync"
+
+	// A prelaunch minipool is close to its launch timeout and still hasn't been staked
+	NotificationEvent_LaunchTimeoutApproaching NotificationEvent = "launchTimeoutApproaching"
+
+	// A signed xrETH yield update was rejected instead of being relayed to the oracle
+	NotificationEvent_YieldUpdateRejected NotificationEvent = "yieldUpdateRejected"
+
+	// The network settings have addresses that don't match the ones the Constellation Directory points to
+	NotificationEvent_ResourceMismatch NotificationEvent = "resourceMismatch"
+)
+
+// How urgent a notification is
+type NotificationSeverity string
+
+const (
+	NotificationSeverity_Info    NotificationSeverity = "info"
+	NotificationSeverity_Warning NotificationSeverity = "warning"
+	NotificationSeverity_Error   NotificationSeverity = "error"
+)
+
+// A notification about a lifecycle event; this is the JSON body of webhook requests
+type Notification struct {
+	Event       NotificationEvent    `json:"event"`
+	Severity    NotificationSeverity `json:"severity"`
+	Title       string               `json:"title"`
+	Message     string               `json:"message"`
+	NodeAddress common.Address       `json:"nodeAddress"`
+	Minipool    common.Address       `json:"minipool"`
+	TxHash      common.Hash          `json:"txHash"`
+	Time        time.Time            `json:"time"`
+}
@@ -1,6 +1,14 @@
 package csapi
 
-import csconfig "github.com/nodeset-org/hyperdrive-constellation/shared/config"
+import (
+	"encoding/json"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	csconfig "github.com/nodeset-org/hyperdrive-constellation/shared/config"
+	"github.com/rocket-pool/node-manager-core/beacon"
+)
 
 type ServiceGetResourcesData struct {
 	Resources *csconfig.MergedResources `json:"resources"`
@@ -17,3 +25,102 @@ type ServiceGetConfigData struct {
 type ServiceVersionData struct {
 	Version string `json:"version"`
 }
+
+type ServiceDutyType string
+
+const (
+	ServiceDutyType_Proposal      ServiceDutyType = "proposal"
+	ServiceDutyType_SyncCommittee ServiceDutyType = "syncCommittee"
+)
+
+type ServiceUpcomingDuty struct {
+	Type       ServiceDutyType        `json:"type"`
+	Epoch      uint64                 `json:"epoch"`
+	EpochStart time.Time              `json:"epochStart"`
+	Count      uint64                 `json:"count"`
+	Address    common.Address         `json:"address"`
+	Pubkey     beacon.ValidatorPubkey `json:"pubkey"`
+	Index      string                 `json:"index"`
+}
+
+type ServiceMaintenanceWindowData struct {
+	CurrentEpoch      uint64                `json:"currentEpoch"`
+	LastKnownEpoch    uint64                `json:"lastKnownEpoch"`
+	NoActiveDuties    bool                  `json:"noActiveDuties"`
+	Duties            []ServiceUpcomingDuty `json:"duties"`
+	HasWindow         bool                  `json:"hasWindow"`
+	WindowStart       time.Time             `json:"windowStart"`
+	WindowEnd         time.Time             `json:"windowEnd"`
+	WindowDuration    time.Duration         `json:"windowDuration"`
+	WindowIsOpenEnded bool                  `json:"windowIsOpenEnded"`
+	SafeToRestartNow  bool                  `json:"safeToRestartNow"`
+}
+
+type ServiceRestartVcBody struct {
+	Force bool `json:"force"`
+}
+
+type ServiceRestartVcData struct {
+	Restarted     bool                  `json:"restarted"`
+	BlockedByDuty bool                  `json:"blockedByDuty"`
+	Duties        []ServiceUpcomingDuty `json:"duties"`
+}
+
+type ServiceHealthData struct {
+	Healthy                  bool      `json:"healthy"`
+	Ready                    bool      `json:"ready"`
+	StartTime                time.Time `json:"startTime"`
+	TaskLoopStalled          bool      `json:"taskLoopStalled"`
+	LastTaskLoopUpdate       time.Time `json:"lastTaskLoopUpdate"`
+	ExecutionClientSynced    bool      `json:"executionClientSynced"`
+	BeaconNodeSynced         bool      `json:"beaconNodeSynced"`
+	WalletReady              bool      `json:"walletReady"`
+	NodeSetRegistered        bool      `json:"nodeSetRegistered"`
+	ConstellationWhitelisted bool      `json:"constellationWhitelisted"`
+	HasSnapshot              bool      `json:"hasSnapshot"`
+	LastSnapshotBlock        uint64    `json:"lastSnapshotBlock"`
+	LastSnapshotTime         time.Time `json:"lastSnapshotTime"`
+	Issues                   []string  `json:"issues"`
+}
+
+// A transaction returned by an audited API call, for the client to sign and submit
+type AuditLogTransaction struct {
+	To              common.Address `json:"to"`
+	Value           *big.Int       `json:"value"`
+	DataHash        common.Hash    `json:"dataHash"`
+	SimulationError string         `json:"simulationError"`
+}
+
+// A record of a state-changing API call.
+// Each entry includes the hash of the one before it, so any modification or removal of an entry breaks the chain.
+type AuditLogEntry struct {
+	Sequence      uint64                `json:"sequence"`
+	Time          time.Time             `json:"time"`
+	Method        string                `json:"method"`
+	Route         string                `json:"route"`
+	RemoteAddress string                `json:"remoteAddress"`
+	UserAgent     string                `json:"userAgent"`
+	Arguments     map[string]string     `json:"arguments"`
+	Body          json.RawMessage       `json:"body,omitempty"`
+	StatusCode    int                   `json:"statusCode"`
+	Error         string                `json:"error"`
+	Transactions  []AuditLogTransaction `json:"transactions"`
+	TxHash        *common.Hash          `json:"txHash,omitempty"`
+	PreviousHash  common.Hash           `json:"previousHash"`
+	Hash          common.Hash           `json:"hash"`
+}
+
+type ServiceAuditLogData struct {
+	Entries         []AuditLogEntry `json:"entries"`
+	TotalEntries    uint64          `json:"totalEntries"`
+	ChainIntact     bool            `json:"chainIntact"`
+	InvalidSequence uint64          `json:"invalidSequence"`
+}
+
+// Reloading doesn't take any parameters, but it changes the daemon's state so it's a POST
+type ServiceReloadSettingsBody struct{}
+
+type ServiceReloadSettingsData struct {
+	Changed  bool                            `json:"changed"`
+	Settings *csconfig.ConstellationSettings `json:"settings"`
+}
@@ -0,0 +1,94 @@
+package csapi
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// An unsigned EIP-1559 transaction that can be signed offline (e.g. with a hardware wallet) and submitted later
+type UnsignedTransaction struct {
+	Type                 uint8          `json:"type"`
+	ChainID              *big.Int       `json:"chainId"`
+	From                 common.Address `json:"from"`
+	To                   common.Address `json:"to"`
+	Nonce                uint64         `json:"nonce"`
+	Value                *big.Int       `json:"value"`
+	Data                 hexutil.Bytes  `json:"data"`
+	GasLimit             uint64         `json:"gasLimit"`
+	MaxFeePerGas         *big.Int       `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *big.Int       `json:"maxPriorityFeePerGas"`
+	SimulationError      string         `json:"simulationError,omitempty"`
+}
+
+// A decoded argument of a contract's custom error
+type RevertArgument struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// Why a transaction would revert, decoded from the contract's custom errors where possible
+type RevertInfo struct {
+	Error     string           `json:"error"`
+	Selector  hexutil.Bytes    `json:"selector,omitempty"`
+	Arguments []RevertArgument `json:"arguments"`
+	Reason    string           `json:"reason,omitempty"`
+	Message   string           `json:"message"`
+}
+
+type TxSubmitSignedBody struct {
+	SignedTx hexutil.Bytes `json:"signedTx"`
+}
+
+type TxStatusData struct {
+	TxHash      common.Hash    `json:"txHash"`
+	From        common.Address `json:"from"`
+	Nonce       uint64         `json:"nonce"`
+	Found       bool           `json:"found"`
+	Pending     bool           `json:"pending"`
+	Included    bool           `json:"included"`
+	Succeeded   bool           `json:"succeeded"`
+	BlockNumber uint64         `json:"blockNumber"`
+	GasUsed     uint64         `json:"gasUsed"`
+}
+
+// A transaction submitted by the daemon that hasn't been included in a block yet
+type TrackedTransaction struct {
+	Hash                 common.Hash    `json:"hash"`
+	PreviousHashes       []common.Hash  `json:"previousHashes"`
+	Description          string         `json:"description"`
+	Minipool             common.Address `json:"minipool"`
+	From                 common.Address `json:"from"`
+	To                   common.Address `json:"to"`
+	Nonce                uint64         `json:"nonce"`
+	Value                *big.Int       `json:"value"`
+	Data                 hexutil.Bytes  `json:"data"`
+	GasLimit             uint64         `json:"gasLimit"`
+	MaxFeePerGas         *big.Int       `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *big.Int       `json:"maxPriorityFeePerGas"`
+	SubmissionTime       time.Time      `json:"submissionTime"`
+	LastReplacementTime  time.Time      `json:"lastReplacementTime"`
+	ReplacementCount     int            `json:"replacementCount"`
+	HasDeadline          bool           `json:"hasDeadline"`
+	Deadline             time.Time      `json:"deadline"`
+	IsCancellation       bool           `json:"isCancellation"`
+}
+
+type TxPendingData struct {
+	Transactions []TrackedTransaction `json:"transactions"`
+}
+
+type TxReplaceBody struct {
+	Nonce uint64 `json:"nonce"`
+}
+
+type TxReplaceData struct {
+	OriginalHash         common.Hash `json:"originalHash"`
+	NewHash              common.Hash `json:"newHash"`
+	Nonce                uint64      `json:"nonce"`
+	MaxFeePerGas         *big.Int    `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *big.Int    `json:"maxPriorityFeePerGas"`
+}
@@ -2,7 +2,10 @@ package csconfig
 
 import (
 	"fmt"
+	"strings"
+	"sync"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/nodeset-org/hyperdrive-constellation/shared"
 	"github.com/nodeset-org/hyperdrive-constellation/shared/config/ids"
 	hdconfig "github.com/nodeset-org/hyperdrive-daemon/shared/config"
@@ -26,6 +29,48 @@ type ConstellationConfig struct {
 	// The Docker Hub tag for the Constellation daemon
 	DaemonContainerTag config.Parameter[string]
 
+	// Toggle for using the fee history forecast to time automatic transactions instead of the flat gas threshold
+	GasStrategyEnabled config.Parameter[bool]
+
+	// Number of recent blocks to sample when forecasting gas prices
+	GasSampleBlocks config.Parameter[uint64]
+
+	// Percentile of the sampled base fees that's considered cheap enough to submit at
+	GasCheapPercentile config.Parameter[float64]
+
+	// Percentile of the sampled priority fees to use as the tip
+	GasTipPercentile config.Parameter[float64]
+
+	// Comma-separated list of additional sub-node addresses to monitor alongside the daemon's own node
+	FleetNodeAddresses config.Parameter[string]
+
+	// Number of blocks behind the head of the chain to take network snapshots at
+	SnapshotConfirmationDepth config.Parameter[uint64]
+
+	// Toggle for the API routes that build Constellation admin transactions
+	AdminRoutesEnabled config.Parameter[bool]
+
+	// Toggle for the keeper that processes Constellation's minipools as a public good
+	KeeperEnabled config.Parameter[bool]
+
+	// Most ETH the keeper can spend on gas per day
+	KeeperDailyBudget config.Parameter[float64]
+
+	// How many times the gas cost the rewards distributed by processing a minipool have to be worth
+	KeeperMinRewardMultiple config.Parameter[float64]
+
+	// Toggle for relaying signed xrETH yield updates to the PoA Constellation Oracle
+	OracleReporterEnabled config.Parameter[bool]
+
+	// URL to fetch signed xrETH yield updates from
+	OracleReporterEndpoint config.Parameter[string]
+
+	// Oldest a yield update can be, in minutes, before it's considered stale
+	OracleReporterMaxAge config.Parameter[uint64]
+
+	// Notification settings
+	Notifications *NotificationsConfig
+
 	// Validator client configs
 	VcCommon   *config.ValidatorClientCommonConfig
 	Lighthouse *config.LighthouseVcConfig
@@ -35,9 +80,11 @@ type ConstellationConfig struct {
 	Teku       *config.TekuVcConfig
 
 	// Internal fields
-	Version         string
-	hdCfg           *hdconfig.HyperdriveConfig
-	networkSettings []*ConstellationSettings
+	Version          string
+	hdCfg            *hdconfig.HyperdriveConfig
+	networkSettings  []*ConstellationSettings
+	networkLock      *sync.RWMutex
+	migrationChanges []string
 }
 
 // Generates a new Constellation config
@@ -45,6 +92,7 @@ func NewConstellationConfig(hdCfg *hdconfig.HyperdriveConfig, networks []*Conste
 	cfg := &ConstellationConfig{
 		hdCfg:           hdCfg,
 		networkSettings: networks,
+		networkLock:     &sync.RWMutex{},
 
 		Enabled: config.Parameter[bool]{
 			ParameterCommon: &config.ParameterCommon{
@@ -87,8 +135,191 @@ func NewConstellationConfig(hdCfg *hdconfig.HyperdriveConfig, networks []*Conste
 				config.Network_All: daemonTag,
 			},
 		},
+
+		GasStrategyEnabled: config.Parameter[bool]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 ids.GasStrategyEnableID,
+				Name:               "Enable Gas Forecasting",
+				Description:        "Enable this to have the daemon sample recent base fees and wait for a cheap window before automatically submitting transactions such as minipool stakes, instead of using Hyperdrive's flat Auto TX Gas Threshold. Transactions will still be submitted in time to meet their deadlines.",
+				AffectsContainers:  []config.ContainerID{ContainerID_ConstellationDaemon},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[config.Network]bool{
+				config.Network_All: false,
+			},
+		},
+
+		GasSampleBlocks: config.Parameter[uint64]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 ids.GasSampleBlocksID,
+				Name:               "Gas Sample Blocks",
+				Description:        "The number of recent blocks to sample base fees from when forecasting gas prices. Most Execution Clients cap this at 1024 blocks (a little under 3.5 hours).",
+				AffectsContainers:  []config.ContainerID{ContainerID_ConstellationDaemon},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[config.Network]uint64{
+				config.Network_All: DefaultGasSampleBlocks,
+			},
+		},
+
+		GasCheapPercentile: config.Parameter[float64]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 ids.GasCheapPercentileID,
+				Name:               "Cheap Gas Percentile",
+				Description:        "The percentile of the sampled base fees that's considered cheap enough to submit a transaction at. Lower values save more on gas but mean waiting longer.",
+				AffectsContainers:  []config.ContainerID{ContainerID_ConstellationDaemon},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[config.Network]float64{
+				config.Network_All: DefaultGasCheapPercentile,
+			},
+		},
+
+		GasTipPercentile: config.Parameter[float64]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 ids.GasTipPercentileID,
+				Name:               "Priority Fee Percentile",
+				Description:        "The percentile of the priority fees paid in recent blocks to use as the tip for automatic transactions when gas forecasting is enabled.",
+				AffectsContainers:  []config.ContainerID{ContainerID_ConstellationDaemon},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[config.Network]float64{
+				config.Network_All: DefaultGasTipPercentile,
+			},
+		},
+
+		FleetNodeAddresses: config.Parameter[string]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 ids.FleetNodeAddressesID,
+				Name:               "Fleet Node Addresses",
+				Description:        "A comma-separated list of other Constellation sub-node addresses to monitor alongside this node, such as ones run by the rest of your team. Their minipools will be included in the fleet status reports, and the task loop will warn you (and send launch timeout notifications) when one of them needs to be staked. These nodes are monitored in read-only mode; the daemon can only sign transactions for its own wallet, so their operators still need to run their own daemons to stake minipools, submit exits and so on.",
+				AffectsContainers:  []config.ContainerID{ContainerID_ConstellationDaemon},
+				CanBeBlank:         true,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[config.Network]string{
+				config.Network_All: "",
+			},
+		},
+
+		SnapshotConfirmationDepth: config.Parameter[uint64]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 ids.SnapshotConfirmationDepthID,
+				Name:               "Snapshot Confirmation Depth",
+				Description:        "The number of blocks behind the head of the chain that the daemon should take its network snapshots at, so its decisions are based on state that's less likely to be reorged out. Use 0 to take snapshots at the latest block; the most allowed is 64, two epochs. Regardless of this setting, the daemon will take a new snapshot if the one it's using is reorged out before it submits minipool stakes.",
+				AffectsContainers:  []config.ContainerID{ContainerID_ConstellationDaemon},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[config.Network]uint64{
+				config.Network_All: DefaultSnapshotConfirmationDepth,
+			},
+		},
+
+		AdminRoutesEnabled: config.Parameter[bool]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 ids.AdminRoutesEnableID,
+				Name:               "Enable Admin Routes",
+				Description:        "Enable the API routes that build transactions for Constellation's admin functions, such as changing fees or claiming from the Treasury. These are only useful to protocol operators whose wallet holds the relevant role; the daemon checks the role before building each transaction. Leave this disabled on ordinary nodes.",
+				AffectsContainers:  []config.ContainerID{ContainerID_ConstellationDaemon},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[config.Network]bool{
+				config.Network_All: false,
+			},
+		},
+
+		KeeperEnabled: config.Parameter[bool]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 ids.KeeperEnableID,
+				Name:               "Enable Minipool Processing Keeper",
+				Description:        "Enable this to have the daemon call the OperatorDistributor's processNextMinipool function, which distributes the rewards in Constellation's minipools and keeps the vaults balanced. This is a public good that benefits every Constellation operator; the gas is paid by your node wallet, within the daily budget below.",
+				AffectsContainers:  []config.ContainerID{ContainerID_ConstellationDaemon},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[config.Network]bool{
+				config.Network_All: false,
+			},
+		},
+
+		KeeperDailyBudget: config.Parameter[float64]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 ids.KeeperDailyBudgetID,
+				Name:               "Keeper Daily Budget",
+				Description:        "The most ETH the minipool processing keeper can spend on gas per day (UTC). Once it's been spent, processing waits until the next day.",
+				AffectsContainers:  []config.ContainerID{ContainerID_ConstellationDaemon},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[config.Network]float64{
+				config.Network_All: DefaultKeeperDailyBudget,
+			},
+		},
+
+		KeeperMinRewardMultiple: config.Parameter[float64]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 ids.KeeperMinRewardMultipleID,
+				Name:               "Keeper Minimum Reward Multiple",
+				Description:        "The keeper only processes a minipool if the rewards that processing would distribute are worth at least this many times the gas it costs. Use 0 to process minipools regardless of their rewards.",
+				AffectsContainers:  []config.ContainerID{ContainerID_ConstellationDaemon},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[config.Network]float64{
+				config.Network_All: DefaultKeeperMinRewardMultiple,
+			},
+		},
+
+		OracleReporterEnabled: config.Parameter[bool]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 ids.OracleReporterEnableID,
+				Name:               "Enable xrETH Oracle Reporter",
+				Description:        "Enable this to have the daemon relay signed xrETH yield updates to the PoA Constellation Oracle. Updates are checked against the oracle and the Operator Distributor before they're submitted, and the gas is paid by your node wallet. This requires the Oracle Reporter Endpoint to be set.",
+				AffectsContainers:  []config.ContainerID{ContainerID_ConstellationDaemon},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[config.Network]bool{
+				config.Network_All: false,
+			},
+		},
+
+		OracleReporterEndpoint: config.Parameter[string]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 ids.OracleReporterEndpointID,
+				Name:               "Oracle Reporter Endpoint",
+				Description:        "The URL of the service that publishes signed xrETH yield updates. The NodeSet server doesn't provide them through Hyperdrive yet, so the reporter can't run without this.",
+				AffectsContainers:  []config.ContainerID{ContainerID_ConstellationDaemon},
+				CanBeBlank:         true,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[config.Network]string{
+				config.Network_All: "",
+			},
+		},
+
+		OracleReporterMaxAge: config.Parameter[uint64]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 ids.OracleReporterMaxAgeID,
+				Name:               "Oracle Reporter Max Age",
+				Description:        "The oldest a signed yield update can be, in minutes, compared to the latest block. Older updates are rejected as stale.",
+				AffectsContainers:  []config.ContainerID{ContainerID_ConstellationDaemon},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[config.Network]uint64{
+				config.Network_All: DefaultOracleReporterMaxAge,
+			},
+		},
 	}
 
+	cfg.Notifications = NewNotificationsConfig()
 	cfg.VcCommon = config.NewValidatorClientCommonConfig()
 	cfg.VcCommon.MetricsPort.Default[config.Network_All] = DefaultVcMetricsPort
 	cfg.Lighthouse = config.NewLighthouseVcConfig()
@@ -121,18 +352,32 @@ func (cfg *ConstellationConfig) GetParameters() []config.IParameter {
 		&cfg.Enabled,
 		&cfg.ApiPort,
 		&cfg.DaemonContainerTag,
+		&cfg.GasStrategyEnabled,
+		&cfg.GasSampleBlocks,
+		&cfg.GasCheapPercentile,
+		&cfg.GasTipPercentile,
+		&cfg.FleetNodeAddresses,
+		&cfg.SnapshotConfirmationDepth,
+		&cfg.AdminRoutesEnabled,
+		&cfg.KeeperEnabled,
+		&cfg.KeeperDailyBudget,
+		&cfg.KeeperMinRewardMultiple,
+		&cfg.OracleReporterEnabled,
+		&cfg.OracleReporterEndpoint,
+		&cfg.OracleReporterMaxAge,
 	}
 }
 
 // Get the sections underneath this one
 func (cfg *ConstellationConfig) GetSubconfigs() map[string]config.IConfigSection {
 	return map[string]config.IConfigSection{
-		ids.VcCommonID:   cfg.VcCommon,
-		ids.LighthouseID: cfg.Lighthouse,
-		ids.LodestarID:   cfg.Lodestar,
-		ids.NimbusID:     cfg.Nimbus,
-		ids.PrysmID:      cfg.Prysm,
-		ids.TekuID:       cfg.Teku,
+		ids.NotificationsID: cfg.Notifications,
+		ids.VcCommonID:      cfg.VcCommon,
+		ids.LighthouseID:    cfg.Lighthouse,
+		ids.LodestarID:      cfg.Lodestar,
+		ids.NimbusID:        cfg.Nimbus,
+		ids.PrysmID:         cfg.Prysm,
+		ids.TekuID:          cfg.Teku,
 	}
 }
 
@@ -144,7 +389,7 @@ func (cfg *ConstellationConfig) ChangeNetwork(oldNetwork config.Network, newNetw
 
 // Creates a copy of the configuration
 func (cfg *ConstellationConfig) Clone() hdconfig.IModuleConfig {
-	clone, _ := NewConstellationConfig(cfg.hdCfg, cfg.networkSettings)
+	clone, _ := NewConstellationConfig(cfg.hdCfg, cfg.GetNetworkSettings())
 	config.Clone(cfg, clone, cfg.hdCfg.Network.Value)
 	clone.Version = cfg.Version
 	return clone
@@ -158,6 +403,78 @@ func (cfg *ConstellationConfig) UpdateDefaults(network config.Network) {
 // Checks to see if the current configuration is valid; if not, returns a list of errors
 func (cfg *ConstellationConfig) Validate() []string {
 	errors := []string{}
+	_, err := cfg.GetFleetNodeAddresses()
+	if err != nil {
+		errors = append(errors, err.Error())
+	}
+	errors = append(errors, cfg.Notifications.Validate()...)
+
+	// Make sure the gas strategy's settings are usable
+	if cfg.GasStrategyEnabled.Value {
+		if cfg.GasSampleBlocks.Value == 0 {
+			errors = append(errors, "the number of blocks to sample for the gas strategy must be greater than 0")
+		}
+		if cfg.GasCheapPercentile.Value < 0 || cfg.GasCheapPercentile.Value > 100 {
+			errors = append(errors, fmt.Sprintf("the gas strategy's cheap base fee percentile (%v) must be between 0 and 100", cfg.GasCheapPercentile.Value))
+		}
+		if cfg.GasTipPercentile.Value < 0 || cfg.GasTipPercentile.Value > 100 {
+			errors = append(errors, fmt.Sprintf("the gas strategy's priority fee percentile (%v) must be between 0 and 100", cfg.GasTipPercentile.Value))
+		}
+	}
+
+	// Hyperdrive doesn't serve yield updates yet, so the reporter needs an endpoint to get them from
+	if cfg.OracleReporterEnabled.Value && cfg.OracleReporterEndpoint.Value == "" {
+		errors = append(errors, "the xrETH oracle reporter is enabled but its endpoint isn't set")
+	}
+
+	// Snapshots deeper than this would act on state that's too stale to be useful
+	if cfg.SnapshotConfirmationDepth.Value > MaxSnapshotConfirmationDepth {
+		errors = append(errors, fmt.Sprintf("the snapshot confirmation depth (%d) can't be more than %d blocks", cfg.SnapshotConfirmationDepth.Value, MaxSnapshotConfirmationDepth))
+	}
+
+	// The rest only matters if the module's containers are going to run
+	if !cfg.Enabled.Value {
+		return errors
+	}
+
+	// Make sure the ports don't conflict
+	if cfg.ApiPort.Value == cfg.VcCommon.MetricsPort.Value {
+		errors = append(errors, fmt.Sprintf("the Constellation API port and the Validator Client metrics port are both set to %d", cfg.ApiPort.Value))
+	}
+
+	// Make sure the daemon matches this version
+	expectedTagSuffix := ":v" + shared.ConstellationVersion
+	if !strings.HasSuffix(cfg.DaemonContainerTag.Value, expectedTagSuffix) {
+		errors = append(errors, fmt.Sprintf("the daemon container tag [%s] isn't for this version of Constellation (v%s), it should be [%s]", cfg.DaemonContainerTag.Value, shared.ConstellationVersion, daemonTag))
+	}
+
+	// Make sure the selected VC has its settings and doesn't override the doppelganger setting
+	vc, err := cfg.getSelectedVcSettings()
+	if err != nil {
+		errors = append(errors, err.Error())
+	} else {
+		if vc.containerTag.Value == "" {
+			errors = append(errors, fmt.Sprintf("the %s Validator Client container tag is missing", vc.name))
+		}
+		for _, flag := range strings.Fields(vc.additionalFlags.Value) {
+			if flag == vc.doppelgangerFlag || strings.HasPrefix(flag, vc.doppelgangerFlag+"=") {
+				errors = append(errors, fmt.Sprintf("the %s Validator Client's additional flags include [%s], which conflicts with the Doppelgänger Detection setting; remove it and use the setting instead", vc.name, flag))
+			}
+		}
+	}
+
+	// Make sure there are resources for the selected network
+	network := cfg.hdCfg.Network.Value
+	hasSettings := false
+	for _, settings := range cfg.GetNetworkSettings() {
+		if settings.Key == network {
+			hasSettings = true
+			break
+		}
+	}
+	if !hasSettings {
+		errors = append(errors, fmt.Sprintf("there are no Constellation network settings for the selected network [%s]", network))
+	}
 	return errors
 }
 
@@ -170,29 +487,61 @@ func (cfg *ConstellationConfig) Serialize() map[string]any {
 
 // Deserialize the module config from a map
 func (cfg *ConstellationConfig) Deserialize(configMap map[string]any, network config.Network) error {
-	err := config.Deserialize(cfg, configMap, network)
+	// Upgrade configs saved by older versions first
+	version, changes, err := migrateConfig(configMap)
 	if err != nil {
-		return err
+		return fmt.Errorf("error upgrading Constellation config to v%s: %w", shared.ConstellationVersion, err)
 	}
-	version, exists := configMap[hdids.VersionID]
-	if !exists {
-		// Handle pre-version configs
-		version = "0.0.1"
+	err = config.Deserialize(cfg, configMap, network)
+	if err != nil {
+		return err
 	}
-	cfg.Version = version.(string)
+	cfg.Version = version
+	cfg.migrationChanges = changes
 	return nil
 }
 
+// Get a description of each setting that was changed when the config was upgraded from an older version, if it was
+func (cfg *ConstellationConfig) GetMigrationChanges() []string {
+	return cfg.migrationChanges
+}
+
 // Get the version of the module config
 func (cfg *ConstellationConfig) GetVersion() string {
 	return cfg.Version
 }
 
+// Get the additional sub-node addresses to monitor as part of the fleet
+func (cfg *ConstellationConfig) GetFleetNodeAddresses() ([]common.Address, error) {
+	addresses := []common.Address{}
+	for _, entry := range strings.Split(cfg.FleetNodeAddresses.Value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !common.IsHexAddress(entry) {
+			return nil, fmt.Errorf("invalid fleet node address [%s]", entry)
+		}
+		addresses = append(addresses, common.HexToAddress(entry))
+	}
+	return addresses, nil
+}
+
 // Get all loaded network settings
 func (cfg *ConstellationConfig) GetNetworkSettings() []*ConstellationSettings {
+	cfg.networkLock.RLock()
+	defer cfg.networkLock.RUnlock()
 	return cfg.networkSettings
 }
 
+// Replace the loaded network settings, such as after the settings files are reloaded.
+// The list is swapped as a whole, so callers holding the old one can keep using it.
+func (cfg *ConstellationConfig) SetNetworkSettings(networks []*ConstellationSettings) {
+	cfg.networkLock.Lock()
+	defer cfg.networkLock.Unlock()
+	cfg.networkSettings = networks
+}
+
 // ===================
 // === Module Info ===
 // ===================
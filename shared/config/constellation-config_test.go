@@ -0,0 +1,146 @@
+package csconfig
+
+import (
+	"testing"
+
+	"github.com/rocket-pool/node-manager-core/config"
+	snconfig "github.com/rocket-pool/smartnode/v2/shared/config"
+	"github.com/stretchr/testify/require"
+)
+
+// Make sure each validation rule catches the config it's for, and a valid config passes
+func TestConstellationConfigValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		bn     config.BeaconNode
+		modify func(*ConstellationConfig)
+		errors []string
+	}{
+		{
+			name:   "valid",
+			modify: func(*ConstellationConfig) {},
+		},
+		{
+			name: "port conflict",
+			modify: func(cfg *ConstellationConfig) {
+				cfg.VcCommon.MetricsPort.Value = cfg.ApiPort.Value
+			},
+			errors: []string{"the Constellation API port and the Validator Client metrics port are both set to"},
+		},
+		{
+			name: "port conflict while disabled",
+			modify: func(cfg *ConstellationConfig) {
+				cfg.Enabled.Value = false
+				cfg.VcCommon.MetricsPort.Value = cfg.ApiPort.Value
+			},
+		},
+		{
+			name: "missing vc section",
+			modify: func(cfg *ConstellationConfig) {
+				cfg.Lighthouse = nil
+			},
+			errors: []string{"the Constellation Validator Client settings for the selected Beacon Node [lighthouse] are missing"},
+		},
+		{
+			name: "missing vc tag",
+			modify: func(cfg *ConstellationConfig) {
+				cfg.Lighthouse.ContainerTag.Value = ""
+			},
+			errors: []string{"the Lighthouse Validator Client container tag is missing"},
+		},
+		{
+			name: "doppelganger flag",
+			modify: func(cfg *ConstellationConfig) {
+				cfg.Lighthouse.AdditionalFlags.Value = "--debug-level info --enable-doppelganger-protection"
+			},
+			errors: []string{"include [--enable-doppelganger-protection], which conflicts with the Doppelgänger Detection setting"},
+		},
+		{
+			name: "doppelganger flag with a value",
+			bn:   config.BeaconNode_Lodestar,
+			modify: func(cfg *ConstellationConfig) {
+				cfg.Lodestar.AdditionalFlags.Value = "--doppelgangerProtection=false"
+			},
+			errors: []string{"the Lodestar Validator Client's additional flags include [--doppelgangerProtection=false]"},
+		},
+		{
+			name: "doppelganger flag for another client",
+			modify: func(cfg *ConstellationConfig) {
+				cfg.Lodestar.AdditionalFlags.Value = "--doppelgangerProtection"
+			},
+		},
+		{
+			name: "wrong daemon tag",
+			modify: func(cfg *ConstellationConfig) {
+				cfg.DaemonContainerTag.Value = "nodeset/hyperdrive-constellation:v0.0.1"
+			},
+			errors: []string{"the daemon container tag [nodeset/hyperdrive-constellation:v0.0.1] isn't for this version of Constellation"},
+		},
+		{
+			name: "missing network",
+			modify: func(cfg *ConstellationConfig) {
+				cfg.SetNetworkSettings([]*ConstellationSettings{})
+			},
+			errors: []string{"there are no Constellation network settings for the selected network [devnet]"},
+		},
+		{
+			name: "oracle reporter without an endpoint",
+			modify: func(cfg *ConstellationConfig) {
+				cfg.OracleReporterEnabled.Value = true
+				cfg.OracleReporterEndpoint.Value = ""
+			},
+			errors: []string{"the xrETH oracle reporter is enabled but its endpoint isn't set"},
+		},
+		{
+			name: "snapshot confirmation depth",
+			modify: func(cfg *ConstellationConfig) {
+				cfg.SnapshotConfirmationDepth.Value = MaxSnapshotConfirmationDepth + 1
+			},
+			errors: []string{"the snapshot confirmation depth (65) can't be more than 64 blocks"},
+		},
+		{
+			name: "gas strategy",
+			modify: func(cfg *ConstellationConfig) {
+				cfg.GasStrategyEnabled.Value = true
+				cfg.GasSampleBlocks.Value = 0
+				cfg.GasCheapPercentile.Value = 101
+				cfg.GasTipPercentile.Value = -1
+			},
+			errors: []string{
+				"the number of blocks to sample for the gas strategy must be greater than 0",
+				"the gas strategy's cheap base fee percentile (101) must be between 0 and 100",
+				"the gas strategy's priority fee percentile (-1) must be between 0 and 100",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bn := test.bn
+			if bn == "" {
+				bn = config.BeaconNode_Lighthouse
+			}
+			cfg := newValidTestConfig(t, bn)
+			test.modify(cfg)
+
+			errors := cfg.Validate()
+			require.Len(t, errors, len(test.errors), "errors: %v", errors)
+			for i, expected := range test.errors {
+				require.Contains(t, errors[i], expected)
+			}
+		})
+	}
+}
+
+// Create an enabled config on the test network with settings for it, using a local Beacon Node of the given type
+func newValidTestConfig(t *testing.T, bn config.BeaconNode) *ConstellationConfig {
+	settings := newTestDiscoverySettings()
+	settings.ConstellationResources.RocketStorage = &testRocketStorage
+	settings.ConstellationResources.FeeRecipient = &testSmoothingPool
+	settings.SmartNodeResources = &snconfig.SmartNodeResources{}
+	cfg := newTestConfig(t, testNetwork, settings)
+	cfg.hdCfg.ClientMode.Value = config.ClientMode_Local
+	cfg.hdCfg.LocalBeaconClient.BeaconNode.Value = bn
+	cfg.Enabled.Value = true
+	cfg.Notifications.SmtpHost.Value = ""
+	return cfg
+}
@@ -0,0 +1,153 @@
+package csconfig
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	hdconfig "github.com/nodeset-org/hyperdrive-daemon/shared/config"
+	"github.com/rocket-pool/node-manager-core/config"
+	snconfig "github.com/rocket-pool/smartnode/v2/shared/config"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// The file in the module's data folder that the discovered network resources are saved to
+	DiscoveredResourcesFilename string = "discovered-resources.yaml"
+
+	// Permissions for the discovered resources file
+	discoveredResourcesFileMode fs.FileMode = 0644
+)
+
+// The network resources the daemon discovered on-chain because the network settings left them out.
+// The daemon saves these in the module's data folder so templating can use them and later startups don't have to wait
+// for the Execution Client before they can be used.
+type DiscoveredResources struct {
+	// The network the resources were discovered on
+	Network config.Network `yaml:"network"`
+
+	// The Constellation Directory they were discovered from
+	Directory common.Address `yaml:"directory"`
+
+	// The RocketStorage contract the Directory uses
+	RocketStorage common.Address `yaml:"rocketStorage"`
+
+	// The Rocket Pool Smoothing Pool, which is the fee recipient for Constellation validators
+	FeeRecipient common.Address `yaml:"feeRecipient"`
+
+	// The RPL token the Directory uses
+	RplToken common.Address `yaml:"rplToken"`
+
+	// The rETH token registered in RocketStorage
+	RethToken common.Address `yaml:"rethToken"`
+}
+
+// Load the discovered resources from the given module data folder; returns nil if they haven't been saved yet
+func LoadDiscoveredResources(moduleDir string) (*DiscoveredResources, error) {
+	path := filepath.Join(moduleDir, DiscoveredResourcesFilename)
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading discovered network resources [%s]: %w", path, err)
+	}
+	resources := new(DiscoveredResources)
+	err = yaml.Unmarshal(bytes, resources)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling discovered network resources [%s]: %w", path, err)
+	}
+	return resources, nil
+}
+
+// Save the discovered resources to the given module data folder
+func (r *DiscoveredResources) Save(moduleDir string) error {
+	bytes, err := yaml.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("error serializing discovered network resources: %w", err)
+	}
+	path := filepath.Join(moduleDir, DiscoveredResourcesFilename)
+	err = os.WriteFile(path, bytes, discoveredResourcesFileMode)
+	if err != nil {
+		return fmt.Errorf("error saving discovered network resources [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Check if the resources were discovered for the given settings' network and Directory
+func (r *DiscoveredResources) Matches(settings *ConstellationSettings) bool {
+	directory := settings.ConstellationResources.Directory
+	return r.Network == settings.Key && directory != nil && r.Directory == *directory
+}
+
+// Fill in the resources left out of the given settings; the ones that were set are left alone
+func (r *DiscoveredResources) Fill(settings *ConstellationSettings) {
+	res := settings.ConstellationResources
+	if isAddressMissing(res.RocketStorage) {
+		address := r.RocketStorage
+		res.RocketStorage = &address
+	}
+	if isAddressMissing(res.FeeRecipient) {
+		address := r.FeeRecipient
+		res.FeeRecipient = &address
+	}
+	if settings.SmartNodeResources == nil {
+		settings.SmartNodeResources = &snconfig.SmartNodeResources{}
+	}
+	snRes := settings.SmartNodeResources
+	if snRes.StorageAddress == (common.Address{}) {
+		snRes.StorageAddress = r.RocketStorage
+	}
+	if snRes.RplTokenAddress == (common.Address{}) {
+		snRes.RplTokenAddress = r.RplToken
+	}
+	if snRes.RethAddress == (common.Address{}) {
+		snRes.RethAddress = r.RethToken
+	}
+}
+
+// Make a copy of the settings that can be filled in without changing the original
+func (s *ConstellationSettings) copyForDiscovery() *ConstellationSettings {
+	settings := *s
+	res := *s.ConstellationResources
+	settings.ConstellationResources = &res
+	if s.SmartNodeResources != nil {
+		snRes := *s.SmartNodeResources
+		settings.SmartNodeResources = &snRes
+	}
+	return &settings
+}
+
+// Get a copy of the settings with the resources left out of them filled in from the discovered resources file in the
+// given module data folder. The bool is false if they haven't been discovered for this network and Directory yet.
+func (s *ConstellationSettings) WithDiscoveredResources(moduleDir string) (*ConstellationSettings, bool, error) {
+	if !s.NeedsDiscovery() {
+		return s, true, nil
+	}
+	discovered, err := LoadDiscoveredResources(moduleDir)
+	if err != nil {
+		return nil, false, err
+	}
+	if discovered == nil || !discovered.Matches(s) {
+		return nil, false, nil
+	}
+	settings := s.copyForDiscovery()
+	discovered.Fill(settings)
+	return settings, true, nil
+}
+
+// Get a copy of the settings with the resources left out of them set to the zero address, for use until they can be
+// discovered
+func (s *ConstellationSettings) WithPlaceholderResources() *ConstellationSettings {
+	settings := s.copyForDiscovery()
+	(&DiscoveredResources{}).Fill(settings)
+	return settings
+}
+
+// Get the data folder the daemon uses for this module on the host
+func (cfg *ConstellationConfig) getModuleDir() string {
+	return filepath.Join(cfg.hdCfg.UserDataPath.Value, hdconfig.ModulesName, ModuleName)
+}
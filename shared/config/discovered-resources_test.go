@@ -0,0 +1,172 @@
+package csconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	hdconfig "github.com/nodeset-org/hyperdrive-daemon/shared/config"
+	"github.com/rocket-pool/node-manager-core/config"
+	snconfig "github.com/rocket-pool/smartnode/v2/shared/config"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testNetwork config.Network = "devnet"
+)
+
+var (
+	testDirectory     = common.HexToAddress("0x71C95911E9a5D330f4D621842EC243EE1343292e")
+	testRocketStorage = common.HexToAddress("0xe7f1725E7734CE288F8367e1Bb143E90bb3F0512")
+	testSmoothingPool = common.HexToAddress("0x0E801D84Fa97b50751Dbf25036d067dCf18858bF")
+	testRplToken      = common.HexToAddress("0xa513E6E4b8f2a923D98304ec87F64353C4D5C853")
+	testRethToken     = common.HexToAddress("0x5FC8d32690cc91D4c39d9d3abcBD16989F875707")
+)
+
+// Make sure discovered resources survive a round trip to disk, and that loading them before they're saved isn't an error
+func TestDiscoveredResourcesSaveLoad(t *testing.T) {
+	folder := t.TempDir()
+	loaded, err := LoadDiscoveredResources(folder)
+	require.NoError(t, err)
+	require.Nil(t, loaded)
+
+	discovered := newTestDiscoveredResources()
+	require.NoError(t, discovered.Save(folder))
+	loaded, err = LoadDiscoveredResources(folder)
+	require.NoError(t, err)
+	require.Equal(t, discovered, loaded)
+}
+
+// Make sure only the resources left out of the settings are filled in, without changing the original settings
+func TestWithDiscoveredResources(t *testing.T) {
+	folder := t.TempDir()
+	require.NoError(t, newTestDiscoveredResources().Save(folder))
+
+	configuredStorage := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	original := newTestDiscoverySettings()
+	original.ConstellationResources.RocketStorage = &configuredStorage
+	settings, discovered, err := original.WithDiscoveredResources(folder)
+	require.NoError(t, err)
+	require.True(t, discovered)
+	require.False(t, settings.NeedsDiscovery())
+	require.Equal(t, configuredStorage, *settings.ConstellationResources.RocketStorage)
+	require.Equal(t, testSmoothingPool, *settings.ConstellationResources.FeeRecipient)
+	require.Equal(t, testRocketStorage, settings.SmartNodeResources.StorageAddress)
+	require.Equal(t, testRplToken, settings.SmartNodeResources.RplTokenAddress)
+	require.Equal(t, testRethToken, settings.SmartNodeResources.RethAddress)
+
+	// The original still needs discovery
+	require.True(t, original.NeedsDiscovery())
+	require.Nil(t, original.ConstellationResources.FeeRecipient)
+	require.Nil(t, original.SmartNodeResources)
+}
+
+// Make sure resources discovered for a different Directory or network aren't used
+func TestWithDiscoveredResourcesMismatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*DiscoveredResources)
+	}{
+		{
+			name: "other directory",
+			modify: func(r *DiscoveredResources) {
+				r.Directory = common.HexToAddress("0x2222222222222222222222222222222222222222")
+			},
+		},
+		{
+			name: "other network",
+			modify: func(r *DiscoveredResources) {
+				r.Network = "othernet"
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			folder := t.TempDir()
+			discovered := newTestDiscoveredResources()
+			test.modify(discovered)
+			require.NoError(t, discovered.Save(folder))
+
+			settings, found, err := newTestDiscoverySettings().WithDiscoveredResources(folder)
+			require.NoError(t, err)
+			require.False(t, found)
+			require.Nil(t, settings)
+		})
+	}
+}
+
+// Make sure the placeholders fill everything in with the zero address without changing the original settings
+func TestWithPlaceholderResources(t *testing.T) {
+	original := newTestDiscoverySettings()
+	settings := original.WithPlaceholderResources()
+	require.Equal(t, common.Address{}, *settings.ConstellationResources.RocketStorage)
+	require.Equal(t, common.Address{}, *settings.ConstellationResources.FeeRecipient)
+	require.NotNil(t, settings.SmartNodeResources)
+	require.Nil(t, original.ConstellationResources.RocketStorage)
+	require.Nil(t, original.SmartNodeResources)
+}
+
+// Make sure templating gets the fee recipient from the settings, falls back to the discovered one, and errors without either
+func TestTemplatingFeeRecipient(t *testing.T) {
+	// Set in the settings
+	configured := newTestDiscoverySettings()
+	configured.ConstellationResources.RocketStorage = &testRocketStorage
+	configured.ConstellationResources.FeeRecipient = &testSmoothingPool
+	configured.SmartNodeResources = &snconfig.SmartNodeResources{}
+	cfg := newTestConfig(t, testNetwork, configured)
+	feeRecipient, err := cfg.FeeRecipient()
+	require.NoError(t, err)
+	require.Equal(t, testSmoothingPool.Hex(), feeRecipient)
+
+	// Not discovered yet
+	cfg = newTestConfig(t, testNetwork, newTestDiscoverySettings())
+	_, err = cfg.FeeRecipient()
+	require.ErrorContains(t, err, "hasn't discovered yet")
+
+	// Discovered
+	moduleDir := cfg.getModuleDir()
+	require.NoError(t, os.MkdirAll(moduleDir, 0755))
+	require.NoError(t, newTestDiscoveredResources().Save(moduleDir))
+	feeRecipient, err = cfg.FeeRecipient()
+	require.NoError(t, err)
+	require.Equal(t, testSmoothingPool.Hex(), feeRecipient)
+	rocketStorage, err := cfg.RocketStorage()
+	require.NoError(t, err)
+	require.Equal(t, testRocketStorage.Hex(), rocketStorage)
+}
+
+// Create a Constellation config on the given network with the given network settings, using a temporary user data folder
+func newTestConfig(t *testing.T, network config.Network, settings ...*ConstellationSettings) *ConstellationConfig {
+	hdCfg, err := hdconfig.NewHyperdriveConfigForNetwork(t.TempDir(), []*hdconfig.HyperdriveSettings{}, network)
+	require.NoError(t, err)
+	hdCfg.Network.Value = network
+	hdCfg.UserDataPath.Value = filepath.Join(t.TempDir(), "data")
+	cfg, err := NewConstellationConfig(hdCfg, settings)
+	require.NoError(t, err)
+	return cfg
+}
+
+// Create custom network settings that only have the Directory
+func newTestDiscoverySettings() *ConstellationSettings {
+	directory := testDirectory
+	return &ConstellationSettings{
+		Key: testNetwork,
+		ConstellationResources: &ConstellationResources{
+			DeploymentName: "devnet",
+			Directory:      &directory,
+		},
+	}
+}
+
+// Create discovered resources for the test Directory
+func newTestDiscoveredResources() *DiscoveredResources {
+	return &DiscoveredResources{
+		Network:       testNetwork,
+		Directory:     testDirectory,
+		RocketStorage: testRocketStorage,
+		FeeRecipient:  testSmoothingPool,
+		RplToken:      testRplToken,
+		RethToken:     testRethToken,
+	}
+}
@@ -2,9 +2,33 @@ package ids
 
 const (
 	// Param IDs
-	ConstellationEnableID string = "enable"
-	ApiPortID             string = "apiPort"
-	DaemonContainerTagID  string = "daemonContainerTag"
+	ConstellationEnableID       string = "enable"
+	ApiPortID                   string = "apiPort"
+	DaemonContainerTagID        string = "daemonContainerTag"
+	GasStrategyEnableID         string = "gasStrategyEnable"
+	GasSampleBlocksID           string = "gasSampleBlocks"
+	GasCheapPercentileID        string = "gasCheapPercentile"
+	GasTipPercentileID          string = "gasTipPercentile"
+	FleetNodeAddressesID        string = "fleetNodeAddresses"
+	SnapshotConfirmationDepthID string = "snapshotConfirmationDepth"
+	AdminRoutesEnableID         string = "adminRoutesEnable"
+	KeeperEnableID              string = "keeperEnable"
+	KeeperDailyBudgetID         string = "keeperDailyBudget"
+	KeeperMinRewardMultipleID   string = "keeperMinRewardMultiple"
+	OracleReporterEnableID      string = "oracleReporterEnable"
+	OracleReporterEndpointID    string = "oracleReporterEndpoint"
+	OracleReporterMaxAgeID      string = "oracleReporterMaxAge"
+
+	// Notification param IDs
+	NotificationWebhookUrlID        string = "webhookUrl"
+	NotificationSmtpHostID          string = "smtpHost"
+	NotificationSmtpPortID          string = "smtpPort"
+	NotificationSmtpUsernameID      string = "smtpUsername"
+	NotificationSmtpPasswordID      string = "smtpPassword"
+	NotificationSmtpFromID          string = "smtpFrom"
+	NotificationSmtpToID            string = "smtpTo"
+	NotificationDesyncThresholdID   string = "desyncThreshold"
+	NotificationLaunchTimeoutWarnID string = "launchTimeoutWarning"
 
 	// Subconfig IDs
 	VcCommonID   string = "common"
@@ -13,4 +37,6 @@ const (
 	NimbusID     string = "nimbus"
 	PrysmID      string = "prysm"
 	TekuID       string = "teku"
+
+	NotificationsID string = "notifications"
 )
@@ -0,0 +1,73 @@
+package csconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/nodeset-org/hyperdrive-constellation/shared/config/ids"
+	hdids "github.com/nodeset-org/hyperdrive-daemon/shared/config/ids"
+	nmcids "github.com/rocket-pool/node-manager-core/config/ids"
+)
+
+const (
+	// The version given to configs that were saved before the module config had a version
+	preVersionConfigVersion string = "0.0.1"
+
+	// The generic VC metrics port that configs saved before versioning used, which the other modules' VCs also default to
+	genericVcMetricsPort uint16 = 9101
+)
+
+// Upgrade a serialized config to the current layout if it needs it, returning the version it was saved with and a
+// description of each setting that was changed so it can be shown to the user
+func migrateConfig(configMap map[string]any) (string, []string, error) {
+	versionEntry, exists := configMap[hdids.VersionID]
+	if !exists {
+		changes, err := upgradePreVersionConfig(configMap)
+		if err != nil {
+			return "", nil, fmt.Errorf("error upgrading config from before versioning: %w", err)
+		}
+		return preVersionConfigVersion, changes, nil
+	}
+	configVersion, isString := versionEntry.(string)
+	if !isString {
+		return "", nil, fmt.Errorf("config has an entry named [%s] but it is not a string, it's a %s", hdids.VersionID, reflect.TypeOf(versionEntry))
+	}
+	return configVersion, nil, nil
+}
+
+// Upgrade a config that was saved before the module config had a version.
+// The daemon tag is dropped so the current version's default is used, and the VC metrics port is moved off the
+// generic default it shared with the other modules' VCs. A saved config can't tell that default apart from a port the
+// user picked, so the move is reported in the returned changes in case it was set on purpose.
+func upgradePreVersionConfig(configMap map[string]any) ([]string, error) {
+	delete(configMap, ids.DaemonContainerTagID)
+
+	vcCommonEntry, exists := configMap[ids.VcCommonID]
+	if !exists {
+		return nil, nil
+	}
+	genericPort := strconv.FormatUint(uint64(genericVcMetricsPort), 10)
+	defaultPort := strconv.FormatUint(uint64(DefaultVcMetricsPort), 10)
+	var metricsPort any
+	switch vcCommon := vcCommonEntry.(type) {
+	case map[string]any:
+		metricsPort = vcCommon[nmcids.MetricsPortID]
+		if metricsPort == genericPort {
+			vcCommon[nmcids.MetricsPortID] = defaultPort
+		}
+	case map[any]any:
+		metricsPort = vcCommon[nmcids.MetricsPortID]
+		if metricsPort == genericPort {
+			vcCommon[nmcids.MetricsPortID] = defaultPort
+		}
+	default:
+		return nil, fmt.Errorf("subsection [%s] is not a map, it is %s", ids.VcCommonID, reflect.TypeOf(vcCommonEntry))
+	}
+	if metricsPort != genericPort {
+		return nil, nil
+	}
+	return []string{
+		fmt.Sprintf("The Constellation Validator Client metrics port was moved from %s, which the other modules' Validator Clients use by default, to %s. If you set it to %s on purpose, change it back in the Constellation settings.", genericPort, defaultPort, genericPort),
+	}, nil
+}
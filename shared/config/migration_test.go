@@ -0,0 +1,132 @@
+package csconfig
+
+import (
+	"testing"
+
+	"github.com/nodeset-org/hyperdrive-constellation/shared/config/ids"
+	hdids "github.com/nodeset-org/hyperdrive-daemon/shared/config/ids"
+	nmcids "github.com/rocket-pool/node-manager-core/config/ids"
+	"github.com/stretchr/testify/require"
+)
+
+// Make sure configs from before versioning are upgraded, and versioned ones are left alone
+func TestMigrateConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		configMap   map[string]any
+		version     string
+		metricsPort any
+		changed     bool
+		err         string
+	}{
+		{
+			name: "pre-version with the generic metrics port",
+			configMap: map[string]any{
+				ids.DaemonContainerTagID: "nodeset/hyperdrive-constellation:v0.1.0",
+				ids.VcCommonID:           map[string]any{nmcids.MetricsPortID: "9101"},
+			},
+			version:     preVersionConfigVersion,
+			metricsPort: "9111",
+			changed:     true,
+		},
+		{
+			name: "pre-version loaded as an any-keyed map",
+			configMap: map[string]any{
+				ids.VcCommonID: map[any]any{nmcids.MetricsPortID: "9101"},
+			},
+			version:     preVersionConfigVersion,
+			metricsPort: "9111",
+			changed:     true,
+		},
+		{
+			name: "pre-version with a custom metrics port",
+			configMap: map[string]any{
+				ids.VcCommonID: map[string]any{nmcids.MetricsPortID: "9200"},
+			},
+			version:     preVersionConfigVersion,
+			metricsPort: "9200",
+		},
+		{
+			name:      "pre-version without a vc section",
+			configMap: map[string]any{},
+			version:   preVersionConfigVersion,
+		},
+		{
+			name: "pre-version with a broken vc section",
+			configMap: map[string]any{
+				ids.VcCommonID: "9101",
+			},
+			err: "subsection [common] is not a map",
+		},
+		{
+			name: "versioned with the generic metrics port",
+			configMap: map[string]any{
+				hdids.VersionID: "1.0.0",
+				ids.VcCommonID:  map[string]any{nmcids.MetricsPortID: "9101"},
+			},
+			version:     "1.0.0",
+			metricsPort: "9101",
+		},
+		{
+			name: "version isn't a string",
+			configMap: map[string]any{
+				hdids.VersionID: 1,
+			},
+			err: "config has an entry named [version] but it is not a string",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			version, changes, err := migrateConfig(test.configMap)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.version, version)
+			if test.changed {
+				require.Len(t, changes, 1)
+				require.Contains(t, changes[0], "moved from 9101")
+			} else {
+				require.Empty(t, changes)
+			}
+			if version == preVersionConfigVersion {
+				require.NotContains(t, test.configMap, ids.DaemonContainerTagID)
+			}
+			if test.metricsPort != nil {
+				switch vcCommon := test.configMap[ids.VcCommonID].(type) {
+				case map[string]any:
+					require.Equal(t, test.metricsPort, vcCommon[nmcids.MetricsPortID])
+				case map[any]any:
+					require.Equal(t, test.metricsPort, vcCommon[nmcids.MetricsPortID])
+				}
+			}
+		})
+	}
+}
+
+// Make sure a pre-version config deserializes with the upgrade applied and the change reported
+func TestDeserializePreVersionConfig(t *testing.T) {
+	cfg := newTestConfig(t, testNetwork)
+	configMap := cfg.Serialize()
+	delete(configMap, hdids.VersionID)
+	configMap[ids.DaemonContainerTagID] = "nodeset/hyperdrive-constellation:v0.1.0"
+	configMap[ids.VcCommonID].(map[string]any)[nmcids.MetricsPortID] = "9101"
+
+	upgraded := newTestConfig(t, testNetwork)
+	err := upgraded.Deserialize(configMap, testNetwork)
+	require.NoError(t, err)
+	require.Equal(t, preVersionConfigVersion, upgraded.GetVersion())
+	require.Equal(t, daemonTag, upgraded.DaemonContainerTag.Value)
+	require.Equal(t, DefaultVcMetricsPort, upgraded.VcCommon.MetricsPort.Value)
+	require.Len(t, upgraded.GetMigrationChanges(), 1)
+
+	// Saving it again marks it with a version, so it isn't upgraded twice
+	configMap = upgraded.Serialize()
+	configMap[ids.VcCommonID].(map[string]any)[nmcids.MetricsPortID] = "9101"
+	reloaded := newTestConfig(t, testNetwork)
+	err = reloaded.Deserialize(configMap, testNetwork)
+	require.NoError(t, err)
+	require.Equal(t, uint16(9101), reloaded.VcCommon.MetricsPort.Value)
+	require.Empty(t, reloaded.GetMigrationChanges())
+}
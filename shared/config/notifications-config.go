@@ -0,0 +1,237 @@
+package csconfig
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+
+	"github.com/nodeset-org/hyperdrive-constellation/shared/config/ids"
+	"github.com/rocket-pool/node-manager-core/config"
+)
+
+// Configuration for the alerts the daemon sends when something needs the node operator's attention
+type NotificationsConfig struct {
+	// URL to POST notifications to as JSON
+	WebhookUrl config.Parameter[string]
+
+	// Hostname of the SMTP server to send notification emails through
+	SmtpHost config.Parameter[string]
+
+	// Port of the SMTP server
+	SmtpPort config.Parameter[uint16]
+
+	// Username for the SMTP server, if it requires authentication
+	SmtpUsername config.Parameter[string]
+
+	// Password for the SMTP server, if it requires authentication
+	SmtpPassword config.Parameter[string]
+
+	// Address to send notification emails from
+	SmtpFrom config.Parameter[string]
+
+	// Comma-separated list of addresses to send notification emails to
+	SmtpTo config.Parameter[string]
+
+	// Number of minutes the Execution Client or Beacon Node can be out of sync before a notification is sent
+	DesyncThreshold config.Parameter[uint64]
+
+	// Number of hours before a prelaunch minipool's launch timeout that a notification is sent if it hasn't been staked
+	LaunchTimeoutWarning config.Parameter[uint64]
+}
+
+// Generates a new notifications config
+func NewNotificationsConfig() *NotificationsConfig {
+	return &NotificationsConfig{
+		WebhookUrl: config.Parameter[string]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 ids.NotificationWebhookUrlID,
+				Name:               "Webhook URL",
+				Description:        "The URL to send notifications to. Each notification is sent as a JSON object in the body of a POST request. Leave this blank to disable webhook notifications.",
+				AffectsContainers:  []config.ContainerID{ContainerID_ConstellationDaemon},
+				CanBeBlank:         true,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[config.Network]string{
+				config.Network_All: "",
+			},
+		},
+
+		SmtpHost: config.Parameter[string]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 ids.NotificationSmtpHostID,
+				Name:               "SMTP Host",
+				Description:        "The hostname of the SMTP server to send notification emails through. Leave this blank to disable email notifications.",
+				AffectsContainers:  []config.ContainerID{ContainerID_ConstellationDaemon},
+				CanBeBlank:         true,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[config.Network]string{
+				config.Network_All: "",
+			},
+		},
+
+		SmtpPort: config.Parameter[uint16]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 ids.NotificationSmtpPortID,
+				Name:               "SMTP Port",
+				Description:        "The port of the SMTP server to send notification emails through.",
+				AffectsContainers:  []config.ContainerID{ContainerID_ConstellationDaemon},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[config.Network]uint16{
+				config.Network_All: DefaultNotificationSmtpPort,
+			},
+		},
+
+		SmtpUsername: config.Parameter[string]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 ids.NotificationSmtpUsernameID,
+				Name:               "SMTP Username",
+				Description:        "The username to log into the SMTP server with. Leave this blank if the server doesn't require authentication.",
+				AffectsContainers:  []config.ContainerID{ContainerID_ConstellationDaemon},
+				CanBeBlank:         true,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[config.Network]string{
+				config.Network_All: "",
+			},
+		},
+
+		SmtpPassword: config.Parameter[string]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 ids.NotificationSmtpPasswordID,
+				Name:               "SMTP Password",
+				Description:        "The password to log into the SMTP server with.",
+				AffectsContainers:  []config.ContainerID{ContainerID_ConstellationDaemon},
+				CanBeBlank:         true,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[config.Network]string{
+				config.Network_All: "",
+			},
+		},
+
+		SmtpFrom: config.Parameter[string]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 ids.NotificationSmtpFromID,
+				Name:               "Email Sender",
+				Description:        "The address notification emails should be sent from.",
+				AffectsContainers:  []config.ContainerID{ContainerID_ConstellationDaemon},
+				CanBeBlank:         true,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[config.Network]string{
+				config.Network_All: "",
+			},
+		},
+
+		SmtpTo: config.Parameter[string]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 ids.NotificationSmtpToID,
+				Name:               "Email Recipients",
+				Description:        "A comma-separated list of addresses to send notification emails to.",
+				AffectsContainers:  []config.ContainerID{ContainerID_ConstellationDaemon},
+				CanBeBlank:         true,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[config.Network]string{
+				config.Network_All: "",
+			},
+		},
+
+		DesyncThreshold: config.Parameter[uint64]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 ids.NotificationDesyncThresholdID,
+				Name:               "Client Desync Threshold",
+				Description:        "The number of minutes your Execution Client or Beacon Node can be out of sync before a notification is sent.",
+				AffectsContainers:  []config.ContainerID{ContainerID_ConstellationDaemon},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[config.Network]uint64{
+				config.Network_All: DefaultNotificationDesyncThreshold,
+			},
+		},
+
+		LaunchTimeoutWarning: config.Parameter[uint64]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 ids.NotificationLaunchTimeoutWarnID,
+				Name:               "Launch Timeout Warning",
+				Description:        "The number of hours before a prelaunch minipool's launch timeout that a notification is sent if it still hasn't been staked. Minipools that aren't staked before the launch timeout can be dissolved.",
+				AffectsContainers:  []config.ContainerID{ContainerID_ConstellationDaemon},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[config.Network]uint64{
+				config.Network_All: DefaultNotificationLaunchTimeoutWarning,
+			},
+		},
+	}
+}
+
+// The title for the config
+func (cfg *NotificationsConfig) GetTitle() string {
+	return "Notifications"
+}
+
+// Get the parameters for this config
+func (cfg *NotificationsConfig) GetParameters() []config.IParameter {
+	return []config.IParameter{
+		&cfg.WebhookUrl,
+		&cfg.SmtpHost,
+		&cfg.SmtpPort,
+		&cfg.SmtpUsername,
+		&cfg.SmtpPassword,
+		&cfg.SmtpFrom,
+		&cfg.SmtpTo,
+		&cfg.DesyncThreshold,
+		&cfg.LaunchTimeoutWarning,
+	}
+}
+
+// Get the sections underneath this one
+func (cfg *NotificationsConfig) GetSubconfigs() map[string]config.IConfigSection {
+	return map[string]config.IConfigSection{}
+}
+
+// Get the addresses to send notification emails to
+func (cfg *NotificationsConfig) GetSmtpRecipients() ([]string, error) {
+	recipients := []string{}
+	for _, entry := range strings.Split(cfg.SmtpTo.Value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		_, err := mail.ParseAddress(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid email recipient [%s]: %w", entry, err)
+		}
+		recipients = append(recipients, entry)
+	}
+	return recipients, nil
+}
+
+// Checks the notification settings for errors
+func (cfg *NotificationsConfig) Validate() []string {
+	errors := []string{}
+	if cfg.WebhookUrl.Value != "" {
+		webhookUrl, err := url.Parse(cfg.WebhookUrl.Value)
+		if err != nil || (webhookUrl.Scheme != "http" && webhookUrl.Scheme != "https") || webhookUrl.Host == "" {
+			errors = append(errors, fmt.Sprintf("invalid notification webhook URL [%s]", cfg.WebhookUrl.Value))
+		}
+	}
+	if cfg.SmtpHost.Value != "" {
+		recipients, err := cfg.GetSmtpRecipients()
+		if err != nil {
+			errors = append(errors, err.Error())
+		} else if len(recipients) == 0 {
+			errors = append(errors, "notification emails are enabled but no recipients have been provided")
+		}
+		if _, err := mail.ParseAddress(cfg.SmtpFrom.Value); err != nil {
+			errors = append(errors, fmt.Sprintf("invalid notification email sender [%s]", cfg.SmtpFrom.Value))
+		}
+	}
+	return errors
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	hdconfig "github.com/nodeset-org/hyperdrive-daemon/shared/config"
 	"github.com/rocket-pool/node-manager-core/config"
+	"github.com/rocket-pool/node-manager-core/utils"
 	snconfig "github.com/rocket-pool/smartnode/v2/shared/config"
 	"gopkg.in/yaml.v3"
 )
@@ -23,6 +24,12 @@ const (
 )
 
 var (
+	// The NodeSet deployment each well-known network has to use
+	knownDeploymentNames map[config.Network]string = map[config.Network]string{
+		config.Network_Mainnet: NodesetDeploymentMainnet,
+		config.Network_Holesky: NodesetDeploymentHolesky,
+	}
+
 	// Mainnet resources for reference in testing
 	MainnetResourcesReference *ConstellationResources = &ConstellationResources{
 		Directory:     nil,
@@ -46,7 +53,7 @@ type ConstellationSettings struct {
 	// Hyperdrive resources for the network
 	ConstellationResources *ConstellationResources `yaml:"constellationResources" json:"constellationResources"`
 
-	// Smart Node resources for the network
+	// Smart Node resources for the network; these are optional on custom networks, where the addresses Constellation needs are discovered on-chain
 	SmartNodeResources *snconfig.SmartNodeResources `yaml:"smartNodeResources" json:"smartNodeResources"`
 
 	// A collection of default configuration settings to use for the network, which will override
@@ -62,12 +69,22 @@ type ConstellationResources struct {
 	// The Constellation directory contract address, which houses all of the other contract addresses
 	Directory *common.Address `yaml:"directory" json:"directory"`
 
-	// Address of the RocketStorage contract, the master contract for all of Rocket Pool
+	// Address of the RocketStorage contract, the master contract for all of Rocket Pool.
+	// This is optional on custom networks, where it's discovered from the Directory.
 	RocketStorage *common.Address `yaml:"rocketStorage" json:"rocketStorage"`
 
 	// The fee recipient to use for the Constellation VC. This must ALWAYS be set to the Rocket Pool Smoothing Pool contract address.
 	// Technically this should come from Directory (or RocketStorage within Directory) but it needs to be set here for templating to use it.
+	// This is optional on custom networks, where it's discovered from RocketStorage; the daemon saves what it discovers so templating can
+	// use that instead.
 	FeeRecipient *common.Address `yaml:"feeRecipient" json:"feeRecipient"`
+
+	// The genesis fork version of the Beacon Chain, for custom networks that don't use the one Hyperdrive has for them
+	GenesisForkVersion utils.ByteArray `yaml:"genesisForkVersion,omitempty" json:"genesisForkVersion,omitempty"`
+
+	// The block the Constellation contracts were deployed in, which contract event indexing starts from.
+	// This is required on the well-known networks; if it's unset on a custom network, indexing starts from genesis.
+	DeploymentBlock uint64 `yaml:"deploymentBlock,omitempty" json:"deploymentBlock,omitempty"`
 }
 
 // A merged set of general resources and Constellation-specific resources for the selected network
@@ -82,6 +99,37 @@ type MergedResources struct {
 	*snconfig.SmartNodeResources
 }
 
+// Merge the general resources with the settings for the selected network, applying the network's genesis fork version if it has one
+func NewMergedResources(hdResources *hdconfig.MergedResources, settings *ConstellationSettings) *MergedResources {
+	merged := &MergedResources{
+		MergedResources:        hdResources,
+		ConstellationResources: settings.ConstellationResources,
+		SmartNodeResources:     settings.SmartNodeResources,
+	}
+	if len(settings.ConstellationResources.GenesisForkVersion) > 0 {
+		// Copy the general resources so Hyperdrive's own aren't changed
+		networkResources := *hdResources.NetworkResources
+		networkResources.GenesisForkVersion = settings.ConstellationResources.GenesisForkVersion
+		merged.MergedResources = &hdconfig.MergedResources{
+			NetworkResources:    &networkResources,
+			HyperdriveResources: hdResources.HyperdriveResources,
+		}
+	}
+	return merged
+}
+
+// Check if the network is a custom one, which can leave out the resources that can be discovered on-chain
+func (s *ConstellationSettings) IsCustomNetwork() bool {
+	_, isKnown := knownDeploymentNames[s.Key]
+	return !isKnown
+}
+
+// Check if any of the resources that can be discovered on-chain were left out of the settings
+func (s *ConstellationSettings) NeedsDiscovery() bool {
+	res := s.ConstellationResources
+	return isAddressMissing(res.RocketStorage) || isAddressMissing(res.FeeRecipient) || s.SmartNodeResources == nil
+}
+
 // Load network settings from a folder
 func LoadSettingsFiles(sourceDir string) ([]*ConstellationSettings, error) {
 	// Make sure the folder exists
@@ -97,6 +145,7 @@ func LoadSettingsFiles(sourceDir string) ([]*ConstellationSettings, error) {
 	}
 
 	settingsList := []*ConstellationSettings{}
+	settingsFiles := map[config.Network]string{}
 	for _, file := range files {
 		// Ignore dirs and nonstandard files
 		if file.IsDir() || !file.Type().IsRegular() {
@@ -122,7 +171,73 @@ func LoadSettingsFiles(sourceDir string) ([]*ConstellationSettings, error) {
 		if err != nil {
 			return nil, fmt.Errorf("error unmarshalling network settings file [%s]: %w", settingsFilePath, err)
 		}
+
+		// Make sure it's usable
+		err = settings.Validate()
+		if err != nil {
+			return nil, fmt.Errorf("invalid network settings file [%s]: %w", settingsFilePath, err)
+		}
+		if existingFile, exists := settingsFiles[settings.Key]; exists {
+			return nil, fmt.Errorf("network settings files [%s] and [%s] both have the key [%s]", existingFile, settingsFilePath, settings.Key)
+		}
+		settingsFiles[settings.Key] = settingsFilePath
 		settingsList = append(settingsList, settings)
 	}
 	return settingsList, nil
 }
+
+// Check that the settings have everything the daemon needs to run on their network.
+// Custom networks only need the Directory, since everything else can be discovered on-chain.
+func (s *ConstellationSettings) Validate() error {
+	if s.Key == "" {
+		return errors.New("key is missing")
+	}
+	isCustom := s.IsCustomNetwork()
+	if s.SmartNodeResources == nil && !isCustom {
+		return errors.New("smartNodeResources is missing")
+	}
+	res := s.ConstellationResources
+	if res == nil {
+		return errors.New("constellationResources is missing")
+	}
+
+	// Check the required addresses
+	requiredAddresses := []struct {
+		name         string
+		address      *common.Address
+		discoverable bool
+	}{
+		{name: "directory", address: res.Directory},
+		{name: "rocketStorage", address: res.RocketStorage, discoverable: true},
+		{name: "feeRecipient", address: res.FeeRecipient, discoverable: true},
+	}
+	for _, required := range requiredAddresses {
+		if required.discoverable && isCustom {
+			continue
+		}
+		if isAddressMissing(required.address) {
+			return fmt.Errorf("constellationResources.%s is missing", required.name)
+		}
+	}
+	if res.DeploymentBlock == 0 && !isCustom {
+		return errors.New("constellationResources.deploymentBlock is missing")
+	}
+	if len(res.GenesisForkVersion) > 0 && len(res.GenesisForkVersion) != 4 {
+		return fmt.Errorf("constellationResources.genesisForkVersion is %d bytes but should be 4", len(res.GenesisForkVersion))
+	}
+
+	// Check the deployment; the NodeSet server's deployment list isn't available offline, so only the
+	// well-known networks are pinned to their deployments and custom networks can use any name
+	if res.DeploymentName == "" {
+		return errors.New("constellationResources.deploymentName is missing")
+	}
+	if expected, exists := knownDeploymentNames[s.Key]; exists && res.DeploymentName != expected {
+		return fmt.Errorf("constellationResources.deploymentName [%s] is unknown for network [%s], it should be [%s]", res.DeploymentName, s.Key, expected)
+	}
+	return nil
+}
+
+// Check if an address is unset
+func isAddressMissing(address *common.Address) bool {
+	return address == nil || *address == (common.Address{})
+}
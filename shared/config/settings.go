@@ -10,6 +10,27 @@ const (
 	DefaultVcMetricsPort uint16 = 9111
 	KeystorePasswordFile string = "secret.txt"
 
+	// Gas forecasting
+	DefaultGasSampleBlocks    uint64  = 1024
+	DefaultGasCheapPercentile float64 = 25
+	DefaultGasTipPercentile   float64 = 50
+
+	// Network snapshots
+	DefaultSnapshotConfirmationDepth uint64 = 0
+	MaxSnapshotConfirmationDepth     uint64 = 64
+
+	// Minipool processing keeper
+	DefaultKeeperDailyBudget       float64 = 0.01
+	DefaultKeeperMinRewardMultiple float64 = 1
+
+	// xrETH oracle reporter
+	DefaultOracleReporterMaxAge uint64 = 60
+
+	// Notifications
+	DefaultNotificationSmtpPort             uint16 = 587
+	DefaultNotificationDesyncThreshold      uint64 = 30
+	DefaultNotificationLaunchTimeoutWarning uint64 = 24
+
 	// Logging
 	ClientLogName string = "hd.log"
 )
@@ -38,6 +38,44 @@ func (cfg *ConstellationConfig) GetVcContainerTag() string {
 	}
 }
 
+// The settings of one of the VCs, along with the flag its start script uses for doppelganger detection
+type vcClientSettings struct {
+	name             string
+	containerTag     *config.Parameter[string]
+	additionalFlags  *config.Parameter[string]
+	doppelgangerFlag string
+}
+
+// Get the settings of the VC that matches the selected Beacon Node
+func (cfg *ConstellationConfig) getSelectedVcSettings() (*vcClientSettings, error) {
+	bn := cfg.hdCfg.GetSelectedBeaconNode()
+	switch bn {
+	case config.BeaconNode_Lighthouse:
+		if cfg.Lighthouse != nil {
+			return &vcClientSettings{name: "Lighthouse", containerTag: &cfg.Lighthouse.ContainerTag, additionalFlags: &cfg.Lighthouse.AdditionalFlags, doppelgangerFlag: "--enable-doppelganger-protection"}, nil
+		}
+	case config.BeaconNode_Lodestar:
+		if cfg.Lodestar != nil {
+			return &vcClientSettings{name: "Lodestar", containerTag: &cfg.Lodestar.ContainerTag, additionalFlags: &cfg.Lodestar.AdditionalFlags, doppelgangerFlag: "--doppelgangerProtection"}, nil
+		}
+	case config.BeaconNode_Nimbus:
+		if cfg.Nimbus != nil {
+			return &vcClientSettings{name: "Nimbus", containerTag: &cfg.Nimbus.ContainerTag, additionalFlags: &cfg.Nimbus.AdditionalFlags, doppelgangerFlag: "--doppelganger-detection"}, nil
+		}
+	case config.BeaconNode_Prysm:
+		if cfg.Prysm != nil {
+			return &vcClientSettings{name: "Prysm", containerTag: &cfg.Prysm.ContainerTag, additionalFlags: &cfg.Prysm.AdditionalFlags, doppelgangerFlag: "--enable-doppelganger"}, nil
+		}
+	case config.BeaconNode_Teku:
+		if cfg.Teku != nil {
+			return &vcClientSettings{name: "Teku", containerTag: &cfg.Teku.ContainerTag, additionalFlags: &cfg.Teku.AdditionalFlags, doppelgangerFlag: "--doppelganger-detection-enabled"}, nil
+		}
+	default:
+		return nil, fmt.Errorf("there is no Constellation Validator Client for the selected Beacon Node [%s]", bn)
+	}
+	return nil, fmt.Errorf("the Constellation Validator Client settings for the selected Beacon Node [%s] are missing", bn)
+}
+
 // Gets the additional flags of the selected VC
 func (cfg *ConstellationConfig) GetVcAdditionalFlags() string {
 	bn := cfg.hdCfg.GetSelectedBeaconNode()
@@ -76,3 +114,47 @@ func (cfg *ConstellationConfig) Graffiti() string {
 func (cfg *ConstellationConfig) IsEnabled() bool {
 	return cfg.Enabled.Value
 }
+
+// Used by text/template to set the fee recipient of the VC.
+// Custom networks can leave it out of their settings, in which case it comes from the resources the daemon discovered;
+// this errors if the daemon hasn't discovered them yet, so the VC never runs without one.
+func (cfg *ConstellationConfig) FeeRecipient() (string, error) {
+	settings, err := cfg.getTemplatingSettings()
+	if err != nil {
+		return "", err
+	}
+	return settings.ConstellationResources.FeeRecipient.Hex(), nil
+}
+
+// Used by text/template to get the address of RocketStorage, resolved the same way as the fee recipient
+func (cfg *ConstellationConfig) RocketStorage() (string, error) {
+	settings, err := cfg.getTemplatingSettings()
+	if err != nil {
+		return "", err
+	}
+	return settings.ConstellationResources.RocketStorage.Hex(), nil
+}
+
+// Get the settings for the selected network, with any resources they left out filled in from the ones the daemon discovered
+func (cfg *ConstellationConfig) getTemplatingSettings() (*ConstellationSettings, error) {
+	network := cfg.hdCfg.Network.Value
+	var settings *ConstellationSettings
+	for _, networkSettings := range cfg.GetNetworkSettings() {
+		if networkSettings.Key == network {
+			settings = networkSettings
+			break
+		}
+	}
+	if settings == nil {
+		return nil, fmt.Errorf("no constellation resources found for selected network [%s]", network)
+	}
+
+	settings, discovered, err := settings.WithDiscoveredResources(cfg.getModuleDir())
+	if err != nil {
+		return nil, err
+	}
+	if !discovered {
+		return nil, fmt.Errorf("the network settings for [%s] leave out resources that the daemon hasn't discovered yet; start the daemon and wait for its Execution Client to sync", network)
+	}
+	return settings, nil
+}
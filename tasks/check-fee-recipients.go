@@ -0,0 +1,230 @@
+package cstasks
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/ethereum/go-ethereum/common"
+	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/nodeset-org/hyperdrive-constellation/shared/keys"
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/rocket-pool/node-manager-core/log"
+	"github.com/rocket-pool/rocketpool-go/v2/rocketpool"
+)
+
+const (
+	// Number of epochs to look back on the first scan
+	feeRecipientInitialLookbackEpochs uint64 = 10
+
+	// Max number of epochs to scan in a single run, so a long downtime is caught up on gradually
+	feeRecipientMaxEpochsPerRun uint64 = 20
+)
+
+// Check fee recipients task
+type CheckFeeRecipientsTask struct {
+	sp        cscommon.IConstellationServiceProvider
+	logger    *slog.Logger
+	ctx       context.Context
+	rpMgr     *cscommon.RocketPoolManager
+	monitor   *cscommon.FeeRecipientMonitor
+	bc        beacon.IBeaconClient
+	beaconApi *cscommon.BeaconApiClient
+	beaconCfg *beacon.Eth2Config
+}
+
+// Create a check fee recipients task
+func NewCheckFeeRecipientsTask(ctx context.Context, sp cscommon.IConstellationServiceProvider, logger *log.Logger) *CheckFeeRecipientsTask {
+	log := logger.With(slog.String(keys.TaskKey, "Check Fee Recipients"))
+	return &CheckFeeRecipientsTask{
+		ctx:       ctx,
+		sp:        sp,
+		logger:    log,
+		rpMgr:     sp.GetRocketPoolManager(),
+		monitor:   sp.GetFeeRecipientMonitor(),
+		bc:        sp.GetBeaconClient(),
+		beaconApi: sp.GetBeaconApiClient(),
+	}
+}
+
+// Scan the blocks proposed by the node's validators since the last run and make sure they paid the correct fee recipient
+// and used the configured graffiti
+func (t *CheckFeeRecipientsTask) Run(snapshot *NetworkSnapshot) error {
+	// Log
+	t.logger.Info("Checking fee recipients and graffiti of recent proposals...")
+
+	// Get the Beacon config
+	if t.beaconCfg == nil {
+		cfg, err := t.bc.GetEth2Config(t.ctx)
+		if err != nil {
+			return fmt.Errorf("error getting Beacon config: %w", err)
+		}
+		t.beaconCfg = &cfg
+	}
+
+	// Get the smoothing pool address from RocketStorage and make sure the configured fee recipient matches it
+	smoothingPool, err := t.rpMgr.RocketPool().GetContract(rocketpool.ContractName_RocketSmoothingPool)
+	if err != nil {
+		return fmt.Errorf("error getting smoothing pool contract: %w", err)
+	}
+	smoothingPoolAddress := smoothingPool.Address
+	configuredFeeRecipient := *t.sp.GetResources().FeeRecipient
+	if configuredFeeRecipient != smoothingPoolAddress {
+		t.logger.Error("Configured fee recipient does not match the Rocket Pool smoothing pool! Your validators will be penalized for proposals that use it.",
+			slog.String("feeRecipient", configuredFeeRecipient.Hex()),
+			slog.String("smoothingPool", smoothingPoolAddress.Hex()),
+		)
+	}
+
+	// Get the range of epochs to scan; only completed epochs are checked
+	head, err := t.bc.GetBeaconHead(t.ctx)
+	if err != nil {
+		return fmt.Errorf("error getting beacon head: %w", err)
+	}
+	if head.Epoch == 0 {
+		return nil
+	}
+	endEpoch := head.Epoch - 1
+	var startEpoch uint64
+	lastCheckedEpoch, hasScanned := t.monitor.GetLastCheckedEpoch()
+	if hasScanned {
+		if lastCheckedEpoch >= endEpoch {
+			return nil
+		}
+		startEpoch = lastCheckedEpoch + 1
+	} else if endEpoch > feeRecipientInitialLookbackEpochs {
+		startEpoch = endEpoch - feeRecipientInitialLookbackEpochs
+	}
+	if endEpoch-startEpoch >= feeRecipientMaxEpochsPerRun {
+		endEpoch = startEpoch + feeRecipientMaxEpochsPerRun - 1
+	}
+
+	// Map the node's validator indices to their minipools
+	validators, err := t.getValidators(snapshot)
+	if err != nil {
+		return err
+	}
+	if len(validators) == 0 {
+		// Nothing to check, so just move the checkpoint forward
+		return t.monitor.MarkEpochChecked(endEpoch, nil)
+	}
+	indices := make([]string, 0, len(validators))
+	for index := range validators {
+		indices = append(indices, index)
+	}
+	expectedGraffiti := cscommon.GetExpectedGraffiti(t.sp.GetConfig())
+
+	// Scan each epoch
+	for epoch := startEpoch; epoch <= endEpoch; epoch++ {
+		violations, err := t.checkEpoch(epoch, indices, validators, configuredFeeRecipient, smoothingPoolAddress, expectedGraffiti)
+		if err != nil {
+			return fmt.Errorf("error checking proposals for epoch %d: %w", epoch, err)
+		}
+		err = t.monitor.MarkEpochChecked(epoch, violations)
+		if err != nil {
+			return fmt.Errorf("error saving fee recipient check for epoch %d: %w", epoch, err)
+		}
+	}
+	return nil
+}
+
+// Get a map of validator index to the validator info for each of the node's minipools that are on Beacon
+func (t *CheckFeeRecipientsTask) getValidators(snapshot *NetworkSnapshot) (map[string]csapi.MinipoolValidatorInfo, error) {
+	minipools := snapshot.ConstellationNode.Minipools
+	validators := map[string]csapi.MinipoolValidatorInfo{}
+	if len(minipools) == 0 {
+		return validators, nil
+	}
+
+	pubkeys := make([]beacon.ValidatorPubkey, len(minipools))
+	for i, mp := range minipools {
+		pubkeys[i] = mp.Common().Pubkey.Get()
+	}
+	statuses, err := t.bc.GetValidatorStatuses(t.ctx, pubkeys, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting validator statuses: %w", err)
+	}
+	for _, mp := range minipools {
+		mpCommon := mp.Common()
+		pubkey := mpCommon.Pubkey.Get()
+		status, exists := statuses[pubkey]
+		if !exists || status.Index == "" {
+			continue
+		}
+		validators[status.Index] = csapi.MinipoolValidatorInfo{
+			Address: mpCommon.Address,
+			Pubkey:  pubkey,
+			Index:   status.Index,
+		}
+	}
+	return validators, nil
+}
+
+// Check the blocks proposed by the node's validators in the given epoch
+func (t *CheckFeeRecipientsTask) checkEpoch(epoch uint64, indices []string, validators map[string]csapi.MinipoolValidatorInfo, configuredFeeRecipient common.Address, smoothingPoolAddress common.Address, expectedGraffiti string) ([]csapi.MinipoolFeeRecipientViolation, error) {
+	// Only the slots the validators were scheduled to propose in need to be checked
+	duties, err := t.beaconApi.GetProposerDuties(t.ctx, indices, epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := []csapi.MinipoolFeeRecipientViolation{}
+	for _, duty := range duties {
+		slot := duty.Slot
+		block, exists, err := t.beaconApi.GetBlock(t.ctx, slot)
+		if err != nil {
+			return nil, err
+		}
+		if !exists || !block.HasExecutionPayload {
+			continue
+		}
+		info, isOurs := validators[block.ProposerIndex]
+		if !isOurs {
+			continue
+		}
+
+		wrongFeeRecipient := block.FeeRecipient != smoothingPoolAddress || block.FeeRecipient != configuredFeeRecipient
+		wrongGraffiti := block.Graffiti != expectedGraffiti
+		if !wrongFeeRecipient && !wrongGraffiti {
+			t.logger.Info("Proposal used the correct fee recipient and graffiti",
+				slog.Uint64("slot", slot),
+				slog.String("minipool", info.Address.Hex()),
+			)
+			continue
+		}
+		if wrongFeeRecipient {
+			t.logger.Error("Proposal used the wrong fee recipient!",
+				slog.Uint64("slot", slot),
+				slog.String("minipool", info.Address.Hex()),
+				slog.String("pubkey", info.Pubkey.HexWithPrefix()),
+				slog.String("feeRecipient", block.FeeRecipient.Hex()),
+				slog.String("expected", smoothingPoolAddress.Hex()),
+			)
+		}
+		if wrongGraffiti {
+			t.logger.Warn("Proposal used different graffiti than the one configured; the VC may not be using the current config",
+				slog.Uint64("slot", slot),
+				slog.String("minipool", info.Address.Hex()),
+				slog.String("pubkey", info.Pubkey.HexWithPrefix()),
+				slog.String("graffiti", block.Graffiti),
+				slog.String("expected", expectedGraffiti),
+			)
+		}
+		violations = append(violations, csapi.MinipoolFeeRecipientViolation{
+			Slot:                 slot,
+			Epoch:                epoch,
+			ExecutionBlockNumber: block.ExecutionBlockNumber,
+			Address:              info.Address,
+			Pubkey:               info.Pubkey,
+			Index:                info.Index,
+			WrongFeeRecipient:    wrongFeeRecipient,
+			FeeRecipient:         block.FeeRecipient,
+			ExpectedFeeRecipient: smoothingPoolAddress,
+			WrongGraffiti:        wrongGraffiti,
+			Graffiti:             block.Graffiti,
+			ExpectedGraffiti:     expectedGraffiti,
+		})
+	}
+	return violations, nil
+}
@@ -2,15 +2,18 @@ package cstasks
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"math/big"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
 	"github.com/nodeset-org/hyperdrive-constellation/shared/keys"
 	batch "github.com/rocket-pool/batch-query"
 	"github.com/rocket-pool/node-manager-core/eth"
@@ -19,15 +22,22 @@ import (
 	"github.com/rocket-pool/rocketpool-go/v2/dao/oracle"
 	"github.com/rocket-pool/rocketpool-go/v2/dao/protocol"
 	"github.com/rocket-pool/rocketpool-go/v2/minipool"
+	rptypes "github.com/rocket-pool/rocketpool-go/v2/types"
 )
 
 const (
 	minipoolDetailsBatchSize int = 100
 )
 
+var (
+	// The block a network snapshot was taken at is no longer on the canonical chain
+	ErrSnapshotReorged error = errors.New("the network snapshot's block was reorged out")
+)
+
 type ConstellationNodeSnapshot struct {
-	NodeAddress common.Address
-	Minipools   []minipool.IMinipool
+	NodeAddress   common.Address
+	IsWhitelisted bool
+	Minipools     []minipool.IMinipool
 }
 
 type RocketPoolNetworkSettings struct {
@@ -49,6 +59,9 @@ type NetworkSnapshotTask struct {
 	csMgr  *cscommon.ConstellationManager
 	rpMgr  *cscommon.RocketPoolManager
 	ec     eth.IExecutionClient
+
+	// The status of each of the node's minipools as of the last snapshot, used to report status changes
+	minipoolStatuses map[common.Address]rptypes.MinipoolStatus
 }
 
 // Creates a new network snapshot task
@@ -85,9 +98,46 @@ func (t *NetworkSnapshotTask) Run(walletStatus *wallet.WalletStatus) (*NetworkSn
 	t.logger.Info("Network snapshot created",
 		slog.String("block", snapshot.ExecutionBlockHeader.Number.String()),
 	)
+	blockNumber := snapshot.ExecutionBlockHeader.Number.Uint64()
+	t.sp.GetHealthTracker().SetSnapshot(blockNumber, snapshot.ConstellationNode.IsWhitelisted)
+	t.sp.GetEventBroker().Publish(csapi.Event{
+		Type:        csapi.EventType_SnapshotCreated,
+		Block:       blockNumber,
+		NodeAddress: snapshot.ConstellationNode.NodeAddress,
+	})
+	t.publishStatusChanges(snapshot)
 	return snapshot, nil
 }
 
+// Publish an event for each minipool whose status changed since the last snapshot.
+// Minipools are only reported once they've been seen in a snapshot, so nothing is published on the first run.
+func (t *NetworkSnapshotTask) publishStatusChanges(snapshot *NetworkSnapshot) {
+	isFirstRun := t.minipoolStatuses == nil
+	statuses := map[common.Address]rptypes.MinipoolStatus{}
+	for _, mp := range snapshot.ConstellationNode.Minipools {
+		mpCommon := mp.Common()
+		status := mpCommon.Status.Formatted()
+		statuses[mpCommon.Address] = status
+		if isFirstRun {
+			continue
+		}
+
+		previousStatus, exists := t.minipoolStatuses[mpCommon.Address]
+		if exists && previousStatus == status {
+			continue
+		}
+		t.sp.GetEventBroker().Publish(csapi.Event{
+			Type:           csapi.EventType_MinipoolStatusChanged,
+			Block:          snapshot.ExecutionBlockHeader.Number.Uint64(),
+			NodeAddress:    snapshot.ConstellationNode.NodeAddress,
+			Minipool:       mpCommon.Address,
+			PreviousStatus: previousStatus,
+			Status:         status,
+		})
+	}
+	t.minipoolStatuses = statuses
+}
+
 // Refresh the contract managers
 func (t *NetworkSnapshotTask) refreshContracts() error {
 	// Refresh RP
@@ -107,7 +157,7 @@ func (t *NetworkSnapshotTask) refreshContracts() error {
 // Get the network snapshot
 func (t *NetworkSnapshotTask) createNetworkSnapshot(nodeAddress common.Address) (*NetworkSnapshot, error) {
 	// Make some bindings
-	rp := t.rpMgr.RocketPool
+	rp := t.rpMgr.RocketPool()
 	qMgr := t.sp.GetQueryManager()
 	pdaoMgr, err := protocol.NewProtocolDaoManager(rp)
 	if err != nil {
@@ -122,19 +172,32 @@ func (t *NetworkSnapshotTask) createNetworkSnapshot(nodeAddress common.Address)
 		return nil, fmt.Errorf("error creating minipool manager binding: %w", err)
 	}
 
-	// Get the latest header
+	// Get the latest header, then step back to the confirmation depth if there is one
 	header, err := t.ec.HeaderByNumber(t.ctx, nil)
 	if err != nil {
 		return nil, fmt.Errorf("error getting the latest block header: %w", err)
 	}
+	depth := t.sp.GetConfig().SnapshotConfirmationDepth.Value
+	if depth > 0 {
+		blockNumber := big.NewInt(0)
+		if header.Number.Uint64() > depth {
+			blockNumber.SetUint64(header.Number.Uint64() - depth)
+		}
+		header, err = t.ec.HeaderByNumber(t.ctx, blockNumber)
+		if err != nil {
+			return nil, fmt.Errorf("error getting the header for block %s: %w", blockNumber.String(), err)
+		}
+	}
 	callOpts := &bind.CallOpts{
 		BlockNumber: header.Number,
 	}
 
 	// Run a query
 	var minipoolAddresses []common.Address
+	var isWhitelisted bool
 	err = qMgr.Query(func(mc *batch.MultiCaller) error {
-		t.csMgr.SuperNodeAccount.GetSubNodeMinipools(mc, &minipoolAddresses, nodeAddress)
+		t.csMgr.Contracts().SuperNodeAccount.GetSubNodeMinipools(mc, &minipoolAddresses, nodeAddress)
+		t.csMgr.Contracts().Whitelist.IsAddressInWhitelist(mc, &isWhitelisted, nodeAddress)
 		return nil
 	}, callOpts,
 		odaoMgr.Settings.Minipool.ScrubPeriod,
@@ -178,11 +241,29 @@ func (t *NetworkSnapshotTask) createNetworkSnapshot(nodeAddress common.Address)
 			MinipoolStakeValue: mpMgr.StakeValue.Get(),
 		},
 		ConstellationNode: &ConstellationNodeSnapshot{
-			NodeAddress: nodeAddress,
-			Minipools:   mps,
+			NodeAddress:   nodeAddress,
+			IsWhitelisted: isWhitelisted,
+			Minipools:     mps,
 		},
 	}
 
 	// Return
 	return snapshot, nil
 }
+
+// Make sure the block a network snapshot was taken at is still on the canonical chain.
+// Returns ErrSnapshotReorged if it isn't, in which case a new snapshot should be taken before acting on it.
+func checkSnapshotCanonical(ctx context.Context, ec eth.IExecutionClient, snapshot *NetworkSnapshot) error {
+	header, err := ec.HeaderByNumber(ctx, snapshot.ExecutionBlockHeader.Number)
+	if errors.Is(err, ethereum.NotFound) {
+		// The canonical chain is shorter than it was, so the block is gone
+		return fmt.Errorf("%w: block %s is no longer on the chain", ErrSnapshotReorged, snapshot.ExecutionBlockHeader.Number.String())
+	}
+	if err != nil {
+		return fmt.Errorf("error getting the header for block %s: %w", snapshot.ExecutionBlockHeader.Number.String(), err)
+	}
+	if header.Hash() != snapshot.ExecutionBlockHeader.Hash() {
+		return fmt.Errorf("%w: block %s changed from %s to %s", ErrSnapshotReorged, header.Number.String(), snapshot.ExecutionBlockHeader.Hash().Hex(), header.Hash().Hex())
+	}
+	return nil
+}
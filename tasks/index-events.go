@@ -0,0 +1,51 @@
+package cstasks
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	"github.com/nodeset-org/hyperdrive-constellation/shared/keys"
+	"github.com/rocket-pool/node-manager-core/log"
+)
+
+// Index contract events task
+type IndexEventsTask struct {
+	sp      cscommon.IConstellationServiceProvider
+	logger  *slog.Logger
+	ctx     context.Context
+	indexer *cscommon.EventIndexer
+}
+
+// Create an index contract events task
+func NewIndexEventsTask(ctx context.Context, sp cscommon.IConstellationServiceProvider, logger *log.Logger) *IndexEventsTask {
+	log := logger.With(slog.String(keys.TaskKey, "Index Contract Events"))
+	return &IndexEventsTask{
+		ctx:     ctx,
+		sp:      sp,
+		logger:  log,
+		indexer: sp.GetEventIndexer(),
+	}
+}
+
+// Add the Constellation contract events since the last run to the index.
+// This relies on the network snapshot task having loaded the contracts.
+func (t *IndexEventsTask) Run() error {
+	// Log
+	t.logger.Info("Indexing Constellation contract events...")
+
+	status, err := t.indexer.Update(t.ctx)
+	if err != nil {
+		return fmt.Errorf("error updating event index: %w", err)
+	}
+	if !status.IsCaughtUp() {
+		t.logger.Info("Event index is still catching up",
+			slog.Uint64("indexedBlock", status.IndexedBlock),
+			slog.Uint64("latestBlock", status.LatestBlock),
+		)
+		return nil
+	}
+	t.logger.Info("Event index is up to date", slog.Uint64("block", status.IndexedBlock))
+	return nil
+}
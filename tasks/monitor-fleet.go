@@ -0,0 +1,117 @@
+package cstasks
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	csconfig "github.com/nodeset-org/hyperdrive-constellation/shared/config"
+	"github.com/nodeset-org/hyperdrive-constellation/shared/keys"
+	"github.com/rocket-pool/node-manager-core/log"
+	"github.com/rocket-pool/node-manager-core/wallet"
+	rptypes "github.com/rocket-pool/rocketpool-go/v2/types"
+)
+
+// Monitor fleet task
+type MonitorFleetTask struct {
+	sp           cscommon.IConstellationServiceProvider
+	logger       *slog.Logger
+	ctx          context.Context
+	cfg          *csconfig.ConstellationConfig
+	snapshotTask *NetworkSnapshotTask
+}
+
+// Create a monitor fleet task
+func NewMonitorFleetTask(ctx context.Context, sp cscommon.IConstellationServiceProvider, logger *log.Logger) *MonitorFleetTask {
+	log := logger.With(slog.String(keys.TaskKey, "Monitor Fleet"))
+	return &MonitorFleetTask{
+		ctx:          ctx,
+		sp:           sp,
+		logger:       log,
+		cfg:          sp.GetConfig(),
+		snapshotTask: NewNetworkSnapshotTask(ctx, sp, logger),
+	}
+}
+
+// Check the minipools of the other nodes in the fleet.
+// The daemon's own node is handled by the other tasks, and an error on one node won't stop the rest from being checked.
+// The daemon can't sign for the other nodes, so this only warns about (and sends notifications for) minipools that
+// their operators need to act on; it doesn't run the rest of the task loop for them.
+func (t *MonitorFleetTask) Run(walletStatus *wallet.WalletStatus) error {
+	nodes, err := cscommon.GetFleetNodes(t.cfg, *walletStatus)
+	if err != nil {
+		return fmt.Errorf("error getting fleet nodes: %w", err)
+	}
+	if len(nodes) < 2 {
+		return nil
+	}
+
+	// Log
+	t.logger.Info("Checking fleet nodes...", slog.Int("count", len(nodes)-1))
+
+	for _, node := range nodes {
+		if node.IsDaemonNode {
+			continue
+		}
+		err := t.checkNode(node.Address)
+		if err != nil {
+			t.logger.Error("Error checking fleet node",
+				slog.String("node", node.Address.Hex()),
+				log.Err(err),
+			)
+		}
+	}
+	return nil
+}
+
+// Check a single fleet node for minipools that need attention from its operator
+func (t *MonitorFleetTask) checkNode(nodeAddress common.Address) error {
+	snapshot, err := t.snapshotTask.createNetworkSnapshot(nodeAddress)
+	if err != nil {
+		return fmt.Errorf("error creating network snapshot: %w", err)
+	}
+
+	scrubPeriod := snapshot.RocketPoolNetworkSettings.ScrubPeriod
+	launchTimeout := snapshot.RocketPoolNetworkSettings.LaunchTimeout
+	blockTime := time.Unix(int64(snapshot.ExecutionBlockHeader.Time), 0)
+	prelaunchCount := 0
+	for _, mp := range snapshot.ConstellationNode.Minipools {
+		mpCommon := mp.Common()
+		if mpCommon.Status.Formatted() != rptypes.MinipoolStatus_Prelaunch {
+			continue
+		}
+		prelaunchCount++
+		checkLaunchTimeout(t.ctx, t.sp, t.logger, nodeAddress, mpCommon.Address, mpCommon.StatusTime.Formatted(), launchTimeout, blockTime)
+
+		// Skip minipools that are still in the scrub period
+		prelaunchTime := mpCommon.StatusTime.Formatted()
+		if prelaunchTime.Add(scrubPeriod).After(blockTime) {
+			continue
+		}
+
+		// The daemon can't sign for this node, so all it can do is warn the operator
+		isDue, timeUntilDue := isTransactionDue(prelaunchTime, launchTimeout)
+		if isDue {
+			t.logger.Warn("Fleet node has a minipool that has exceeded half of the timeout period without being staked; its operator needs to stake it.",
+				slog.String("node", nodeAddress.Hex()),
+				slog.String("minipool", mpCommon.Address.Hex()),
+			)
+		} else {
+			t.logger.Info("Fleet node has a minipool that's ready to stake.",
+				slog.String("node", nodeAddress.Hex()),
+				slog.String("minipool", mpCommon.Address.Hex()),
+				slog.String("timeUntilDue", timeUntilDue.String()),
+			)
+		}
+	}
+
+	t.logger.Info("Fleet node checked.",
+		slog.String("node", nodeAddress.Hex()),
+		slog.Int("minipools", len(snapshot.ConstellationNode.Minipools)),
+		slog.Int("prelaunch", prelaunchCount),
+	)
+	return nil
+}
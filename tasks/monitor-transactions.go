@@ -0,0 +1,200 @@
+package cstasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/nodeset-org/hyperdrive-constellation/shared/keys"
+	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/rocket-pool/node-manager-core/log"
+)
+
+const (
+	// How long before a transaction's deadline it starts getting sped up if it's paying less than the current suggested fees
+	txSpeedUpWindow time.Duration = time.Hour * 6
+
+	// The minimum time between automatic replacements of the same transaction, so each one has a chance to be included
+	txMinReplacementInterval time.Duration = time.Minute * 5
+)
+
+// Monitor transactions task
+type MonitorTransactionsTask struct {
+	sp        cscommon.IConstellationServiceProvider
+	logger    *slog.Logger
+	ctx       context.Context
+	txTracker *cscommon.TransactionTracker
+}
+
+// Create a monitor transactions task
+func NewMonitorTransactionsTask(ctx context.Context, sp cscommon.IConstellationServiceProvider, logger *log.Logger) *MonitorTransactionsTask {
+	log := logger.With(slog.String(keys.TaskKey, "Monitor Transactions"))
+	return &MonitorTransactionsTask{
+		ctx:       ctx,
+		sp:        sp,
+		logger:    log,
+		txTracker: sp.GetTransactionTracker(),
+	}
+}
+
+// Check on the transactions submitted by the daemon, and speed up any that are stuck as their deadline approaches
+func (t *MonitorTransactionsTask) Run(snapshot *NetworkSnapshot) error {
+	// Report on anything that's finished
+	completed, err := t.txTracker.Refresh(t.ctx)
+	if err != nil {
+		return fmt.Errorf("error refreshing pending transactions: %w", err)
+	}
+	errs := []error{}
+	for _, completedTx := range completed {
+		tx := completedTx.Transaction
+		receipt := completedTx.Receipt
+		if receipt == nil {
+			t.logger.Warn("Tracked transaction's nonce was used by a different transaction.",
+				slog.String("description", tx.Description),
+				slog.Uint64("nonce", tx.Nonce),
+			)
+		} else if receipt.Status != types.ReceiptStatusSuccessful {
+			t.logger.Error("Tracked transaction was included but reverted.",
+				slog.String("description", tx.Description),
+				slog.String("hash", receipt.TxHash.Hex()),
+			)
+		} else if tx.IsCancellation {
+			t.logger.Info("Tracked transaction was cancelled.",
+				slog.String("description", tx.Description),
+				slog.String("hash", receipt.TxHash.Hex()),
+			)
+		} else {
+			t.logger.Info("Tracked transaction complete.",
+				slog.String("description", tx.Description),
+				slog.String("hash", receipt.TxHash.Hex()),
+			)
+		}
+		if receipt != nil {
+			t.sp.GetEventBroker().Publish(csapi.Event{
+				Type:        csapi.EventType_TxMined,
+				Block:       receipt.BlockNumber.Uint64(),
+				NodeAddress: tx.From,
+				Minipool:    tx.Minipool,
+				TxHash:      receipt.TxHash,
+				Description: tx.Description,
+				Succeeded:   receipt.Status == types.ReceiptStatusSuccessful,
+			})
+		}
+		switch tx.Description {
+		case stakeTxDescription:
+			t.notifyStakeResult(completedTx)
+		case processMinipoolTxDescription:
+			err = t.recordKeeperCost(completedTx)
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	// Find any that are running out of time
+	pendingTxs := t.txTracker.GetPendingTransactions()
+	if len(pendingTxs) == 0 {
+		return errors.Join(errs...)
+	}
+	t.logger.Info("Checking pending transactions...", slog.Int("count", len(pendingTxs)))
+	suggestedMaxFee, suggestedPriorityFee, err := cscommon.SuggestTransactionFees(t.ctx, t.sp.GetEthClient())
+	if err != nil {
+		return errors.Join(append(errs, err)...)
+	}
+	for _, tx := range pendingTxs {
+		if !tx.HasDeadline || tx.IsCancellation {
+			continue
+		}
+		timeUntilDeadline := time.Until(tx.Deadline)
+		if timeUntilDeadline > txSpeedUpWindow || time.Since(tx.LastReplacementTime) < txMinReplacementInterval {
+			continue
+		}
+		if tx.MaxFeePerGas.Cmp(suggestedMaxFee) >= 0 && tx.MaxPriorityFeePerGas.Cmp(suggestedPriorityFee) >= 0 {
+			continue
+		}
+
+		// Replace it with higher fees
+		opts := t.sp.GetSigner().GetTransactor(tx.From)
+		opts.Context = t.ctx
+		replacement, err := t.txTracker.ReplaceTransaction(t.ctx, opts, tx.Nonce, false)
+		if err != nil {
+			// Keep going so one TX that can't be replaced doesn't hold up the others
+			t.logger.Error("Error speeding up pending transaction",
+				slog.String("description", tx.Description),
+				slog.String("hash", tx.Hash.Hex()),
+				log.Err(err),
+			)
+			errs = append(errs, fmt.Errorf("error speeding up transaction %s: %w", tx.Hash.Hex(), err))
+			continue
+		}
+		t.logger.Warn("Pending transaction was underpriced as its deadline approached, so it has been sped up.",
+			slog.String("description", tx.Description),
+			slog.String("timeUntilDeadline", timeUntilDeadline.String()),
+			slog.String("oldHash", tx.Hash.Hex()),
+			slog.String("newHash", replacement.Hash.Hex()),
+			slog.Float64("maxFee", eth.WeiToGwei(replacement.MaxFeePerGas)),
+			slog.Float64("maxPriorityFee", eth.WeiToGwei(replacement.MaxPriorityFeePerGas)),
+		)
+		t.sp.GetEventBroker().Publish(csapi.Event{
+			Type:        csapi.EventType_TxSubmitted,
+			NodeAddress: replacement.From,
+			Minipool:    replacement.Minipool,
+			TxHash:      replacement.Hash,
+			Description: replacement.Description,
+		})
+	}
+	return errors.Join(errs...)
+}
+
+// Charge the keeper's budget with what a minipool processing transaction actually cost, including any replacement fees
+func (t *MonitorTransactionsTask) recordKeeperCost(completedTx cscommon.CompletedTransaction) error {
+	tx := completedTx.Transaction
+	hashes := append([]common.Hash{tx.Hash}, tx.PreviousHashes...)
+	found, err := t.sp.GetKeeperTracker().RecordMined(hashes, completedTx.Receipt)
+	if err != nil {
+		return fmt.Errorf("error recording cost of minipool processing transaction %s: %w", tx.Hash.Hex(), err)
+	}
+	if !found {
+		t.logger.Warn("Minipool processing transaction wasn't in the keeper's stats, so its cost couldn't be recorded.",
+			slog.String("hash", tx.Hash.Hex()),
+		)
+	}
+	return nil
+}
+
+// Send a notification about how a tracked stake transaction turned out
+func (t *MonitorTransactionsTask) notifyStakeResult(completedTx cscommon.CompletedTransaction) {
+	tx := completedTx.Transaction
+	receipt := completedTx.Receipt
+	notification := csapi.Notification{
+		Event:       csapi.NotificationEvent_StakeFailed,
+		Severity:    csapi.NotificationSeverity_Error,
+		Title:       "Minipool stake failed",
+		NodeAddress: tx.From,
+		Minipool:    tx.Minipool,
+	}
+	switch {
+	case receipt == nil:
+		notification.Message = fmt.Sprintf("The stake transaction for minipool %s was replaced by a different transaction with the same nonce (%d).", tx.Minipool.Hex(), tx.Nonce)
+	case receipt.Status != types.ReceiptStatusSuccessful:
+		notification.Message = fmt.Sprintf("The stake transaction for minipool %s was included in block %d but reverted.", tx.Minipool.Hex(), receipt.BlockNumber.Uint64())
+		notification.TxHash = receipt.TxHash
+	case tx.IsCancellation:
+		notification.Severity = csapi.NotificationSeverity_Warning
+		notification.Message = fmt.Sprintf("The stake transaction for minipool %s was cancelled.", tx.Minipool.Hex())
+		notification.TxHash = receipt.TxHash
+	default:
+		notification.Event = csapi.NotificationEvent_StakeSucceeded
+		notification.Severity = csapi.NotificationSeverity_Info
+		notification.Title = "Minipool staked"
+		notification.Message = fmt.Sprintf("Minipool %s was staked successfully in block %d.", tx.Minipool.Hex(), receipt.BlockNumber.Uint64())
+		notification.TxHash = receipt.TxHash
+	}
+	sendNotification(t.ctx, t.sp, t.logger, "", notification)
+}
@@ -0,0 +1,248 @@
+package cstasks
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	csconfig "github.com/nodeset-org/hyperdrive-constellation/shared/config"
+	"github.com/nodeset-org/hyperdrive-constellation/shared/keys"
+	"github.com/nodeset-org/hyperdrive-daemon/module-utils/tx"
+	batch "github.com/rocket-pool/batch-query"
+	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/rocket-pool/node-manager-core/log"
+	"github.com/rocket-pool/node-manager-core/wallet"
+	"github.com/rocket-pool/rocketpool-go/v2/minipool"
+	rptypes "github.com/rocket-pool/rocketpool-go/v2/types"
+)
+
+const (
+	// Description of tracked minipool processing transactions
+	processMinipoolTxDescription string = "Process minipool"
+)
+
+// Process minipools task
+type ProcessMinipoolsTask struct {
+	sp             cscommon.IConstellationServiceProvider
+	logger         *slog.Logger
+	ctx            context.Context
+	cfg            *csconfig.ConstellationConfig
+	csMgr          *cscommon.ConstellationManager
+	rpMgr          *cscommon.RocketPoolManager
+	txTracker      *cscommon.TransactionTracker
+	keeper         *cscommon.KeeperTracker
+	maxFee         *big.Int
+	maxPriorityFee *big.Int
+}
+
+// Create a process minipools task
+func NewProcessMinipoolsTask(ctx context.Context, sp cscommon.IConstellationServiceProvider, logger *log.Logger) *ProcessMinipoolsTask {
+	log := logger.With(slog.String(keys.TaskKey, "Process Minipools"))
+	maxFee, maxPriorityFee := tx.GetAutoTxInfo(sp.GetHyperdriveConfig(), log)
+	return &ProcessMinipoolsTask{
+		ctx:            ctx,
+		sp:             sp,
+		logger:         log,
+		cfg:            sp.GetConfig(),
+		csMgr:          sp.GetConstellationManager(),
+		rpMgr:          sp.GetRocketPoolManager(),
+		txTracker:      sp.GetTransactionTracker(),
+		keeper:         sp.GetKeeperTracker(),
+		maxFee:         maxFee,
+		maxPriorityFee: maxPriorityFee,
+	}
+}
+
+// Process the Operator Distributor's next minipool if the rewards it would distribute are worth the gas and it fits in the daily budget.
+// This relies on the network snapshot task having loaded the contracts.
+func (t *ProcessMinipoolsTask) Run(walletStatus *wallet.WalletStatus) error {
+	if !t.cfg.KeeperEnabled.Value {
+		return nil
+	}
+	if !wallet.IsWalletReady(*walletStatus) {
+		t.logger.Debug("Wallet isn't ready, skipping minipool processing.")
+		return nil
+	}
+
+	// Log
+	t.logger.Info("Checking for a minipool to process...")
+
+	// Wait for the last one to finish so the same minipool isn't processed twice
+	for _, pendingTx := range t.txTracker.GetPendingTransactions() {
+		if pendingTx.Description == processMinipoolTxDescription {
+			t.logger.Info("Previous minipool processing transaction is still pending.", slog.String("hash", pendingTx.Hash.Hex()))
+			return t.keeper.RecordSkipped(csapi.KeeperSkipReason_TxPending, fmt.Sprintf("transaction %s is still pending", pendingTx.Hash.Hex()))
+		}
+	}
+
+	// Get the next minipool
+	var minipoolAddress common.Address
+	err := t.sp.GetQueryManager().Query(func(mc *batch.MultiCaller) error {
+		t.csMgr.Contracts().OperatorDistributor.GetNextMinipool(mc, &minipoolAddress)
+		return nil
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("error getting next minipool to process: %w", err)
+	}
+	if minipoolAddress == (common.Address{}) {
+		t.logger.Info("No minipools to process.")
+		return t.keeper.RecordSkipped(csapi.KeeperSkipReason_NoMinipools, "")
+	}
+	rewards, err := t.getDistributableRewards(minipoolAddress)
+	if err != nil {
+		return err
+	}
+
+	// Simulate processing it
+	nodeAddress := walletStatus.Wallet.WalletAddress
+	opts := t.sp.GetSigner().GetTransactor(nodeAddress)
+	opts.Context = t.ctx
+	txInfo, err := t.csMgr.Contracts().OperatorDistributor.ProcessNextMinipool(opts)
+	if err != nil {
+		return fmt.Errorf("error creating process minipool transaction: %w", err)
+	}
+	if revert := cscommon.GetSimulationRevert(t.ctx, t.sp.GetEthClient(), nodeAddress, txInfo); revert != nil {
+		t.logger.Warn("Processing the next minipool failed simulation.",
+			slog.String("minipool", minipoolAddress.Hex()),
+			slog.String("error", revert.Message),
+		)
+		return t.keeper.RecordSkipped(csapi.KeeperSkipReason_SimulationReverted, revert.Message)
+	}
+
+	// Make sure it's worth the gas and fits in the budget
+	gasPrice, maxFee, maxPriorityFee, err := t.getGasPrices()
+	if err != nil {
+		return err
+	}
+	cost := new(big.Int).Mul(new(big.Int).SetUint64(txInfo.SimulationResult.EstimatedGasLimit), gasPrice)
+	minRewards := eth.EthToWei(eth.WeiToEth(cost) * t.cfg.KeeperMinRewardMultiple.Value)
+	if rewards.Cmp(minRewards) < 0 {
+		message := fmt.Sprintf("minipool %s has %.6f ETH to distribute but needs at least %.6f ETH", minipoolAddress.Hex(), eth.WeiToEth(rewards), eth.WeiToEth(minRewards))
+		t.logger.Info("Processing the next minipool isn't worth the gas yet.",
+			slog.String("minipool", minipoolAddress.Hex()),
+			slog.Float64("rewards", eth.WeiToEth(rewards)),
+			slog.Float64("estimatedCost", eth.WeiToEth(cost)),
+		)
+		return t.keeper.RecordSkipped(csapi.KeeperSkipReason_Unprofitable, message)
+	}
+	remainingBudget := t.keeper.GetRemainingBudget(eth.EthToWei(t.cfg.KeeperDailyBudget.Value))
+	if cost.Cmp(remainingBudget) > 0 {
+		message := fmt.Sprintf("processing would cost %.6f ETH but only %.6f ETH of today's budget is left", eth.WeiToEth(cost), eth.WeiToEth(remainingBudget))
+		t.logger.Info("Processing the next minipool would exceed the keeper's daily budget.",
+			slog.String("minipool", minipoolAddress.Hex()),
+			slog.Float64("estimatedCost", eth.WeiToEth(cost)),
+			slog.Float64("remainingBudget", eth.WeiToEth(remainingBudget)),
+		)
+		return t.keeper.RecordSkipped(csapi.KeeperSkipReason_OverBudget, message)
+	}
+
+	// Submit it
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = maxPriorityFee
+	opts.GasLimit = txInfo.SimulationResult.SafeGasLimit
+	submittedTx, err := t.sp.GetTransactionManager().ExecuteTransaction(txInfo, opts)
+	if err != nil {
+		return fmt.Errorf("error submitting process minipool transaction: %w", err)
+	}
+	t.logger.Info("Minipool processing transaction has been submitted.",
+		slog.String("minipool", minipoolAddress.Hex()),
+		slog.String("hash", submittedTx.Hash().Hex()),
+		slog.Float64("rewards", eth.WeiToEth(rewards)),
+		slog.Float64("estimatedCost", eth.WeiToEth(cost)),
+	)
+	t.sp.GetEventBroker().Publish(csapi.Event{
+		Type:        csapi.EventType_TxSubmitted,
+		NodeAddress: nodeAddress,
+		Minipool:    minipoolAddress,
+		TxHash:      submittedTx.Hash(),
+		Description: processMinipoolTxDescription,
+	})
+	err = t.txTracker.TrackTransaction(submittedTx, nodeAddress, processMinipoolTxDescription, minipoolAddress, time.Time{})
+	if err != nil {
+		return fmt.Errorf("error tracking process minipool transaction: %w", err)
+	}
+
+	// Charge the estimate for now; the monitor task swaps it for the actual cost, including any replacements, once it's mined
+	return t.keeper.RecordProcessed(csapi.KeeperProcessedMinipool{
+		Minipool:             minipoolAddress,
+		TxHash:               submittedTx.Hash(),
+		Time:                 time.Now(),
+		DistributableRewards: rewards,
+		EstimatedCost:        cost,
+	})
+}
+
+// Get the skimmed rewards in a minipool that processing it would distribute
+func (t *ProcessMinipoolsTask) getDistributableRewards(address common.Address) (*big.Int, error) {
+	mpMgr, err := minipool.NewMinipoolManager(t.rpMgr.RocketPool())
+	if err != nil {
+		return nil, fmt.Errorf("error creating minipool manager binding: %w", err)
+	}
+	mp, err := mpMgr.CreateMinipoolFromAddress(address, false, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating binding for minipool %s: %w", address.Hex(), err)
+	}
+	mpCommon := mp.Common()
+	err = t.sp.GetQueryManager().Query(nil, nil,
+		mpCommon.Status,
+		mpCommon.IsFinalised,
+		mpCommon.NodeRefundBalance,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error getting details of minipool %s: %w", address.Hex(), err)
+	}
+	balance, err := t.sp.GetEthClient().BalanceAt(t.ctx, address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting balance of minipool %s: %w", address.Hex(), err)
+	}
+
+	// Anything in a staking minipool's balance beyond its refund is skimmed rewards
+	rewards := big.NewInt(0)
+	if mpCommon.Status.Formatted() == rptypes.MinipoolStatus_Staking && !mpCommon.IsFinalised.Get() {
+		skim := new(big.Int).Sub(balance, mpCommon.NodeRefundBalance.Get())
+		if skim.Sign() > 0 {
+			rewards = skim
+		}
+	}
+	return rewards, nil
+}
+
+// Get the price per unit of gas the transaction is expected to pay, along with the max fee and priority fee to submit it with.
+// The configured auto-TX fees are used if they're set, otherwise the suggested fees are.
+func (t *ProcessMinipoolsTask) getGasPrices() (*big.Int, *big.Int, *big.Int, error) {
+	ec := t.sp.GetEthClient()
+	header, err := ec.HeaderByNumber(t.ctx, nil)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error getting latest block header: %w", err)
+	}
+	suggestedMaxFee, suggestedPriorityFee, err := cscommon.SuggestTransactionFees(t.ctx, ec)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	maxFee := suggestedMaxFee
+	if t.maxFee != nil && t.maxFee.Sign() > 0 {
+		maxFee = t.maxFee
+	}
+	maxPriorityFee := suggestedPriorityFee
+	if t.maxPriorityFee != nil && t.maxPriorityFee.Sign() > 0 {
+		maxPriorityFee = t.maxPriorityFee
+	}
+	if maxPriorityFee.Cmp(maxFee) > 0 {
+		maxPriorityFee = new(big.Int).Set(maxFee)
+	}
+
+	// The effective price is the base fee plus the tip, capped by the max fee
+	gasPrice := new(big.Int).Set(maxPriorityFee)
+	if header.BaseFee != nil {
+		gasPrice.Add(gasPrice, header.BaseFee)
+	}
+	if gasPrice.Cmp(maxFee) > 0 {
+		gasPrice.Set(maxFee)
+	}
+	return gasPrice, maxFee, maxPriorityFee, nil
+}
@@ -0,0 +1,195 @@
+package cstasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	"github.com/nodeset-org/hyperdrive-constellation/common/contracts/constellation"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	csconfig "github.com/nodeset-org/hyperdrive-constellation/shared/config"
+	"github.com/nodeset-org/hyperdrive-constellation/shared/keys"
+	batch "github.com/rocket-pool/batch-query"
+	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/rocket-pool/node-manager-core/log"
+	"github.com/rocket-pool/node-manager-core/wallet"
+)
+
+const (
+	// Description of tracked xrETH yield report transactions
+	reportYieldTxDescription string = "Report xrETH yield"
+
+	// How far ahead of the latest block a yield update's timestamp can be, to allow for clock drift on the signer
+	yieldUpdateMaxClockDrift time.Duration = time.Minute * 5
+
+	// Notification key for rejected yield updates
+	yieldUpdateRejectedKey string = "yieldUpdateRejected"
+)
+
+var (
+	// The yield update failed one of the checks against the chain, so it wasn't submitted
+	ErrYieldUpdateRejected error = errors.New("yield update rejected")
+)
+
+// Report xrETH yield task
+type ReportYieldTask struct {
+	sp        cscommon.IConstellationServiceProvider
+	logger    *slog.Logger
+	ctx       context.Context
+	cfg       *csconfig.ConstellationConfig
+	csMgr     *cscommon.ConstellationManager
+	txTracker *cscommon.TransactionTracker
+}
+
+// Create a report xrETH yield task
+func NewReportYieldTask(ctx context.Context, sp cscommon.IConstellationServiceProvider, logger *log.Logger) *ReportYieldTask {
+	log := logger.With(slog.String(keys.TaskKey, "Report xrETH Yield"))
+	return &ReportYieldTask{
+		ctx:       ctx,
+		sp:        sp,
+		logger:    log,
+		cfg:       sp.GetConfig(),
+		csMgr:     sp.GetConstellationManager(),
+		txTracker: sp.GetTransactionTracker(),
+	}
+}
+
+// Fetch the latest signed xrETH yield update, check it against the oracle and the Operator Distributor, and submit it if it's new and consistent.
+// This relies on the network snapshot task having loaded the contracts.
+func (t *ReportYieldTask) Run(walletStatus *wallet.WalletStatus) error {
+	if !t.cfg.OracleReporterEnabled.Value {
+		return nil
+	}
+	if !wallet.IsWalletReady(*walletStatus) {
+		t.logger.Debug("Wallet isn't ready, skipping xrETH yield report.")
+		return nil
+	}
+
+	// Log
+	t.logger.Info("Checking for a new xrETH yield update...")
+
+	// Wait for the last report to finish so the same update isn't submitted twice
+	for _, pendingTx := range t.txTracker.GetPendingTransactions() {
+		if pendingTx.Description == reportYieldTxDescription {
+			t.logger.Info("Previous yield report transaction is still pending.", slog.String("hash", pendingTx.Hash.Hex()))
+			return nil
+		}
+	}
+
+	// Get the update; the config requires an endpoint when the reporter is enabled
+	update, err := cscommon.FetchYieldUpdate(t.ctx, t.cfg.OracleReporterEndpoint.Value)
+	if err != nil {
+		return err
+	}
+
+	// Get the on-chain state to check it against
+	ec := t.sp.GetEthClient()
+	header, err := ec.HeaderByNumber(t.ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error getting latest block header: %w", err)
+	}
+	chainID, err := ec.ChainID(t.ctx)
+	if err != nil {
+		return fmt.Errorf("error getting chain ID: %w", err)
+	}
+	oracle := t.csMgr.Contracts().PoAConstellationOracle
+	signer, err := cscommon.GetYieldUpdateSigner(update, oracle.Address, chainID)
+	if err != nil {
+		return t.reject(walletStatus, err.Error())
+	}
+	var currentYield *big.Int
+	var lastUpdated *big.Int
+	var oracleError *big.Int
+	var signerIsOracleAdmin bool
+	err = t.sp.GetQueryManager().Query(func(mc *batch.MultiCaller) error {
+		oracle.GetTotalYieldAccrued(mc, &currentYield)
+		oracle.GetLastUpdatedTotalYieldAccrued(mc, &lastUpdated)
+		t.csMgr.Contracts().OperatorDistributor.GetOracleError(mc, &oracleError)
+		t.csMgr.Contracts().Directory.HasRole(mc, &signerIsOracleAdmin, constellation.AdminOracleRole, signer)
+		return nil
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("error getting xrETH oracle state: %w", err)
+	}
+
+	// Make sure it's new
+	blockTime := time.Unix(int64(header.Time), 0)
+	updateTime := update.GetTime()
+	if update.Timestamp == lastUpdated.Int64() {
+		t.logger.Info("Latest yield update has already been reported.", slog.Time("timestamp", updateTime))
+		return nil
+	}
+	if update.Timestamp < lastUpdated.Int64() {
+		return t.reject(walletStatus, fmt.Sprintf("it was signed at %s, before the oracle's last update at %s", updateTime, time.Unix(lastUpdated.Int64(), 0)))
+	}
+	maxAge := time.Duration(t.cfg.OracleReporterMaxAge.Value) * time.Minute
+	if age := blockTime.Sub(updateTime); age > maxAge {
+		return t.reject(walletStatus, fmt.Sprintf("it was signed %s before the latest block, which is older than the max age of %s", age, maxAge))
+	}
+	if updateTime.Sub(blockTime) > yieldUpdateMaxClockDrift {
+		return t.reject(walletStatus, fmt.Sprintf("it was signed at %s, which is after the latest block at %s", updateTime, blockTime))
+	}
+
+	// Make sure it's consistent with the chain
+	if update.ExpectedOracleError.Cmp(oracleError) != 0 {
+		return t.reject(walletStatus, fmt.Sprintf("it expects an oracle error of %s wei but the Operator Distributor's is %s wei", update.ExpectedOracleError.String(), oracleError.String()))
+	}
+	if !signerIsOracleAdmin {
+		return t.reject(walletStatus, fmt.Sprintf("it was signed by %s, which doesn't have the ADMIN_ORACLE_ROLE", signer.Hex()))
+	}
+
+	// Simulate it
+	nodeAddress := walletStatus.Wallet.WalletAddress
+	opts := t.sp.GetSigner().GetTransactor(nodeAddress)
+	opts.Context = t.ctx
+	txInfo, err := oracle.SetTotalYieldAccrued(update.NewTotalYieldAccrued, update.ExpectedOracleError, update.Signature, updateTime, opts)
+	if err != nil {
+		return fmt.Errorf("error creating yield report transaction: %w", err)
+	}
+	if revert := cscommon.GetSimulationRevert(t.ctx, ec, nodeAddress, txInfo); revert != nil {
+		return t.reject(walletStatus, fmt.Sprintf("it failed simulation: %s", revert.Message))
+	}
+
+	// Submit it
+	opts.GasLimit = txInfo.SimulationResult.SafeGasLimit
+	submittedTx, err := t.sp.GetTransactionManager().ExecuteTransaction(txInfo, opts)
+	if err != nil {
+		return fmt.Errorf("error submitting yield report transaction: %w", err)
+	}
+	t.logger.Info("xrETH yield report has been submitted.",
+		slog.String("hash", submittedTx.Hash().Hex()),
+		slog.Float64("previousYield", eth.WeiToEth(currentYield)),
+		slog.Float64("newYield", eth.WeiToEth(update.NewTotalYieldAccrued)),
+		slog.Time("timestamp", updateTime),
+	)
+	t.sp.GetEventBroker().Publish(csapi.Event{
+		Type:        csapi.EventType_TxSubmitted,
+		NodeAddress: nodeAddress,
+		TxHash:      submittedTx.Hash(),
+		Description: reportYieldTxDescription,
+	})
+	t.sp.GetNotifier().Reset(yieldUpdateRejectedKey)
+	err = t.txTracker.TrackTransaction(submittedTx, nodeAddress, reportYieldTxDescription, common.Address{}, time.Time{})
+	if err != nil {
+		return fmt.Errorf("error tracking yield report transaction: %w", err)
+	}
+	return nil
+}
+
+// Log and send a notification about a yield update that won't be submitted
+func (t *ReportYieldTask) reject(walletStatus *wallet.WalletStatus, reason string) error {
+	t.logger.Warn("Rejected xrETH yield update.", slog.String("reason", reason))
+	sendNotification(t.ctx, t.sp, t.logger, yieldUpdateRejectedKey, csapi.Notification{
+		Event:       csapi.NotificationEvent_YieldUpdateRejected,
+		Severity:    csapi.NotificationSeverity_Warning,
+		Title:       "xrETH yield update rejected",
+		Message:     fmt.Sprintf("The latest xrETH yield update wasn't submitted to the oracle because %s.", reason),
+		NodeAddress: walletStatus.Address.NodeAddress,
+	})
+	return fmt.Errorf("%w: %s", ErrYieldUpdateRejected, reason)
+}
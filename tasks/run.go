@@ -2,13 +2,18 @@ package cstasks
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/fatih/color"
 	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	csconfig "github.com/nodeset-org/hyperdrive-constellation/shared/config"
 	"github.com/nodeset-org/hyperdrive-constellation/shared/keys"
 	"github.com/nodeset-org/hyperdrive-daemon/shared/types/api"
 	"github.com/rocket-pool/node-manager-core/log"
@@ -27,6 +32,13 @@ const (
 	// Time to wait if the tasks loop isn't ready before checking again
 	notReadySleepTime time.Duration = time.Second * 15
 
+	// Number of times to take a network snapshot in one iteration if the previous one is reorged out
+	maxSnapshotAttempts int = 3
+
+	// Notification keys for client desyncs
+	executionClientDesyncKey string = "clientDesync:ec"
+	beaconClientDesyncKey    string = "clientDesync:bn"
+
 	ErrorColor             = color.FgRed
 	WarningColor           = color.FgYellow
 	UpdateDepositDataColor = color.FgHiWhite
@@ -49,15 +61,27 @@ type TaskLoop struct {
 	wg     *sync.WaitGroup
 	csMgr  *cscommon.ConstellationManager
 	rpMgr  *cscommon.RocketPoolManager
+	health *cscommon.HealthTracker
 
 	// Tasks
+	verifyResources       *VerifyResourcesTask
 	createNetworkSnapshot *NetworkSnapshotTask
 	stakeMinipools        *StakeMinipoolsTask
+	monitorTransactions   *MonitorTransactionsTask
 	sendExitData          *SubmitSignedExitsTask
+	checkFeeRecipients    *CheckFeeRecipientsTask
+	monitorFleet          *MonitorFleetTask
+	indexEvents           *IndexEventsTask
+	processMinipools      *ProcessMinipoolsTask
+	reportYield           *ReportYieldTask
 
 	// Internal
-	wasExecutionClientSynced bool
-	wasBeaconClientSynced    bool
+	wasExecutionClientSynced   bool
+	wasBeaconClientSynced      bool
+	executionClientDesyncStart time.Time
+	beaconClientDesyncStart    time.Time
+	nodeAddress                common.Address
+	verifiedResources          *csconfig.MergedResources
 }
 
 func NewTaskLoop(sp cscommon.IConstellationServiceProvider, wg *sync.WaitGroup) *TaskLoop {
@@ -70,9 +94,17 @@ func NewTaskLoop(sp cscommon.IConstellationServiceProvider, wg *sync.WaitGroup)
 		wg:                    wg,
 		csMgr:                 sp.GetConstellationManager(),
 		rpMgr:                 sp.GetRocketPoolManager(),
+		health:                sp.GetHealthTracker(),
+		verifyResources:       NewVerifyResourcesTask(ctx, sp, logger),
 		createNetworkSnapshot: NewNetworkSnapshotTask(ctx, sp, logger),
 		stakeMinipools:        NewStakeMinipoolsTask(ctx, sp, logger),
+		monitorTransactions:   NewMonitorTransactionsTask(ctx, sp, logger),
 		sendExitData:          NewSubmitSignedExitsTask(ctx, sp, logger),
+		checkFeeRecipients:    NewCheckFeeRecipientsTask(ctx, sp, logger),
+		monitorFleet:          NewMonitorFleetTask(ctx, sp, logger),
+		indexEvents:           NewIndexEventsTask(ctx, sp, logger),
+		processMinipools:      NewProcessMinipoolsTask(ctx, sp, logger),
+		reportYield:           NewReportYieldTask(ctx, sp, logger),
 
 		wasExecutionClientSynced: true,
 		wasBeaconClientSynced:    true,
@@ -176,15 +208,22 @@ func (t *TaskLoop) waitUntilReady() (*wallet.WalletStatus, waitUntilReadyResult)
 		if strings.Contains(errMsg, "context canceled") {
 			return nil, waitUntilReadyExit
 		}
+		if t.wasExecutionClientSynced {
+			t.executionClientDesyncStart = time.Now()
+		}
 		t.wasExecutionClientSynced = false
+		t.health.SetExecutionClientSynced(false)
 		t.logger.Error("Execution Client not synced. Waiting for sync...", slog.String(log.ErrorKey, errMsg))
+		t.checkClientDesync("Execution Client", executionClientDesyncKey, t.executionClientDesyncStart, errMsg)
 		return nil, t.sleepAndReturnReadyResult()
 	}
 
 	if !t.wasExecutionClientSynced {
 		t.logger.Info("Execution Client is now synced.")
 		t.wasExecutionClientSynced = true
+		t.sp.GetNotifier().Reset(executionClientDesyncKey)
 	}
+	t.health.SetExecutionClientSynced(true)
 
 	// Check the BC status
 	err = t.sp.WaitBeaconClientSynced(t.ctx, false) // Force refresh the primary / fallback BC status
@@ -194,17 +233,29 @@ func (t *TaskLoop) waitUntilReady() (*wallet.WalletStatus, waitUntilReadyResult)
 			return nil, waitUntilReadyExit
 		}
 		// NOTE: if not synced, it returns an error - so there isn't necessarily an underlying issue
+		if t.wasBeaconClientSynced {
+			t.beaconClientDesyncStart = time.Now()
+		}
 		t.wasBeaconClientSynced = false
+		t.health.SetBeaconNodeSynced(false)
 		t.logger.Error("Beacon Node not synced. Waiting for sync...", slog.String(log.ErrorKey, errMsg))
+		t.checkClientDesync("Beacon Node", beaconClientDesyncKey, t.beaconClientDesyncStart, errMsg)
 		return nil, t.sleepAndReturnReadyResult()
 	}
 
 	if !t.wasBeaconClientSynced {
 		t.logger.Info("Beacon Node is now synced.")
 		t.wasBeaconClientSynced = true
+		t.sp.GetNotifier().Reset(beaconClientDesyncKey)
 	}
+	t.health.SetBeaconNodeSynced(true)
 
-	// Wait for a wallet
+	// Wait for a wallet, only reporting it as not ready if it actually isn't
+	hd := t.sp.GetHyperdriveClient()
+	hdWalletStatus, err := hd.Wallet.Status()
+	if err != nil || !wallet.IsWalletReady(hdWalletStatus.Data.WalletStatus) {
+		t.health.SetWalletReady(false)
+	}
 	walletStatus, err := t.sp.WaitForWallet(t.ctx)
 	if err != nil {
 		errMsg := err.Error()
@@ -214,15 +265,38 @@ func (t *TaskLoop) waitUntilReady() (*wallet.WalletStatus, waitUntilReadyResult)
 	if walletStatus == nil {
 		return nil, waitUntilReadyExit
 	}
+	t.nodeAddress = walletStatus.Address.NodeAddress
+	t.health.SetWalletReady(wallet.IsWalletReady(*walletStatus))
 
-	// Wait for NodeSet registration
+	// Wait for NodeSet registration, only reporting it as unregistered if it actually is
+	registrationResponse, err := hd.NodeSet.GetRegistrationStatus()
+	if err != nil || registrationResponse.Data.Status != api.NodeSetRegistrationStatus_Registered {
+		t.health.SetNodeSetRegistered(false)
+	}
 	if t.sp.WaitForNodeSetRegistration(t.ctx) {
 		return nil, waitUntilReadyExit
 	}
+	t.health.SetNodeSetRegistered(true)
 
 	return walletStatus, waitUntilReadySuccess
 }
 
+// Send a notification if a client has been out of sync for longer than the configured threshold
+func (t *TaskLoop) checkClientDesync(clientName string, key string, desyncStart time.Time, errMsg string) {
+	threshold := time.Duration(t.sp.GetConfig().Notifications.DesyncThreshold.Value) * time.Minute
+	desyncTime := time.Since(desyncStart)
+	if desyncTime < threshold {
+		return
+	}
+	sendNotification(t.ctx, t.sp, t.logger.Logger, key, csapi.Notification{
+		Event:       csapi.NotificationEvent_ClientDesync,
+		Severity:    csapi.NotificationSeverity_Error,
+		Title:       fmt.Sprintf("%s out of sync", clientName),
+		Message:     fmt.Sprintf("Your %s has been out of sync for %s, so the daemon can't stake minipools or upload signed exits: %s", clientName, desyncTime.Round(time.Minute), errMsg),
+		NodeAddress: t.nodeAddress,
+	})
+}
+
 // Sleep on the context for the not-ready sleep time, and return either exit or continue
 // based on whether the context was cancelled.
 func (t *TaskLoop) sleepAndReturnReadyResult() waitUntilReadyResult {
@@ -236,16 +310,51 @@ func (t *TaskLoop) sleepAndReturnReadyResult() waitUntilReadyResult {
 // Runs an iteration of the node tasks.
 // Returns true if the task loop should exit, false if it should continue.
 func (t *TaskLoop) runTasks(walletStatus *wallet.WalletStatus) bool {
+	// Pick up any Constellation contracts that were upgraded since the last iteration
+	if err := t.csMgr.CheckForUpgrades(); err != nil {
+		t.reportTaskError("Check Constellation Contracts", err)
+		return utils.SleepWithCancel(t.ctx, tasksInterval)
+	}
+
+	// Make sure the network settings match the chain before doing anything with them; they only change at startup and
+	// when the settings are reloaded, so they don't need to be checked again until then
+	if t.sp.GetResources() != t.verifiedResources {
+		res, err := t.verifyResources.Run(walletStatus)
+		if err != nil {
+			t.reportTaskError("Verify Network Resources", err)
+			return utils.SleepWithCancel(t.ctx, tasksInterval)
+		}
+		t.verifiedResources = res
+	}
+
 	// Create a network snapshot
 	snapshot, err := t.createNetworkSnapshot.Run(walletStatus)
 	if err != nil {
-		t.logger.Error(err.Error())
+		t.reportTaskError("Network Snapshot", err)
 		return utils.SleepWithCancel(t.ctx, tasksInterval)
 	}
 
-	// Stake minipools that are ready
-	if err := t.stakeMinipools.Run(snapshot); err != nil {
-		t.logger.Error(err.Error())
+	// Stake minipools that are ready, taking a new snapshot if the current one gets reorged out first
+	err = t.stakeMinipools.Run(snapshot)
+	for attempt := 1; errors.Is(err, ErrSnapshotReorged) && attempt < maxSnapshotAttempts; attempt++ {
+		t.logger.Warn("Network snapshot was reorged out, taking a new one", log.Err(err))
+		snapshot, err = t.createNetworkSnapshot.Run(walletStatus)
+		if err != nil {
+			t.reportTaskError("Network Snapshot", err)
+			return utils.SleepWithCancel(t.ctx, tasksInterval)
+		}
+		err = t.stakeMinipools.Run(snapshot)
+	}
+	if err != nil {
+		t.reportTaskError("Minipool Stake", err)
+	}
+	if utils.SleepWithCancel(t.ctx, taskCooldown) {
+		return true
+	}
+
+	// Check on pending transactions, speeding up any that are stuck
+	if err := t.monitorTransactions.Run(snapshot); err != nil {
+		t.reportTaskError("Monitor Transactions", err)
 	}
 	if utils.SleepWithCancel(t.ctx, taskCooldown) {
 		return true
@@ -253,8 +362,59 @@ func (t *TaskLoop) runTasks(walletStatus *wallet.WalletStatus) bool {
 
 	// Submit missing exit messages to the NodeSet server
 	if err := t.sendExitData.Run(snapshot); err != nil {
-		t.logger.Error(err.Error())
+		t.reportTaskError("Submit Signed Exits", err)
+	}
+	if utils.SleepWithCancel(t.ctx, taskCooldown) {
+		return true
+	}
+
+	// Make sure recent proposals paid the correct fee recipient
+	if err := t.checkFeeRecipients.Run(snapshot); err != nil {
+		t.reportTaskError("Check Fee Recipients", err)
+	}
+	if utils.SleepWithCancel(t.ctx, taskCooldown) {
+		return true
+	}
+
+	// Check on the other nodes in the fleet
+	if err := t.monitorFleet.Run(walletStatus); err != nil {
+		t.reportTaskError("Monitor Fleet", err)
+	}
+	if utils.SleepWithCancel(t.ctx, taskCooldown) {
+		return true
+	}
+
+	// Catch the contract event index up to the chain
+	if err := t.indexEvents.Run(); err != nil {
+		t.reportTaskError("Index Contract Events", err)
+	}
+	if utils.SleepWithCancel(t.ctx, taskCooldown) {
+		return true
+	}
+
+	// Process Constellation's next minipool if it's enabled and worth the gas
+	if err := t.processMinipools.Run(walletStatus); err != nil {
+		t.reportTaskError("Process Minipools", err)
+	}
+	if utils.SleepWithCancel(t.ctx, taskCooldown) {
+		return true
+	}
+
+	// Relay the latest xrETH yield update to the oracle if the reporter is enabled
+	if err := t.reportYield.Run(walletStatus); err != nil {
+		t.reportTaskError("Report xrETH Yield", err)
 	}
 
 	return utils.SleepWithCancel(t.ctx, tasksInterval)
 }
+
+// Log an error from one of the tasks and publish it to the event stream
+func (t *TaskLoop) reportTaskError(task string, err error) {
+	t.logger.Error(err.Error())
+	t.sp.GetEventBroker().Publish(csapi.Event{
+		Type:        csapi.EventType_TaskError,
+		NodeAddress: t.nodeAddress,
+		Task:        task,
+		Error:       err.Error(),
+	})
+}
@@ -2,6 +2,7 @@ package cstasks
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"math/big"
@@ -9,11 +10,14 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
 	csconfig "github.com/nodeset-org/hyperdrive-constellation/shared/config"
 	"github.com/nodeset-org/hyperdrive-constellation/shared/keys"
 	"github.com/nodeset-org/hyperdrive-daemon/module-utils/gas"
 	"github.com/nodeset-org/hyperdrive-daemon/module-utils/tx"
+	batch "github.com/rocket-pool/batch-query"
 	"github.com/rocket-pool/node-manager-core/beacon"
 	"github.com/rocket-pool/node-manager-core/eth"
 	"github.com/rocket-pool/node-manager-core/log"
@@ -22,6 +26,11 @@ import (
 	rptypes "github.com/rocket-pool/rocketpool-go/v2/types"
 )
 
+const (
+	// Description of tracked minipool stake transactions
+	stakeTxDescription string = "Stake minipool"
+)
+
 var (
 	oneGwei *big.Int = big.NewInt(1e9)
 )
@@ -31,9 +40,10 @@ type StakeMinipoolsTask struct {
 	sp             cscommon.IConstellationServiceProvider
 	logger         *slog.Logger
 	ctx            context.Context
-	res            *csconfig.MergedResources
+	cfg            *csconfig.ConstellationConfig
 	w              *cscommon.Wallet
 	csMgr          *cscommon.ConstellationManager
+	txTracker      *cscommon.TransactionTracker
 	opts           *bind.TransactOpts
 	gasThreshold   float64
 	maxFee         *big.Int
@@ -54,9 +64,10 @@ func NewStakeMinipoolsTask(ctx context.Context, sp cscommon.IConstellationServic
 		ctx:            ctx,
 		sp:             sp,
 		logger:         log,
-		res:            sp.GetResources(),
+		cfg:            sp.GetConfig(),
 		w:              sp.GetWallet(),
 		csMgr:          sp.GetConstellationManager(),
+		txTracker:      sp.GetTransactionTracker(),
 		gasThreshold:   gasThreshold,
 		maxFee:         maxFee,
 		maxPriorityFee: maxPriorityFee,
@@ -81,45 +92,122 @@ func (t *StakeMinipoolsTask) Run(snapshot *NetworkSnapshot) error {
 		return nil
 	}
 
+	// The snapshot can be several blocks behind the chain, so drop any that have been staked since it was taken
+	minipools, err = t.filterStillPrelaunch(minipools)
+	if err != nil {
+		return err
+	}
+	if len(minipools) == 0 {
+		return nil
+	}
+
 	// Log
 	t.logger.Info(
 		"Minipools are ready for staking.",
 		slog.Int("count", len(minipools)),
 	)
 
-	// Stake minipools
-	txSubmissions := make([]*eth.TransactionSubmission, len(minipools))
-	for i, mp := range minipools {
-		txSubmissions[i], err = t.createStakeMinipoolTx(snapshot, mp)
+	// Prepare the stakes, skipping any minipool that can't be staked so it doesn't hold up the rest
+	errs := []error{}
+	txSubmissions := []*eth.TransactionSubmission{}
+	readyMinipools := []minipool.IMinipool{}
+	for _, mp := range minipools {
+		submission, err := t.createStakeMinipoolTx(snapshot, mp)
 		if err != nil {
 			t.logger.Error(
 				"Error preparing submission to stake minipool",
 				slog.String("minipool", mp.Common().Address.Hex()),
 				log.Err(err),
 			)
-			return err
+			sendNotification(t.ctx, t.sp, t.logger, "stakeFailed:"+mp.Common().Address.Hex(), csapi.Notification{
+				Event:       csapi.NotificationEvent_StakeFailed,
+				Severity:    csapi.NotificationSeverity_Error,
+				Title:       "Minipool stake failed",
+				Message:     fmt.Sprintf("Preparing the stake transaction for minipool %s failed: %s", mp.Common().Address.Hex(), err.Error()),
+				NodeAddress: nodeAddress,
+				Minipool:    mp.Common().Address,
+			})
+			errs = append(errs, err)
+			continue
 		}
+		txSubmissions = append(txSubmissions, submission)
+		readyMinipools = append(readyMinipools, mp)
+	}
+	if len(readyMinipools) == 0 {
+		return errors.Join(errs...)
 	}
 
 	// Stake
-	_, err = t.stakeMinipools(snapshot, txSubmissions, minipools)
+	_, err = t.stakeMinipools(snapshot, txSubmissions, readyMinipools)
+	if errors.Is(err, ErrSnapshotReorged) {
+		// Nothing was submitted, so the caller can take a new snapshot and try again
+		return err
+	}
 	if err != nil {
-		return fmt.Errorf("error staking minipools: %w", err)
+		sendNotification(t.ctx, t.sp, t.logger, "stakeFailed", csapi.Notification{
+			Event:       csapi.NotificationEvent_StakeFailed,
+			Severity:    csapi.NotificationSeverity_Error,
+			Title:       "Minipool stake failed",
+			Message:     fmt.Sprintf("Submitting the stake transactions for %d minipools failed: %s", len(readyMinipools), err.Error()),
+			NodeAddress: nodeAddress,
+		})
+		errs = append(errs, fmt.Errorf("error staking minipools: %w", err))
 	}
 
 	// Return
-	return nil
+	return errors.Join(errs...)
+}
+
+// Check the status of the given minipools at the latest block, returning the ones that are still in prelaunch
+func (t *StakeMinipoolsTask) filterStillPrelaunch(minipools []minipool.IMinipool) ([]minipool.IMinipool, error) {
+	rp := t.sp.GetRocketPoolManager().RocketPool()
+	mpMgr, err := minipool.NewMinipoolManager(rp)
+	if err != nil {
+		return nil, fmt.Errorf("error creating minipool manager binding: %w", err)
+	}
+	addresses := make([]common.Address, len(minipools))
+	for i, mp := range minipools {
+		addresses[i] = mp.Common().Address
+	}
+	latestMinipools, err := mpMgr.CreateMinipoolsFromAddresses(addresses, false, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating minipool bindings: %w", err)
+	}
+	err = rp.BatchQuery(len(latestMinipools), minipoolDetailsBatchSize, func(mc *batch.MultiCaller, i int) error {
+		eth.AddQueryablesToMulticall(mc, latestMinipools[i].Common().Status)
+		return nil
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the latest minipool statuses: %w", err)
+	}
+
+	prelaunchMinipools := []minipool.IMinipool{}
+	for i, mp := range minipools {
+		status := latestMinipools[i].Common().Status.Formatted()
+		if status != rptypes.MinipoolStatus_Prelaunch {
+			t.logger.Info(fmt.Sprintf("Minipool %s is no longer in prelaunch (now %s), skipping it.", addresses[i].Hex(), status))
+			continue
+		}
+		prelaunchMinipools = append(prelaunchMinipools, mp)
+	}
+	return prelaunchMinipools, nil
 }
 
 // Get prelaunch minipools
 func (t *StakeMinipoolsTask) getPrelaunchMinipools(snapshot *NetworkSnapshot) ([]minipool.IMinipool, error) {
 	// Prep data
 	scrubPeriod := snapshot.RocketPoolNetworkSettings.ScrubPeriod
+	launchTimeout := snapshot.RocketPoolNetworkSettings.LaunchTimeout
 	blockTime := time.Unix(int64(snapshot.ExecutionBlockHeader.Time), 0)
 	prelaunchMinipools := []minipool.IMinipool{}
 	for _, mp := range snapshot.ConstellationNode.Minipools {
 		mpCommon := mp.Common()
 		if mpCommon.Status.Formatted() == rptypes.MinipoolStatus_Prelaunch {
+			checkLaunchTimeout(t.ctx, t.sp, t.logger, snapshot.ConstellationNode.NodeAddress, mpCommon.Address, mpCommon.StatusTime.Formatted(), launchTimeout, blockTime)
+			if pendingTx, isPending := t.txTracker.GetPendingTransactionForMinipool(mpCommon.Address); isPending {
+				t.logger.Info(fmt.Sprintf("Minipool %s already has a pending stake transaction (%s).", mpCommon.Address.Hex(), pendingTx.Hash.Hex()))
+				continue
+			}
 			creationTime := mpCommon.StatusTime.Formatted()
 			remainingTime := creationTime.Add(scrubPeriod).Sub(blockTime)
 			if remainingTime < 0 {
@@ -157,7 +245,7 @@ func (t *StakeMinipoolsTask) createStakeMinipoolTx(snapshot *NetworkSnapshot, mp
 	// Get validator deposit data
 	stakeValueWei := snapshot.RocketPoolNetworkSettings.MinipoolStakeValue
 	stakeValueGwei := new(big.Int).Div(stakeValueWei, oneGwei).Uint64()
-	depositData, err := validator.GetDepositData(t.logger, validatorKey, withdrawalCredentials, t.res.GenesisForkVersion, stakeValueGwei, t.res.EthNetworkName)
+	depositData, err := validator.GetDepositData(t.logger, validatorKey, withdrawalCredentials, t.sp.GetResources().GenesisForkVersion, stakeValueGwei, t.sp.GetResources().EthNetworkName)
 	if err != nil {
 		return nil, err
 	}
@@ -165,12 +253,13 @@ func (t *StakeMinipoolsTask) createStakeMinipoolTx(snapshot *NetworkSnapshot, mp
 	// Get the tx info
 	signature := beacon.ValidatorSignature(depositData.Signature)
 	depositDataRoot := common.BytesToHash(depositData.DepositDataRoot)
-	txInfo, err := t.csMgr.SuperNodeAccount.Stake(signature, depositDataRoot, mpCommon.Address, t.opts)
+	txInfo, err := t.csMgr.Contracts().SuperNodeAccount.Stake(signature, depositDataRoot, mpCommon.Address, t.opts)
 	if err != nil {
 		return nil, fmt.Errorf("error estimating the gas required to stake the minipool: %w", err)
 	}
 	if txInfo.SimulationResult.SimulationError != "" {
-		return nil, fmt.Errorf("simulating stake minipool tx for %s failed: %s", mpCommon.Address.Hex(), txInfo.SimulationResult.SimulationError)
+		revert := cscommon.GetSimulationRevert(t.ctx, t.sp.GetEthClient(), t.opts.From, txInfo)
+		return nil, fmt.Errorf("simulating stake minipool tx for %s failed: %s", mpCommon.Address.Hex(), revert.Message)
 	}
 
 	submission, err := eth.CreateTxSubmissionFromInfo(txInfo, nil)
@@ -182,6 +271,18 @@ func (t *StakeMinipoolsTask) createStakeMinipoolTx(snapshot *NetworkSnapshot, mp
 
 // Stake all available minipools
 func (t *StakeMinipoolsTask) stakeMinipools(snapshot *NetworkSnapshot, submissions []*eth.TransactionSubmission, minipools []minipool.IMinipool) (bool, error) {
+	// Use the gas forecast to pick which minipools to stake and what to pay, if enabled
+	if t.cfg.GasStrategyEnabled.Value {
+		submissions, minipools, opts, err := t.planStakesWithForecast(snapshot, submissions, minipools)
+		if err != nil {
+			return false, err
+		}
+		if len(submissions) == 0 {
+			return false, nil
+		}
+		return t.submitStakeTransactions(snapshot, submissions, minipools, opts)
+	}
+
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
@@ -205,9 +306,10 @@ func (t *StakeMinipoolsTask) stakeMinipools(snapshot *NetworkSnapshot, submissio
 
 	// Print the gas info
 	launchTimeout := snapshot.RocketPoolNetworkSettings.LaunchTimeout
-	forceSubmissions := []*eth.TransactionSubmission{}
 	if !gas.PrintAndCheckGasInfoForBatch(submissions, true, t.gasThreshold, t.logger, maxFee) {
 		// Check for the timeout buffers
+		forceSubmissions := []*eth.TransactionSubmission{}
+		forceMinipools := []minipool.IMinipool{}
 		for i, mp := range minipools {
 			mpCommon := mp.Common()
 			prelaunchTime := mpCommon.StatusTime.Formatted()
@@ -221,22 +323,152 @@ func (t *StakeMinipoolsTask) stakeMinipools(snapshot *NetworkSnapshot, submissio
 				slog.String("minipool", mpCommon.Address.Hex()),
 			)
 			forceSubmissions = append(forceSubmissions, submissions[i])
+			forceMinipools = append(forceMinipools, mp)
 		}
 
 		if len(forceSubmissions) == 0 {
 			return false, nil
 		}
 		submissions = forceSubmissions
+		minipools = forceMinipools
 	}
 
-	// Print TX info and wait for them to be included in a block
-	txMgr := t.sp.GetTransactionManager()
-	err := tx.PrintAndWaitForTransactionBatch(t.res.NetworkResources, txMgr, t.logger, submissions, opts)
+	return t.submitStakeTransactions(snapshot, submissions, minipools, opts)
+}
+
+// Submit stake transactions and track them until they're included
+func (t *StakeMinipoolsTask) submitStakeTransactions(snapshot *NetworkSnapshot, submissions []*eth.TransactionSubmission, minipools []minipool.IMinipool, opts *bind.TransactOpts) (bool, error) {
+	// Start from the pending nonce so the TXs don't collide with any tracked ones that are still in the mempool
+	nonce, err := t.sp.GetEthClient().PendingNonceAt(t.ctx, opts.From)
+	if err != nil {
+		return false, fmt.Errorf("error getting pending nonce for node: %w", err)
+	}
+	opts.Nonce = new(big.Int).SetUint64(nonce)
+	if opts.GasTipCap != nil && opts.GasFeeCap != nil && opts.GasTipCap.Cmp(opts.GasFeeCap) > 0 {
+		t.logger.Warn("Max priority fee is higher than max fee, setting max priority fee to max fee.",
+			slog.Float64("maxFee", eth.WeiToGwei(opts.GasFeeCap)),
+			slog.Float64("maxPriorityFee", eth.WeiToGwei(opts.GasTipCap)),
+		)
+		opts.GasTipCap = new(big.Int).Set(opts.GasFeeCap)
+	}
+
+	// Don't act on the snapshot if its block was reorged out while the TXs were being prepared
+	err = checkSnapshotCanonical(t.ctx, t.sp.GetEthClient(), snapshot)
 	if err != nil {
 		return false, err
 	}
 
+	// Submit the TXs one at a time, tracking each as soon as it's out so a failure partway through doesn't leave any
+	// submitted TXs untracked
+	txMgr := t.sp.GetTransactionManager()
+	launchTimeout := snapshot.RocketPoolNetworkSettings.LaunchTimeout
+	txs := make([]*types.Transaction, 0, len(submissions))
+	for i, submission := range submissions {
+		mpCommon := minipools[i].Common()
+		opts.GasLimit = submission.GasLimit
+		submittedTx, err := txMgr.ExecuteTransaction(submission.TxInfo, opts)
+		if err != nil {
+			return len(txs) > 0, fmt.Errorf("error submitting stake transaction for minipool %s: %w", mpCommon.Address.Hex(), err)
+		}
+		txs = append(txs, submittedTx)
+		opts.Nonce.Add(opts.Nonce, common.Big1)
+
+		// Track it so it can be sped up if it gets stuck before the launch timeout
+		deadline := cscommon.GetStakeDueTime(mpCommon.StatusTime.Formatted(), launchTimeout)
+		t.logger.Info(
+			"Stake transaction has been submitted.",
+			slog.String("minipool", mpCommon.Address.Hex()),
+			slog.String("hash", submittedTx.Hash().Hex()),
+		)
+		t.sp.GetEventBroker().Publish(csapi.Event{
+			Type:        csapi.EventType_TxSubmitted,
+			NodeAddress: opts.From,
+			Minipool:    mpCommon.Address,
+			TxHash:      submittedTx.Hash(),
+			Description: stakeTxDescription,
+		})
+		err = t.txTracker.TrackTransaction(submittedTx, opts.From, stakeTxDescription, mpCommon.Address, deadline)
+		if err != nil {
+			return true, fmt.Errorf("error tracking stake transaction for minipool %s: %w", mpCommon.Address.Hex(), err)
+		}
+	}
+	if txWatchUrl := t.sp.GetResources().TxWatchUrl; txWatchUrl != "" {
+		t.logger.Info("You may follow their progress by visiting:")
+		for _, submittedTx := range txs {
+			t.logger.Info(fmt.Sprintf("%s/%s", txWatchUrl, submittedTx.Hash().Hex()))
+		}
+	}
+
 	// Log
-	t.logger.Info("Successfully staked all minipools.")
+	t.logger.Info("Submitted all minipool stakes; their progress will be monitored by the transaction tracker.")
 	return true, nil
 }
+
+// Use the gas forecast to decide which minipools should be staked now, and get the transaction options to stake them with
+func (t *StakeMinipoolsTask) planStakesWithForecast(snapshot *NetworkSnapshot, submissions []*eth.TransactionSubmission, minipools []minipool.IMinipool) ([]*eth.TransactionSubmission, []minipool.IMinipool, *bind.TransactOpts, error) {
+	forecast, err := cscommon.GetGasForecast(t.ctx, t.sp.GetEthClient(), t.cfg)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error getting gas forecast: %w", err)
+	}
+	t.logger.Info("Gas forecast",
+		slog.Float64("currentBaseFee", eth.WeiToGwei(forecast.CurrentBaseFee)),
+		slog.Float64("targetBaseFee", eth.WeiToGwei(forecast.TargetBaseFee)),
+		slog.Float64("priorityFee", eth.WeiToGwei(forecast.SuggestedPriorityFee)),
+		slog.String("expectedWait", forecast.ExpectedWaitForCheapBlock.String()),
+	)
+
+	// Plan each stake
+	scrubPeriod := snapshot.RocketPoolNetworkSettings.ScrubPeriod
+	launchTimeout := snapshot.RocketPoolNetworkSettings.LaunchTimeout
+	now := time.Now()
+	maxFee := big.NewInt(0)
+	readySubmissions := []*eth.TransactionSubmission{}
+	readyMinipools := []minipool.IMinipool{}
+	for i, mp := range minipools {
+		mpCommon := mp.Common()
+		prelaunchTime := mpCommon.StatusTime.Formatted()
+		plan := cscommon.PlanGasSubmission(forecast, prelaunchTime.Add(scrubPeriod), cscommon.GetStakeDueTime(prelaunchTime, launchTimeout), now)
+		if !plan.SubmitNow {
+			t.logger.Info("Waiting to stake minipool for a cheaper base fee.",
+				slog.String("minipool", mpCommon.Address.Hex()),
+				slog.String("reason", plan.Reason),
+				slog.Time("plannedSubmission", plan.PlannedSubmission),
+				slog.Time("deadline", plan.Deadline),
+			)
+			continue
+		}
+		t.logger.Info("Minipool will be staked now.",
+			slog.String("minipool", mpCommon.Address.Hex()),
+			slog.String("reason", plan.Reason),
+		)
+		readySubmissions = append(readySubmissions, submissions[i])
+		readyMinipools = append(readyMinipools, mp)
+		if plan.MaxFeePerGas.Cmp(maxFee) > 0 {
+			maxFee = plan.MaxFeePerGas
+		}
+	}
+
+	// A manually configured max fee or max priority fee always takes priority
+	if t.maxFee != nil && t.maxFee.Uint64() != 0 {
+		maxFee = t.maxFee
+	}
+	maxPriorityFee := forecast.SuggestedPriorityFee
+	if t.maxPriorityFee != nil && t.maxPriorityFee.Uint64() != 0 {
+		maxPriorityFee = t.maxPriorityFee
+	}
+	if maxPriorityFee.Cmp(maxFee) > 0 {
+		t.logger.Warn("Max priority fee is higher than max fee, setting max priority fee to max fee.",
+			slog.Float64("maxFee", eth.WeiToGwei(maxFee)),
+			slog.Float64("maxPriorityFee", eth.WeiToGwei(maxPriorityFee)),
+		)
+		maxPriorityFee = new(big.Int).Set(maxFee)
+	}
+	opts := &bind.TransactOpts{
+		From:      t.opts.From,
+		Signer:    t.opts.Signer,
+		GasFeeCap: maxFee,
+		GasTipCap: maxPriorityFee,
+		Context:   t.ctx,
+	}
+	return readySubmissions, readyMinipools, opts, nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
 	csconfig "github.com/nodeset-org/hyperdrive-constellation/shared/config"
 	"github.com/nodeset-org/hyperdrive-constellation/shared/keys"
 	nscommon "github.com/nodeset-org/nodeset-client-go/common"
@@ -24,7 +25,6 @@ type SubmitSignedExitsTask struct {
 	logger            *slog.Logger
 	ctx               context.Context
 	cfg               *csconfig.ConstellationConfig
-	res               *csconfig.MergedResources
 	w                 *cscommon.Wallet
 	csMgr             *cscommon.ConstellationManager
 	rpMgr             *cscommon.RocketPoolManager
@@ -45,7 +45,6 @@ func NewSubmitSignedExitsTask(ctx context.Context, sp cscommon.IConstellationSer
 		sp:              sp,
 		logger:          log,
 		cfg:             sp.GetConfig(),
-		res:             sp.GetResources(),
 		w:               sp.GetWallet(),
 		csMgr:           sp.GetConstellationManager(),
 		rpMgr:           sp.GetRocketPoolManager(),
@@ -71,7 +70,7 @@ func (t *SubmitSignedExitsTask) Run(snapshot *NetworkSnapshot) error {
 	// Initialize the signed exits cache
 	hd := t.sp.GetHyperdriveClient()
 	if !t.initialized {
-		validatorsResponse, err := hd.NodeSet_Constellation.GetValidators(t.res.DeploymentName)
+		validatorsResponse, err := hd.NodeSet_Constellation.GetValidators(t.sp.GetResources().DeploymentName)
 		if err != nil {
 			return fmt.Errorf("error getting validators from NodeSet: %w", err)
 		}
@@ -85,10 +84,12 @@ func (t *SubmitSignedExitsTask) Run(snapshot *NetworkSnapshot) error {
 		}
 		if validatorsResponse.Data.IncorrectNodeAddress {
 			t.logger.Warn("Your user account has a different node whitelisted for Constellation, can't send signed exits")
+			t.notifyIncorrectNodeAddress(snapshot.ConstellationNode.NodeAddress)
 			return nil
 		}
 		if validatorsResponse.Data.InvalidPermissions {
 			t.logger.Warn("Your user account does not have the correct permissions for Constellation, can't send signed exits")
+			t.notifyInvalidPermissions(snapshot.ConstellationNode.NodeAddress)
 			return nil
 		}
 		for _, validator := range validatorsResponse.Data.Validators {
@@ -101,7 +102,7 @@ func (t *SubmitSignedExitsTask) Run(snapshot *NetworkSnapshot) error {
 
 	// Get the registered address from the server
 	if t.registeredAddress == nil {
-		response, err := hd.NodeSet_Constellation.GetRegisteredAddress(t.res.DeploymentName)
+		response, err := hd.NodeSet_Constellation.GetRegisteredAddress(t.sp.GetResources().DeploymentName)
 		if err != nil {
 			return fmt.Errorf("error getting registered address from NodeSet: %w", err)
 		}
@@ -115,6 +116,7 @@ func (t *SubmitSignedExitsTask) Run(snapshot *NetworkSnapshot) error {
 		}
 		if response.Data.InvalidPermissions {
 			t.logger.Warn("User account does not have the correct permissions for Constellation, can't send signed exits")
+			t.notifyInvalidPermissions(snapshot.ConstellationNode.NodeAddress)
 			return nil
 		}
 		t.registeredAddress = &response.Data.RegisteredAddress
@@ -127,6 +129,7 @@ func (t *SubmitSignedExitsTask) Run(snapshot *NetworkSnapshot) error {
 			slog.String("registeredAddress", registeredAddress.Hex()),
 			slog.String("nodeAddress", snapshot.ConstellationNode.NodeAddress.Hex()),
 		)
+		t.notifyIncorrectNodeAddress(snapshot.ConstellationNode.NodeAddress)
 		return nil
 	}
 
@@ -155,6 +158,13 @@ func (t *SubmitSignedExitsTask) Run(snapshot *NetworkSnapshot) error {
 	// Upload signed exits to NodeSet
 	err = t.uploadSignedExits(eligibleMinipools, exitMessages)
 	if err != nil {
+		sendNotification(t.ctx, t.sp, t.logger, "exitUploadFailed", csapi.Notification{
+			Event:       csapi.NotificationEvent_ExitUploadFailed,
+			Severity:    csapi.NotificationSeverity_Error,
+			Title:       "Signed exit upload failed",
+			Message:     fmt.Sprintf("Uploading signed exit messages for %d minipools to NodeSet failed: %s", len(exitMessages), err.Error()),
+			NodeAddress: snapshot.ConstellationNode.NodeAddress,
+		})
 		return fmt.Errorf("error uploading signed exits: %w", err)
 	}
 
@@ -268,7 +278,7 @@ func (t *SubmitSignedExitsTask) getSignedExits(snapshot *NetworkSnapshot, minipo
 		}
 
 		// Encrypt it
-		encryptedMessage, err := nscommon.EncryptSignedExitMessage(exitMessage, t.res.EncryptionPubkey)
+		encryptedMessage, err := nscommon.EncryptSignedExitMessage(exitMessage, t.sp.GetResources().EncryptionPubkey)
 		if err != nil {
 			t.logger.Warn("Error encrypting signed exit message",
 				slog.String("minipool", mp.Common().Address.Hex()),
@@ -294,7 +304,7 @@ func (t *SubmitSignedExitsTask) getSignedExits(snapshot *NetworkSnapshot, minipo
 // Upload signed exits to NodeSet
 func (t *SubmitSignedExitsTask) uploadSignedExits(eligibleMinipools []minipool.IMinipool, exitMessages []nscommon.EncryptedExitData) error {
 	hd := t.sp.GetHyperdriveClient()
-	uploadResponse, err := hd.NodeSet_Constellation.UploadSignedExits(t.res.DeploymentName, exitMessages)
+	uploadResponse, err := hd.NodeSet_Constellation.UploadSignedExits(t.sp.GetResources().DeploymentName, exitMessages)
 	if err != nil {
 		return fmt.Errorf("error uploading signed exits: %w", err)
 	}
@@ -305,6 +315,7 @@ func (t *SubmitSignedExitsTask) uploadSignedExits(eligibleMinipools []minipool.I
 		return fmt.Errorf("node has not been whitelisted for Constellation usage, can't send signed exits")
 	}
 	if uploadResponse.Data.IncorrectNodeAddress {
+		t.notifyIncorrectNodeAddress(*t.registeredAddress)
 		return fmt.Errorf("your user account has a different node registered for Constellation, can't send signed exits")
 	}
 	if uploadResponse.Data.InvalidValidatorOwner {
@@ -314,6 +325,7 @@ func (t *SubmitSignedExitsTask) uploadSignedExits(eligibleMinipools []minipool.I
 		return fmt.Errorf("one of the exit messages is invalid, can't send signed exits")
 	}
 	if uploadResponse.Data.InvalidPermissions {
+		t.notifyInvalidPermissions(*t.registeredAddress)
 		return fmt.Errorf("your user account does not have the correct permissions to upload signed exit messages for Constellation, can't send signed exits")
 	}
 	if uploadResponse.Data.ExitMessageAlreadyExists {
@@ -321,7 +333,7 @@ func (t *SubmitSignedExitsTask) uploadSignedExits(eligibleMinipools []minipool.I
 		origMessageCount := len(exitMessages)
 
 		// Signed exits were probably submitted manually so update the cache
-		validatorsResponse, err := hd.NodeSet_Constellation.GetValidators(t.res.DeploymentName)
+		validatorsResponse, err := hd.NodeSet_Constellation.GetValidators(t.sp.GetResources().DeploymentName)
 		if err != nil {
 			return fmt.Errorf("error getting validators from NodeSet: %w", err)
 		}
@@ -397,6 +409,34 @@ func (t *SubmitSignedExitsTask) uploadSignedExits(eligibleMinipools []minipool.I
 		t.logger.Info("Validator exit message successfully submitted and stored on the NodeSet server",
 			slog.String("pubkey", pubkey.HexWithPrefix()),
 		)
+		t.sp.GetEventBroker().Publish(csapi.Event{
+			Type:        csapi.EventType_ExitUploaded,
+			NodeAddress: mpCommon.NodeAddress.Get(),
+			Minipool:    mpCommon.Address,
+			Description: pubkey.HexWithPrefix(),
+		})
 	}
 	return nil
 }
+
+// Send a notification that NodeSet has a different node registered for the user account
+func (t *SubmitSignedExitsTask) notifyIncorrectNodeAddress(nodeAddress common.Address) {
+	sendNotification(t.ctx, t.sp, t.logger, string(csapi.NotificationEvent_IncorrectNodeAddress), csapi.Notification{
+		Event:       csapi.NotificationEvent_IncorrectNodeAddress,
+		Severity:    csapi.NotificationSeverity_Error,
+		Title:       "Incorrect node address",
+		Message:     "Your NodeSet user account has a different node registered for Constellation, so signed exits can't be uploaded for this node.",
+		NodeAddress: nodeAddress,
+	})
+}
+
+// Send a notification that the user account doesn't have permission to use Constellation
+func (t *SubmitSignedExitsTask) notifyInvalidPermissions(nodeAddress common.Address) {
+	sendNotification(t.ctx, t.sp, t.logger, string(csapi.NotificationEvent_InvalidPermissions), csapi.Notification{
+		Event:       csapi.NotificationEvent_InvalidPermissions,
+		Severity:    csapi.NotificationSeverity_Error,
+		Title:       "Invalid NodeSet permissions",
+		Message:     "Your NodeSet user account does not have the correct permissions for Constellation, so signed exits can't be uploaded for this node.",
+		NodeAddress: nodeAddress,
+	})
+}
@@ -1,18 +1,53 @@
 package cstasks
 
 import (
+	"context"
+	"fmt"
+	"log/slog"
 	"time"
-)
 
-const (
-	// The fraction of the timeout period to trigger minipool stakes
-	stakeTimeoutSafetyFactor time.Duration = 2
+	"github.com/ethereum/go-ethereum/common"
+	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	"github.com/rocket-pool/node-manager-core/log"
 )
 
 // True if a transaction is due and needs to bypass the gas threshold
 func isTransactionDue(startTime time.Time, minipoolLaunchTimeout time.Duration) (bool, time.Duration) {
-	dueTime := minipoolLaunchTimeout / stakeTimeoutSafetyFactor
-	isDue := time.Since(startTime) > dueTime
-	timeUntilDue := time.Until(startTime.Add(dueTime))
+	timeUntilDue := time.Until(cscommon.GetStakeDueTime(startTime, minipoolLaunchTimeout))
+	isDue := timeUntilDue < 0
 	return isDue, timeUntilDue
 }
+
+// Send a notification, logging any failures instead of interrupting the task that sent it
+func sendNotification(ctx context.Context, sp cscommon.IConstellationServiceProvider, logger *slog.Logger, key string, notification csapi.Notification) {
+	err := sp.GetNotifier().Notify(ctx, key, notification)
+	if err != nil {
+		logger.Warn("Error sending notification",
+			slog.String("event", string(notification.Event)),
+			log.Err(err),
+		)
+	}
+}
+
+// Send a notification if a prelaunch minipool is within the configured warning period of its launch timeout
+func checkLaunchTimeout(ctx context.Context, sp cscommon.IConstellationServiceProvider, logger *slog.Logger, nodeAddress common.Address, minipoolAddress common.Address, prelaunchTime time.Time, launchTimeout time.Duration, blockTime time.Time) {
+	warningPeriod := time.Duration(sp.GetConfig().Notifications.LaunchTimeoutWarning.Value) * time.Hour
+	timeLeft := prelaunchTime.Add(launchTimeout).Sub(blockTime)
+	if timeLeft > warningPeriod {
+		return
+	}
+
+	message := fmt.Sprintf("Minipool %s has %s left until its launch timeout and still hasn't been staked. It can be dissolved if it isn't staked in time.", minipoolAddress.Hex(), timeLeft.Round(time.Minute))
+	if timeLeft < 0 {
+		message = fmt.Sprintf("Minipool %s has passed its launch timeout without being staked, so it can be dissolved.", minipoolAddress.Hex())
+	}
+	sendNotification(ctx, sp, logger, "launchTimeout:"+minipoolAddress.Hex(), csapi.Notification{
+		Event:       csapi.NotificationEvent_LaunchTimeoutApproaching,
+		Severity:    csapi.NotificationSeverity_Error,
+		Title:       "Minipool is approaching its launch timeout",
+		Message:     message,
+		NodeAddress: nodeAddress,
+		Minipool:    minipoolAddress,
+	})
+}
@@ -0,0 +1,64 @@
+package cstasks
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	cscommon "github.com/nodeset-org/hyperdrive-constellation/common"
+	csapi "github.com/nodeset-org/hyperdrive-constellation/shared/api"
+	csconfig "github.com/nodeset-org/hyperdrive-constellation/shared/config"
+	"github.com/nodeset-org/hyperdrive-constellation/shared/keys"
+	"github.com/rocket-pool/node-manager-core/log"
+	"github.com/rocket-pool/node-manager-core/wallet"
+)
+
+const (
+	// Notification key for network settings that don't match the chain
+	resourceMismatchKey string = "resourceMismatch"
+)
+
+// Verify network resources task
+type VerifyResourcesTask struct {
+	sp     cscommon.IConstellationServiceProvider
+	logger *slog.Logger
+	ctx    context.Context
+}
+
+// Create a verify network resources task
+func NewVerifyResourcesTask(ctx context.Context, sp cscommon.IConstellationServiceProvider, logger *log.Logger) *VerifyResourcesTask {
+	log := logger.With(slog.String(keys.TaskKey, "Verify Network Resources"))
+	return &VerifyResourcesTask{
+		ctx:    ctx,
+		sp:     sp,
+		logger: log,
+	}
+}
+
+// Make sure the network settings in use match the addresses the Constellation Directory points to, since the other tasks
+// would stake with the wrong fee recipient or talk to the wrong contracts if they don't. Anything the settings left out
+// is discovered first if it hasn't been yet. Returns the resources that were verified.
+func (t *VerifyResourcesTask) Run(walletStatus *wallet.WalletStatus) (*csconfig.MergedResources, error) {
+	err := t.sp.GetSettingsReloader().DiscoverPending()
+	if err != nil {
+		return nil, err
+	}
+	res := t.sp.GetResources()
+	onChain, err := cscommon.GetOnChainResources(*res.Directory, t.sp.GetEthClient(), t.sp.GetQueryManager())
+	if err != nil {
+		return nil, fmt.Errorf("error getting network resources from the chain: %w", err)
+	}
+	err = onChain.Verify(res.ConstellationResources, res.SmartNodeResources)
+	if err != nil {
+		sendNotification(t.ctx, t.sp, t.logger, resourceMismatchKey, csapi.Notification{
+			Event:       csapi.NotificationEvent_ResourceMismatch,
+			Severity:    csapi.NotificationSeverity_Error,
+			Title:       "Network settings don't match the chain",
+			Message:     fmt.Sprintf("The daemon has stopped running its tasks until the network settings are fixed: %s", err.Error()),
+			NodeAddress: walletStatus.Address.NodeAddress,
+		})
+		return nil, err
+	}
+	t.sp.GetNotifier().Reset(resourceMismatchKey)
+	return res, nil
+}
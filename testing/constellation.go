@@ -15,7 +15,7 @@ import (
 func (m *ConstellationTestManager) Constellation_DepositToRplVault(rplVault contracts.IErc4626Token, amount *big.Int, depositOpts *bind.TransactOpts, owner *bind.TransactOpts) error {
 	// Make some bindings
 	sp := m.node.GetServiceProvider()
-	rp := sp.GetRocketPoolManager().RocketPool
+	rp := sp.GetRocketPoolManager().RocketPool()
 	txMgr := sp.GetTransactionManager()
 	rplContract, err := rp.GetContract(rocketpool.ContractName_RocketTokenRPL)
 	if err != nil {
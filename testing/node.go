@@ -143,7 +143,7 @@ func (n *ConstellationNode) CreateSubNode(hdNode *hdtesting.HyperdriveNode, addr
 	if err != nil {
 		return nil, fmt.Errorf("error creating service provider: %v", err)
 	}
-	csSp, err := cscommon.NewConstellationServiceProviderFromCustomServices(moduleSp, cfg, resources)
+	csSp, err := cscommon.NewConstellationServiceProviderFromCustomServices(moduleSp, cfg, resources, "")
 	if err != nil {
 		return nil, fmt.Errorf("error creating constellation service provider: %v", err)
 	}
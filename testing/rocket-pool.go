@@ -23,7 +23,7 @@ import (
 // Register multiple nodes at once with Rocket Pool
 func (m *ConstellationTestManager) RocketPool_RegisterNodes(timezones []string, opts []*bind.TransactOpts) ([]*node.Node, error) {
 	sp := m.node.GetServiceProvider()
-	rp := sp.GetRocketPoolManager().RocketPool
+	rp := sp.GetRocketPoolManager().RocketPool()
 	txMgr := sp.GetTransactionManager()
 	nodes := make([]*node.Node, len(opts))
 	txInfos := make([]*eth.TransactionInfo, len(opts))
@@ -120,7 +120,7 @@ func (m *ConstellationTestManager) RocketPool_CreateOracleDaoNodesWithDefaults(k
 func (m *ConstellationTestManager) RocketPool_CreateOracleDaoNodes(details []OracleDaoNodeCreationDetails, owner *bind.TransactOpts) ([]*node.Node, error) {
 	// Get some contract bindings
 	sp := m.node.GetServiceProvider()
-	rp := sp.GetRocketPoolManager().RocketPool
+	rp := sp.GetRocketPoolManager().RocketPool()
 	qMgr := sp.GetQueryManager()
 	txMgr := sp.GetTransactionManager()
 	odaoMgr, err := oracle.NewOracleDaoManager(rp)
@@ -266,7 +266,7 @@ func (m *ConstellationTestManager) RocketPool_CreateOracleDaoNodes(details []Ora
 // Mints legacy RPL and sends it to the specified address
 func (m *ConstellationTestManager) RocketPool_MintLegacyRpl(receiver common.Address, amount *big.Int, owner *bind.TransactOpts) (*eth.TransactionInfo, error) {
 	sp := m.node.GetServiceProvider()
-	rp := sp.GetRocketPoolManager().RocketPool
+	rp := sp.GetRocketPoolManager().RocketPool()
 	txMgr := sp.GetTransactionManager()
 	fsrpl, err := rp.GetContract(rocketpool.ContractName_RocketTokenRPLFixedSupply)
 	if err != nil {
@@ -45,6 +45,7 @@ func NewConstellationTestManager() (*ConstellationTestManager, error) {
 		closeTestManager(tm)
 		return nil, fmt.Errorf("error creating Constellation config: %v", err)
 	}
+	csCfg.AdminRoutesEnabled.Value = true // Enable the opt-in admin routes so they can be tested
 
 	// Make the module directory
 	moduleDir := filepath.Join(hdCfg.UserDataPath.Value, hdconfig.ModulesName, csconfig.ModuleName)
@@ -60,7 +61,14 @@ func NewConstellationTestManager() (*ConstellationTestManager, error) {
 		closeTestManager(tm)
 		return nil, fmt.Errorf("error creating service provider: %v", err)
 	}
-	csSp, err := cscommon.NewConstellationServiceProviderFromCustomServices(moduleSp, csCfg, csResources)
+	// Write the network settings to a folder so reloading them can be tested
+	settingsFolder := filepath.Join(moduleDir, settingsFolderName)
+	err = saveTestSettings(settingsFolder, hdCfg.Network.Value, csResources)
+	if err != nil {
+		closeTestManager(tm)
+		return nil, fmt.Errorf("error saving network settings: %v", err)
+	}
+	csSp, err := cscommon.NewConstellationServiceProviderFromCustomServices(moduleSp, csCfg, csResources, settingsFolder)
 	if err != nil {
 		closeTestManager(tm)
 		return nil, fmt.Errorf("error creating constellation service provider: %v", err)
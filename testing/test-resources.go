@@ -1,11 +1,16 @@
 package cstesting
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+
 	"github.com/ethereum/go-ethereum/common"
 	csconfig "github.com/nodeset-org/hyperdrive-constellation/shared/config"
 	hdconfig "github.com/nodeset-org/hyperdrive-daemon/shared/config"
 	"github.com/rocket-pool/node-manager-core/config"
 	snconfig "github.com/rocket-pool/smartnode/v2/shared/config"
+	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -29,6 +34,12 @@ const (
 
 	// Balance batcher address
 	BalanceBatcherAddressString string = "0x0b48aF34f4c854F5ae1A3D587da471FeA45bAD52"
+
+	// The folder in the module directory that the test network settings are saved to
+	settingsFolderName string = "settings"
+
+	// The name of the test network settings file
+	SettingsFilename string = "test.yaml"
 )
 
 // Returns a new ConstellationResources instance with test network values
@@ -56,3 +67,30 @@ func provisionNetworkSettings(networkSettings *config.NetworkSettings) *config.N
 	networkSettings.NetworkResources.BalanceBatcherAddress = common.HexToAddress(BalanceBatcherAddressString)
 	return networkSettings
 }
+
+// Save the test network's settings to a folder the daemon can reload them from
+func saveTestSettings(folder string, network config.Network, resources *csconfig.MergedResources) error {
+	err := os.MkdirAll(folder, 0755)
+	if err != nil {
+		return fmt.Errorf("error creating network settings folder [%s]: %w", folder, err)
+	}
+	return SaveTestSettingsFile(folder, &csconfig.ConstellationSettings{
+		Key:                    network,
+		ConstellationResources: resources.ConstellationResources,
+		SmartNodeResources:     resources.SmartNodeResources,
+	})
+}
+
+// Replace the network settings file in a folder, such as the one a node reloads its settings from
+func SaveTestSettingsFile(folder string, settings *csconfig.ConstellationSettings) error {
+	bytes, err := yaml.Marshal(settings)
+	if err != nil {
+		return fmt.Errorf("error serializing network settings: %w", err)
+	}
+	path := filepath.Join(folder, SettingsFilename)
+	err = os.WriteFile(path, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error saving network settings to [%s]: %w", path, err)
+	}
+	return nil
+}